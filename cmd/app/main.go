@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"net/http"
 	"os"
@@ -13,15 +14,19 @@ import (
 	"cor-events-scheduler/docs"
 	_ "cor-events-scheduler/docs"
 	"cor-events-scheduler/internal/config"
-	"cor-events-scheduler/internal/domain/repositories"
+	"cor-events-scheduler/internal/domain/optimize"
+	"cor-events-scheduler/internal/domain/risk"
+	"cor-events-scheduler/internal/domain/share"
 	"cor-events-scheduler/internal/handlers"
 	"cor-events-scheduler/internal/handlers/middleware"
-	"cor-events-scheduler/internal/infrastructure/db"
+	"cor-events-scheduler/internal/infrastructure/notifier"
+	"cor-events-scheduler/internal/infrastructure/storage"
 	"cor-events-scheduler/internal/metrics"
 	"cor-events-scheduler/internal/services"
 	"cor-events-scheduler/pkg/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -53,10 +58,18 @@ func main() {
 		logger.Fatal("Failed to load config", zap.Error(err))
 	}
 
-	database, err := db.NewDatabase(cfg)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), cfg, os.Args[2:]); err != nil {
+			logger.Fatal("Migration failed", zap.Error(err))
+		}
+		return
+	}
+
+	store, err := storage.Open(context.Background(), cfg)
 	if err != nil {
 		logger.Fatal("Failed to initialize database", zap.Error(err))
 	}
+	logger.Info("Storage initialized", zap.String("driver", cfg.Database.Driver))
 
 	docs.SwaggerInfo.Title = "Event Scheduler API"
 	docs.SwaggerInfo.Description = "Service for managing event schedules with risk analysis and optimization"
@@ -65,18 +78,82 @@ func main() {
 	docs.SwaggerInfo.BasePath = "/api/v1"
 	docs.SwaggerInfo.Schemes = []string{"http", "https"}
 
-	scheduleRepo := repositories.NewScheduleRepository(database)
-	versionRepo := repositories.NewVersionRepository(database)
+	ruleService := services.NewRuleService(store.RuleSets, store.Schedules, logger)
+
+	schedulerMetrics := services.NewSchedulerMetrics(prometheus.DefaultRegisterer)
+	riskAnalyzer := risk.NewAnalyzer(risk.Options{HeavyBlockTypes: cfg.Risk.HeavyBlockTypes})
+	riskService := services.NewRiskService(store.Schedules, riskAnalyzer, schedulerMetrics, logger)
 
-	versionService := services.NewVersionService(versionRepo, scheduleRepo, logger)
+	performerService := services.NewPerformerService(store.Performers, logger)
+	resourceService := services.NewResourceService(store.Resources, logger)
+
+	performerNotifier, err := notifier.New(cfg.Notify, logger)
+	if err != nil {
+		logger.Fatal("Failed to create notifier", zap.Error(err))
+	}
+	notificationService := services.NewNotificationService(performerNotifier, performerService, cfg.Notify.Threshold, cfg.Notify.BatchWindow, logger)
+
+	reminderService := services.NewReminderService(
+		store.Reminders,
+		store.Schedules,
+		performerService,
+		performerNotifier,
+		store.ReminderLock,
+		cfg.Reminder.Offsets,
+		cfg.Reminder.ProducerContact,
+		cfg.Reminder.PollInterval,
+		logger,
+	)
 
 	schedulerService := services.NewSchedulerService(
-		scheduleRepo,
-		versionRepo,
+		store.Schedules,
+		store.Versions,
+		ruleService,
+		riskService,
+		performerService,
+		resourceService,
+		schedulerMetrics,
+		notificationService,
+		reminderService,
 		logger,
 	)
 
-	router := setupRouter(schedulerService, versionService, logger) // Добавляем logger
+	versionService := services.NewVersionService(store.Versions, store.Schedules, ruleService, performerService, resourceService, schedulerService, logger)
+
+	searchService := services.NewSearchService(store.Search, logger)
+
+	optimizerService := services.NewOptimizerService(
+		store.Schedules,
+		ruleService,
+		schedulerService,
+		optimize.NewOptimizer(riskAnalyzer, optimize.Options{}),
+		logger,
+	)
+
+	fitService := services.NewFitService(store.Schedules, schedulerService, logger)
+
+	agendaService := services.NewAgendaService(store.Agenda, logger)
+
+	nowService := services.NewNowService(store.Schedules, logger)
+
+	// Вне режима разработки ссылки для просмотра должны переживать перезапуск
+	// и работать на всех репликах, поэтому ключ обязателен
+	releaseMode := os.Getenv("GIN_MODE") == "release"
+
+	shareSecret := []byte(cfg.Share.Secret)
+	if len(shareSecret) == 0 {
+		if releaseMode {
+			logger.Fatal("APP_SHARE_SECRET must be set when GIN_MODE=release")
+		}
+		shareSecret = make([]byte, 32)
+		if _, err := rand.Read(shareSecret); err != nil {
+			logger.Fatal("Failed to generate share link secret", zap.Error(err))
+		}
+		logger.Warn("APP_SHARE_SECRET is not set; using a random key for development, share links will stop working after restart")
+	}
+	shareService := services.NewShareService(store.ShareLinks, schedulerService, versionService, share.NewSigner(shareSecret), logger)
+
+	router := setupRouter(schedulerService, versionService, searchService, ruleService, riskService, optimizerService, fitService, performerService, resourceService, agendaService, nowService, shareService, reminderService, logger) // Добавляем logger
 
 	docs.SwaggerInfo.Title = "Event Scheduler API"
 	docs.SwaggerInfo.Description = "Service for managing event schedules with risk analysis and optimization"
@@ -86,7 +163,7 @@ func main() {
 	docs.SwaggerInfo.Schemes = []string{"http", "https"}
 
 	// Set Gin mode
-	if os.Getenv("GIN_MODE") == "release" {
+	if releaseMode {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
@@ -101,6 +178,18 @@ func main() {
 		Handler: router,
 	}
 
+	// Рассылка напоминаний останавливается вместе с сервером
+	remindersCtx, stopReminders := context.WithCancel(context.Background())
+	remindersDone := make(chan struct{})
+	go func() {
+		defer close(remindersDone)
+		if len(cfg.Reminder.Offsets) == 0 {
+			logger.Info("Reminders are disabled")
+			return
+		}
+		reminderService.Run(remindersCtx)
+	}()
+
 	// Start server in goroutine
 	go func() {
 		logger.Info("Starting server", zap.String("address", serverAddr))
@@ -122,6 +211,9 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Fatal("Server forced to shutdown", zap.Error(err))
 	}
+	notificationService.Flush(ctx)
+	stopReminders()
+	<-remindersDone
 
 	logger.Info("Server exited properly")
 }
@@ -129,15 +221,28 @@ func main() {
 func setupRouter(
 	schedulerService *services.SchedulerService,
 	versionService *services.VersionService,
+	searchService *services.SearchService,
+	ruleService *services.RuleService,
+	riskService *services.RiskService,
+	optimizerService *services.OptimizerService,
+	fitService *services.FitService,
+	performerService *services.PerformerService,
+	resourceService *services.ResourceService,
+	agendaService *services.AgendaService,
+	nowService *services.NowService,
+	shareService *services.ShareService,
+	reminderService *services.ReminderService,
 	logger *zap.Logger,
 ) *gin.Engine {
 	router := gin.New()
 
-	router.Use(gin.Recovery())
+	router.Use(gin.CustomRecovery(handlers.Recovery))
 	router.Use(middleware.NewLoggingMiddleware(logger))
 	router.Use(middleware.CORSMiddleware())
 	router.Use(middleware.NewMetricsMiddleware())
 
+	router.NoRoute(handlers.NoRoute)
+
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -152,6 +257,14 @@ func setupRouter(
 	url := ginSwagger.URL("http://localhost:8282/swagger/doc.json")
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
 
+	ruleHandler := handlers.NewRuleHandler(ruleService, logger)
+
+	router.GET("/p/:slug", formatterHandler.GetPublicPage)
+
+	shareHandler := handlers.NewShareHandler(shareService, logger)
+	reminderHandler := handlers.NewReminderHandler(reminderService, logger)
+	router.GET("/s/:token", shareHandler.GetSharedPage)
+
 	v1 := router.Group("/api/v1")
 	{
 		schedules := v1.Group("/schedules")
@@ -159,11 +272,79 @@ func setupRouter(
 			handler := handlers.NewSchedulerHandler(schedulerService, logger)
 			schedules.POST("/", handler.CreateSchedule)
 			schedules.GET("/", handler.ListSchedules)
+			schedules.POST("/validate", handler.ValidateSchedule)
 			schedules.GET("/:id", handler.GetSchedule)
 			schedules.PUT("/:id", handler.UpdateSchedule)
 			schedules.DELETE("/:id", handler.DeleteSchedule)
 			schedules.GET("/:id/public", formatterHandler.GetPublicSchedule)
+			schedules.GET("/:id/views/:view", formatterHandler.GetView)
+
+			versionHandler := handlers.NewVersionHandler(versionService, logger)
+			schedules.GET("/:id/versions", versionHandler.GetVersionHistory)
+			schedules.GET("/:id/versions/:version", versionHandler.GetVersion)
+			schedules.GET("/:id/versions/:version/changes", versionHandler.GetVersionChanges)
+			schedules.POST("/:id/versions/:version/restore", versionHandler.RestoreVersion)
+
+			riskHandler := handlers.NewRiskHandler(riskService, logger)
+			schedules.GET("/:id/risk", riskHandler.GetScheduleRisk)
+
+			optimizerHandler := handlers.NewOptimizerHandler(optimizerService, logger)
+			schedules.POST("/:id/optimize", optimizerHandler.OptimizeSchedule)
+
+			fitHandler := handlers.NewFitHandler(fitService, logger)
+			schedules.POST("/:id/fit", fitHandler.FitSchedule)
+
+			nowHandler := handlers.NewNowHandler(nowService, logger)
+			schedules.GET("/:id/now", nowHandler.GetNow)
+			schedules.GET("/:id/now/stream", nowHandler.StreamNow)
+
+			schedules.POST("/:id/share-links", shareHandler.CreateLink)
+			schedules.GET("/:id/share-links", shareHandler.ListLinks)
+			schedules.DELETE("/:id/share-links/:link_id", shareHandler.RevokeLink)
+			schedules.GET("/:id/reminders", reminderHandler.ListReminders)
+
+			schedules.GET("/:id/rules", ruleHandler.GetScheduleRules)
+			schedules.PUT("/:id/rules", ruleHandler.SaveScheduleRules)
+			schedules.DELETE("/:id/rules", ruleHandler.DeleteScheduleRules)
+		}
+
+		performers := v1.Group("/performers")
+		{
+			handler := handlers.NewPerformerHandler(performerService, logger)
+			performers.POST("", handler.CreatePerformer)
+			performers.GET("", handler.ListPerformers)
+			performers.GET("/:id", handler.GetPerformer)
+			performers.PUT("/:id", handler.UpdatePerformer)
+			performers.DELETE("/:id", handler.DeletePerformer)
+			performers.GET("/:id/itinerary", handler.GetItinerary)
+			performers.GET("/:id/itinerary.ics", handler.GetItineraryCalendar)
+		}
+
+		resources := v1.Group("/resources")
+		{
+			handler := handlers.NewResourceHandler(resourceService, logger)
+			resources.POST("", handler.CreateResource)
+			resources.GET("", handler.ListResources)
+			resources.GET("/:id", handler.GetResource)
+			resources.PUT("/:id", handler.UpdateResource)
+			resources.DELETE("/:id", handler.DeleteResource)
+			resources.GET("/:id/freebusy", handler.GetFreeBusy)
 		}
+
+		v1.GET("/rules", ruleHandler.GetOrganizationRules)
+		v1.PUT("/rules", ruleHandler.SaveOrganizationRules)
+		v1.DELETE("/rules", ruleHandler.DeleteOrganizationRules)
+
+		v1.GET("/views", formatterHandler.ListViews)
+
+		v1.GET("/shared/:token", shareHandler.GetSharedView)
+		v1.GET("/shared/:token/text", shareHandler.GetSharedText)
+
+		agendaHandler := handlers.NewAgendaHandler(agendaService, logger)
+		v1.GET("/agenda", agendaHandler.GetAgenda)
+
+		searchHandler := handlers.NewSearchHandler(searchService, logger)
+		v1.GET("/search", searchHandler.Search)
 	}
 	return router
 }
@@ -0,0 +1,85 @@
+// cmd/app/migrate.go
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"cor-events-scheduler/internal/config"
+	"cor-events-scheduler/internal/infrastructure/db"
+)
+
+const migrateUsage = "usage: main migrate up | down | status | to N"
+
+// runMigrate выполняет подкоманду migrate:
+//
+//	up      — применить все миграции
+//	down    — откатить последнюю миграцию
+//	status  — показать примененные и ожидающие миграции
+//	to N    — привести схему к версии N (0 откатывает все)
+func runMigrate(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	if cfg.Database.Driver == config.DriverMemory {
+		return fmt.Errorf("driver %q has no schema to migrate", cfg.Database.Driver)
+	}
+
+	database, err := db.Open(cfg)
+	if err != nil {
+		return err
+	}
+	if sqlDB, err := database.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	migrator, err := db.NewMigrator(database)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		err = migrator.Up(ctx)
+	case args[0] == "down" && len(args) == 1:
+		err = migrator.Down(ctx)
+	case args[0] == "to" && len(args) == 2:
+		target, convErr := strconv.Atoi(args[1])
+		if convErr != nil || target < 0 {
+			return fmt.Errorf("invalid migration version %q", args[1])
+		}
+		err = migrator.To(ctx, target)
+	case args[0] == "status" && len(args) == 1:
+		// Статус печатается ниже
+	default:
+		return errors.New(migrateUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	return printMigrationStatus(ctx, migrator)
+}
+
+func printMigrationStatus(ctx context.Context, migrator *db.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	return w.Flush()
+}
@@ -3,12 +3,21 @@
 package config
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
+	Risk     RiskConfig
+	Share    ShareConfig
+	Notify   NotifyConfig
+	Reminder ReminderConfig
 }
 
 type ServerConfig struct {
@@ -16,7 +25,18 @@ type ServerConfig struct {
 	Port    string
 }
 
+// Поддерживаемые хранилища
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
 type DatabaseConfig struct {
+	// Driver выбирает хранилище: postgres, sqlite или memory (данные только в памяти процесса)
+	Driver string
+	// Path — путь к файлу базы SQLite
+	Path     string
 	Host     string
 	Port     string
 	User     string
@@ -24,6 +44,61 @@ type DatabaseConfig struct {
 	DBName   string
 }
 
+type RiskConfig struct {
+	// HeavyBlockTypes — типы блоков, вокруг которых нужны длинные техперерывы
+	HeavyBlockTypes []string
+}
+
+type ShareConfig struct {
+	// Secret — ключ подписи ссылок для просмотра; обязателен при
+	// GIN_MODE=release, в режиме разработки без него ключ создается при запуске
+	// и ссылки перестают работать после перезапуска
+	Secret string
+}
+
+// Каналы уведомлений выступающих
+const (
+	NotifyLog      = "log"
+	NotifySMTP     = "smtp"
+	NotifyWebhook  = "webhook"
+	NotifyTelegram = "telegram"
+)
+
+type NotifyConfig struct {
+	// Driver выбирает канал: log (только запись в журнал), smtp, webhook или telegram
+	Driver string
+	// Threshold — смещение выступления, о котором не сообщается
+	Threshold time.Duration
+	// BatchWindow — сколько копить правки расписания перед отправкой уведомлений
+	BatchWindow time.Duration
+	SMTP        SMTPConfig
+	// WebhookURL — адрес, на который отправляются уведомления в JSON
+	WebhookURL string
+	// TelegramToken — токен бота; контакт выступающего — chat_id или @username
+	TelegramToken string
+}
+
+// ReminderOff — значение REMINDER_OFFSETS, выключающее напоминания
+const ReminderOff = "off"
+
+type ReminderConfig struct {
+	// Offsets — за сколько минут до начала блоков и выступлений напоминать;
+	// значение off выключает напоминания
+	Offsets []int
+	// PollInterval — как часто проверять наступившие напоминания
+	PollInterval time.Duration
+	// ProducerContact — адрес команды площадки для напоминаний о блоках
+	ProducerContact string
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
 func Load() (*Config, error) {
 	viper.AutomaticEnv()
 	viper.SetEnvPrefix("APP")
@@ -31,11 +106,21 @@ func Load() (*Config, error) {
 	// Значения по умолчанию
 	viper.SetDefault("SERVER_ADDRESS", "localhost")
 	viper.SetDefault("SERVER_PORT", "8282")
+	viper.SetDefault("DB_DRIVER", DriverPostgres)
+	viper.SetDefault("DB_PATH", "scheduler.db")
 	viper.SetDefault("DB_HOST", "localhost")
 	viper.SetDefault("DB_PORT", "5432")
 	viper.SetDefault("DB_USER", "postgres")
 	viper.SetDefault("DB_PASSWORD", "your_secure_password")
 	viper.SetDefault("DB_NAME", "mew")
+	viper.SetDefault("RISK_HEAVY_BLOCK_TYPES", "concert,band,cosplay_performance,show")
+	viper.SetDefault("SHARE_SECRET", "")
+	viper.SetDefault("NOTIFY_DRIVER", NotifyLog)
+	viper.SetDefault("NOTIFY_THRESHOLD", "5m")
+	viper.SetDefault("NOTIFY_BATCH_WINDOW", "2m")
+	viper.SetDefault("NOTIFY_SMTP_PORT", "587")
+	viper.SetDefault("REMINDER_OFFSETS", "30,10")
+	viper.SetDefault("REMINDER_POLL_INTERVAL", "30s")
 
 	config := &Config{
 		Server: ServerConfig{
@@ -43,13 +128,83 @@ func Load() (*Config, error) {
 			Port:    viper.GetString("SERVER_PORT"),
 		},
 		Database: DatabaseConfig{
+			Driver:   viper.GetString("DB_DRIVER"),
+			Path:     viper.GetString("DB_PATH"),
 			Host:     viper.GetString("DB_HOST"),
 			Port:     viper.GetString("DB_PORT"),
 			User:     viper.GetString("DB_USER"),
 			Password: viper.GetString("DB_PASSWORD"),
 			DBName:   viper.GetString("DB_NAME"),
 		},
+		Risk: RiskConfig{
+			HeavyBlockTypes: splitList(viper.GetString("RISK_HEAVY_BLOCK_TYPES")),
+		},
+		Share: ShareConfig{
+			Secret: viper.GetString("SHARE_SECRET"),
+		},
+		Notify: NotifyConfig{
+			Driver:      viper.GetString("NOTIFY_DRIVER"),
+			Threshold:   viper.GetDuration("NOTIFY_THRESHOLD"),
+			BatchWindow: viper.GetDuration("NOTIFY_BATCH_WINDOW"),
+			SMTP: SMTPConfig{
+				Host:     viper.GetString("NOTIFY_SMTP_HOST"),
+				Port:     viper.GetString("NOTIFY_SMTP_PORT"),
+				Username: viper.GetString("NOTIFY_SMTP_USERNAME"),
+				Password: viper.GetString("NOTIFY_SMTP_PASSWORD"),
+				From:     viper.GetString("NOTIFY_SMTP_FROM"),
+			},
+			WebhookURL:    viper.GetString("NOTIFY_WEBHOOK_URL"),
+			TelegramToken: viper.GetString("NOTIFY_TELEGRAM_TOKEN"),
+		},
+		Reminder: ReminderConfig{
+			PollInterval:    viper.GetDuration("REMINDER_POLL_INTERVAL"),
+			ProducerContact: viper.GetString("REMINDER_PRODUCER_CONTACT"),
+		},
+	}
+
+	offsets := viper.GetString("REMINDER_OFFSETS")
+	if offsets == ReminderOff {
+		offsets = ""
+	}
+	for _, item := range splitList(offsets) {
+		minutes, err := strconv.Atoi(item)
+		if err != nil || minutes <= 0 {
+			return nil, fmt.Errorf("invalid reminder offset %q: want positive minutes", item)
+		}
+		config.Reminder.Offsets = append(config.Reminder.Offsets, minutes)
+	}
+	if config.Reminder.PollInterval <= 0 {
+		return nil, fmt.Errorf("invalid reminder poll interval %s", config.Reminder.PollInterval)
+	}
+
+	switch config.Database.Driver {
+	case DriverPostgres, DriverSQLite, DriverMemory:
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", config.Database.Driver)
+	}
+
+	notify := config.Notify
+	switch {
+	case notify.Driver == NotifySMTP && (notify.SMTP.Host == "" || notify.SMTP.From == ""):
+		return nil, fmt.Errorf("notify driver %q requires NOTIFY_SMTP_HOST and NOTIFY_SMTP_FROM", notify.Driver)
+	case notify.Driver == NotifyWebhook && notify.WebhookURL == "":
+		return nil, fmt.Errorf("notify driver %q requires NOTIFY_WEBHOOK_URL", notify.Driver)
+	case notify.Driver == NotifyTelegram && notify.TelegramToken == "":
+		return nil, fmt.Errorf("notify driver %q requires NOTIFY_TELEGRAM_TOKEN", notify.Driver)
+	case notify.Driver != NotifyLog && notify.Driver != NotifySMTP && notify.Driver != NotifyWebhook && notify.Driver != NotifyTelegram:
+		return nil, fmt.Errorf("unsupported notify driver %q", notify.Driver)
 	}
 
 	return config, nil
 }
+
+// splitList разбирает список через запятую, пропуская пустые элементы
+func splitList(value string) []string {
+	list := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
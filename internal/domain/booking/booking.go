@@ -0,0 +1,96 @@
+// Package booking считает загрузку общего ресурса по броням из разных
+// расписаний: в какие периоды сколько единиц ресурса занято и когда он свободен.
+package booking
+
+import (
+	"sort"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// Interval — полуинтервал времени [Start, End)
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Period — отрезок времени, в течение которого действуют одни и те же брони
+type Period struct {
+	Interval
+	// Used — сколько единиц ресурса занято бронями периода
+	Used   int                    `json:"used"`
+	Usages []models.ResourceUsage `json:"usages"`
+}
+
+// Timeline делит [from, to) на периоды, в которых набор действующих броней не
+// меняется, и возвращает по порядку периоды хотя бы с одной бронью. Брони
+// нулевой длины не занимают ресурс.
+func Timeline(usages []models.ResourceUsage, from, to time.Time) []Period {
+	var active []models.ResourceUsage
+	boundaries := []time.Time{from, to}
+	for _, usage := range usages {
+		if !usage.StartTime.Before(usage.EndTime) || !usage.StartTime.Before(to) || !from.Before(usage.EndTime) {
+			continue
+		}
+		active = append(active, usage)
+		if usage.StartTime.After(from) {
+			boundaries = append(boundaries, usage.StartTime)
+		}
+		if usage.EndTime.Before(to) {
+			boundaries = append(boundaries, usage.EndTime)
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+
+	var periods []Period
+	for k := 1; k < len(boundaries); k++ {
+		start, end := boundaries[k-1], boundaries[k]
+		if !start.Before(end) {
+			continue
+		}
+
+		period := Period{Interval: Interval{Start: start, End: end}}
+		for _, usage := range active {
+			if !usage.StartTime.After(start) && !usage.EndTime.Before(end) {
+				period.Used += usage.Quantity
+				period.Usages = append(period.Usages, usage)
+			}
+		}
+		if len(period.Usages) > 0 {
+			periods = append(periods, period)
+		}
+	}
+	return periods
+}
+
+// Peak возвращает период с наибольшей загрузкой; ok ложно, если периодов нет
+func Peak(periods []Period) (peak Period, ok bool) {
+	for _, period := range periods {
+		if !ok || period.Used > peak.Used {
+			peak, ok = period, true
+		}
+	}
+	return peak, ok
+}
+
+// Free возвращает части [from, to), не занятые ни одним из периодов Timeline
+func Free(busy []Period, from, to time.Time) []Interval {
+	free := []Interval{}
+	current := from
+	for _, period := range busy {
+		if current.Before(period.Start) {
+			free = append(free, Interval{Start: current, End: period.Start})
+		}
+		if period.End.After(current) {
+			current = period.End
+		}
+	}
+	if current.Before(to) {
+		free = append(free, Interval{Start: current, End: to})
+	}
+	return free
+}
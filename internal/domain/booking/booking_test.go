@@ -0,0 +1,75 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+var base = time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+
+func at(minutes int) time.Time {
+	return base.Add(time.Duration(minutes) * time.Minute)
+}
+
+func usage(name string, from, to, quantity int) models.ResourceUsage {
+	return models.ResourceUsage{BlockName: name, Quantity: quantity, StartTime: at(from), EndTime: at(to)}
+}
+
+func TestTimelineSplitsAtBoundaries(t *testing.T) {
+	usages := []models.ResourceUsage{
+		usage("Открытие", 0, 60, 1),
+		usage("Лекция", 30, 90, 2),
+		usage("Вечер", 120, 180, 1),
+		usage("Пустая", 100, 100, 5),
+	}
+
+	periods := Timeline(usages, at(0), at(150))
+	want := []struct {
+		from, to, used int
+	}{
+		{0, 30, 1},
+		{30, 60, 3},
+		{60, 90, 2},
+		{120, 150, 1},
+	}
+	if len(periods) != len(want) {
+		t.Fatalf("want %d periods, got %+v", len(want), periods)
+	}
+	for i, w := range want {
+		p := periods[i]
+		if !p.Start.Equal(at(w.from)) || !p.End.Equal(at(w.to)) || p.Used != w.used {
+			t.Fatalf("period %d: want %d–%d used %d, got %v–%v used %d",
+				i, w.from, w.to, w.used, p.Start, p.End, p.Used)
+		}
+	}
+	if len(periods[1].Usages) != 2 {
+		t.Fatalf("overlap must list both usages, got %+v", periods[1].Usages)
+	}
+
+	peak, ok := Peak(periods)
+	if !ok || peak.Used != 3 || !peak.Start.Equal(at(30)) {
+		t.Fatalf("unexpected peak %+v", peak)
+	}
+
+	free := Free(periods, at(0), at(150))
+	if len(free) != 1 || !free[0].Start.Equal(at(90)) || !free[0].End.Equal(at(120)) {
+		t.Fatalf("unexpected free intervals %+v", free)
+	}
+}
+
+func TestTimelineWithoutUsages(t *testing.T) {
+	periods := Timeline([]models.ResourceUsage{usage("Раньше", 0, 30, 1)}, at(30), at(60))
+	if len(periods) != 0 {
+		t.Fatalf("usage ending at the range start must not count, got %+v", periods)
+	}
+	if _, ok := Peak(periods); ok {
+		t.Fatal("no peak expected without periods")
+	}
+
+	free := Free(periods, at(30), at(60))
+	if len(free) != 1 || !free[0].Start.Equal(at(30)) || !free[0].End.Equal(at(60)) {
+		t.Fatalf("the whole range must be free, got %+v", free)
+	}
+}
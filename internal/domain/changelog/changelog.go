@@ -0,0 +1,300 @@
+// Package changelog сравнивает два состояния расписания и описывает отличия
+// в терминах предметной области: блок сдвинут, элемент сокращен, блок удален.
+// Блоки и элементы сопоставляются по ID, а не по позиции, поэтому перестановка
+// не выглядит как замена всех полей; служебные поля — ID, порядок, метки
+// времени записи и рассчитанные времена элементов — не сравниваются.
+package changelog
+
+import (
+	"reflect"
+	"slices"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// Объекты изменений
+const (
+	TargetSchedule = "schedule"
+	TargetBlock    = "block"
+	TargetItem     = "item"
+)
+
+// Виды изменений
+const (
+	KindAdded   = "added"
+	KindRemoved = "removed"
+	KindRenamed = "renamed"
+	// KindShifted — блок начинается на Minutes минут позже (или раньше при отрицательном значении)
+	KindShifted = "shifted"
+	// KindResized — длительность изменилась с From на To минут
+	KindResized = "resized"
+	// KindMoved — элемент перенесен из блока From в блок To
+	KindMoved = "moved"
+	// KindUpdated — изменилось поле Field
+	KindUpdated = "updated"
+)
+
+// Change — одно изменение расписания
+type Change struct {
+	Kind    string `json:"kind"`
+	Target  string `json:"target"`
+	BlockID uint   `json:"block_id,omitempty"`
+	ItemID  uint   `json:"item_id,omitempty"`
+	// Name — название блока или элемента; у удаленных — прежнее, у остальных — новое
+	Name string `json:"name"`
+	// Block — название блока, в котором находится элемент
+	Block string `json:"block,omitempty"`
+	// Field — JSON-имя измененного поля для KindUpdated
+	Field   string `json:"field,omitempty"`
+	From    any    `json:"from,omitempty"`
+	To      any    `json:"to,omitempty"`
+	Minutes int    `json:"minutes,omitempty"`
+}
+
+// field — сравниваемое поле: JSON-имя и значение в блоке или элементе
+type field[T any] struct {
+	name  string
+	value func(*T) any
+}
+
+var scheduleFields = []field[models.Schedule]{
+	{"start_date", func(s *models.Schedule) any { return s.StartDate }},
+	{"end_date", func(s *models.Schedule) any { return s.EndDate }},
+	{"slug", func(s *models.Schedule) any { return s.Slug }},
+	{"language", func(s *models.Schedule) any { return s.Language }},
+	{"timezone", func(s *models.Schedule) any { return s.Timezone }},
+}
+
+var blockFields = []field[models.Block]{
+	{"type", func(b *models.Block) any { return b.Type }},
+	{"track", func(b *models.Block) any { return b.Track }},
+	{"tech_break_duration", func(b *models.Block) any { return b.TechBreakDuration }},
+	{"item_gap", func(b *models.Block) any { return b.ItemGap }},
+	{"slack_policy", func(b *models.Block) any { return b.SlackPolicy }},
+	{"visibility", func(b *models.Block) any { return visibility(b.Visibility) }},
+	{"notes", func(b *models.Block) any { return b.Notes }},
+	{"resources", func(b *models.Block) any { return b.Resources }},
+}
+
+var itemFields = []field[models.BlockItem]{
+	{"type", func(i *models.BlockItem) any { return i.Type }},
+	{"description", func(i *models.BlockItem) any { return i.Description }},
+	{"priority", func(i *models.BlockItem) any { return i.Priority }},
+	{"min_duration", func(i *models.BlockItem) any { return i.MinDuration }},
+	{"call_offset", func(i *models.BlockItem) any { return i.CallOffset }},
+	{"visibility", func(i *models.BlockItem) any { return visibility(i.Visibility) }},
+	{"notes", func(i *models.BlockItem) any { return i.Notes }},
+	{"performer_ids", func(i *models.BlockItem) any { return sortedIDs(i.PerformerIDs) }},
+	{"resources", func(i *models.BlockItem) any { return i.Resources }},
+}
+
+// Compare возвращает изменения, превращающие old в new: сначала изменения
+// самого расписания, затем удаленные блоки, затем блоки new по порядку с их
+// элементами, затем удаленные элементы. Элементы добавленного или удаленного
+// целиком блока отдельно не перечисляются, кроме перенесенных в другой блок.
+func Compare(old, new *models.Schedule) []Change {
+	changes := []Change{}
+
+	if old.Name != new.Name {
+		changes = append(changes, Change{Kind: KindRenamed, Target: TargetSchedule, Name: new.Name, From: old.Name, To: new.Name})
+	}
+	for _, f := range scheduleFields {
+		if from, to := f.value(old), f.value(new); !equal(from, to) {
+			changes = append(changes, Change{Kind: KindUpdated, Target: TargetSchedule, Name: new.Name, Field: f.name, From: from, To: to})
+		}
+	}
+
+	blockPairs := match(old.Blocks, new.Blocks,
+		func(b *models.Block) uint { return b.ID },
+		func(b *models.Block) string { return b.Name })
+
+	for i := range old.Blocks {
+		if _, ok := blockPairs.byOld[i]; !ok {
+			block := &old.Blocks[i]
+			changes = append(changes, Change{Kind: KindRemoved, Target: TargetBlock, BlockID: block.ID, Name: block.Name})
+		}
+	}
+
+	// Элементы сопоставляются по всему расписанию, чтобы заметить перенос между блоками
+	oldItems, newItems := flatten(old), flatten(new)
+	itemPairs := match(oldItems, newItems,
+		func(i *placedItem) uint { return i.item.ID },
+		func(i *placedItem) string { return i.block.Name + "\x00" + i.item.Name })
+
+	next := 0
+	for j := range new.Blocks {
+		block := &new.Blocks[j]
+		i, kept := blockPairs.byNew[j]
+		if kept {
+			changes = append(changes, compareBlocks(&old.Blocks[i], block)...)
+		} else {
+			changes = append(changes, Change{Kind: KindAdded, Target: TargetBlock, BlockID: block.ID, Name: block.Name})
+		}
+
+		for ; next < len(newItems) && newItems[next].block == block; next++ {
+			if i, ok := itemPairs.byNew[next]; ok {
+				from, ok := blockPairs.byOld[oldItems[i].blockIndex]
+				moved := !ok || from != j
+				changes = append(changes, compareItems(&oldItems[i], &newItems[next], moved)...)
+			} else if kept {
+				item := newItems[next].item
+				changes = append(changes, Change{Kind: KindAdded, Target: TargetItem, BlockID: block.ID, ItemID: item.ID, Name: item.Name, Block: block.Name})
+			}
+		}
+	}
+
+	for i, placed := range oldItems {
+		_, matched := itemPairs.byOld[i]
+		_, blockKept := blockPairs.byOld[placed.blockIndex]
+		if !matched && blockKept {
+			changes = append(changes, Change{
+				Kind: KindRemoved, Target: TargetItem, BlockID: placed.block.ID, ItemID: placed.item.ID,
+				Name: placed.item.Name, Block: placed.block.Name,
+			})
+		}
+	}
+
+	return changes
+}
+
+func compareBlocks(old, new *models.Block) []Change {
+	var changes []Change
+	change := func(c Change) {
+		c.Target, c.BlockID, c.Name = TargetBlock, new.ID, new.Name
+		changes = append(changes, c)
+	}
+
+	if old.Name != new.Name {
+		change(Change{Kind: KindRenamed, From: old.Name, To: new.Name})
+	}
+	if shift := int(new.StartTime.Sub(old.StartTime) / time.Minute); shift != 0 {
+		change(Change{Kind: KindShifted, From: old.StartTime, To: new.StartTime, Minutes: shift})
+	}
+	if old.Duration != new.Duration {
+		change(Change{Kind: KindResized, From: old.Duration, To: new.Duration, Minutes: new.Duration - old.Duration})
+	}
+	for _, f := range blockFields {
+		if from, to := f.value(old), f.value(new); !equal(from, to) {
+			change(Change{Kind: KindUpdated, Field: f.name, From: from, To: to})
+		}
+	}
+	return changes
+}
+
+func compareItems(old, new *placedItem, moved bool) []Change {
+	var changes []Change
+	change := func(c Change) {
+		c.Target, c.BlockID, c.ItemID, c.Name, c.Block = TargetItem, new.block.ID, new.item.ID, new.item.Name, new.block.Name
+		changes = append(changes, c)
+	}
+
+	if old.item.Name != new.item.Name {
+		change(Change{Kind: KindRenamed, From: old.item.Name, To: new.item.Name})
+	}
+	if moved {
+		change(Change{Kind: KindMoved, From: old.block.Name, To: new.block.Name})
+	}
+	if old.item.Duration != new.item.Duration {
+		change(Change{Kind: KindResized, From: old.item.Duration, To: new.item.Duration, Minutes: new.item.Duration - old.item.Duration})
+	}
+	for _, f := range itemFields {
+		if from, to := f.value(old.item), f.value(new.item); !equal(from, to) {
+			change(Change{Kind: KindUpdated, Field: f.name, From: from, To: to})
+		}
+	}
+	return changes
+}
+
+// placedItem — элемент вместе с блоком, в котором он находится
+type placedItem struct {
+	blockIndex int
+	block      *models.Block
+	item       *models.BlockItem
+}
+
+func flatten(schedule *models.Schedule) []placedItem {
+	var items []placedItem
+	for i := range schedule.Blocks {
+		block := &schedule.Blocks[i]
+		for j := range block.Items {
+			items = append(items, placedItem{blockIndex: i, block: block, item: &block.Items[j]})
+		}
+	}
+	return items
+}
+
+// pairs — сопоставление индексов старого и нового списков
+type pairs struct {
+	byOld map[int]int
+	byNew map[int]int
+}
+
+// match сопоставляет элементы двух списков сначала по ненулевому ID, а
+// оставшиеся — по ключу; так сравниваются и снимки без ID
+func match[T any](old, new []T, id func(*T) uint, key func(*T) string) pairs {
+	p := pairs{byOld: make(map[int]int), byNew: make(map[int]int)}
+	link := func(i, j int) {
+		p.byOld[i] = j
+		p.byNew[j] = i
+	}
+
+	oldByID := make(map[uint]int)
+	for i := range old {
+		if v := id(&old[i]); v != 0 {
+			oldByID[v] = i
+		}
+	}
+	for j := range new {
+		if i, ok := oldByID[id(&new[j])]; ok && id(&new[j]) != 0 {
+			link(i, j)
+		}
+	}
+
+	oldByKey := make(map[string][]int)
+	for i := range old {
+		if _, ok := p.byOld[i]; !ok {
+			k := key(&old[i])
+			oldByKey[k] = append(oldByKey[k], i)
+		}
+	}
+	for j := range new {
+		if _, ok := p.byNew[j]; ok {
+			continue
+		}
+		k := key(&new[j])
+		if candidates := oldByKey[k]; len(candidates) > 0 {
+			link(candidates[0], j)
+			oldByKey[k] = candidates[1:]
+		}
+	}
+	return p
+}
+
+// equal сравнивает значения полей; времена — как моменты, без учета зоны
+func equal(a, b any) bool {
+	if ta, ok := a.(time.Time); ok {
+		tb, _ := b.(time.Time)
+		return ta.Equal(tb)
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	// Пустой и отсутствующий списки не различаются
+	if va.Kind() == reflect.Slice && va.Len() == 0 && vb.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// visibility возвращает видимость с учетом значения по умолчанию
+func visibility(v string) string {
+	if v == "" {
+		return models.VisibilityPublic
+	}
+	return v
+}
+
+func sortedIDs(ids []uint) []uint {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	return sorted
+}
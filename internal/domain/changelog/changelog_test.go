@@ -0,0 +1,143 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+)
+
+func TestCompareMatchesByID(t *testing.T) {
+	old := domaintest.NewSavedSchedule("Фестиваль")
+	new := domaintest.NewSavedSchedule("Фестиваль")
+
+	// Блоки поменялись местами, отличия — только в содержании
+	new.Blocks[0], new.Blocks[1] = new.Blocks[1], new.Blocks[0]
+	opening := &new.Blocks[1]
+	opening.StartTime = opening.StartTime.Add(15 * time.Minute)
+	opening.UpdatedAt = time.Now()
+	opening.Order = 7
+	opening.Items[0].Duration = 5
+	opening.Items[0].StartTime = time.Now()
+	opening.Items = opening.Items[:1]
+	new.Blocks[0].Name = "Конкурс косплея"
+	new.Blocks[0].Items = append(new.Blocks[0].Items, models.BlockItem{Name: "Участник 2", Duration: 10})
+	new.Blocks = append(new.Blocks, models.Block{ID: 9, Name: "Вопросы", Duration: 20})
+
+	got := strings.Join(Text(Compare(old, new), models.LanguageEnglish, nil), "\n")
+	want := strings.Join([]string{
+		"Block 'Косплей' renamed to 'Конкурс косплея'",
+		"Item 'Участник 2' added to block 'Конкурс косплея'",
+		"Block 'Открытие' moved 15 min later",
+		"Item 'Приветствие' shortened from 10 to 5 min",
+		"Block 'Вопросы' added",
+		"Item 'Гимн' removed from block 'Открытие'",
+	}, "\n")
+	if got != want {
+		t.Fatalf("unexpected changelog:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestCompareItemMovedBetweenBlocks(t *testing.T) {
+	old := domaintest.NewSavedSchedule("Фестиваль")
+	new := domaintest.NewSavedSchedule("Фестиваль")
+	anthem := new.Blocks[0].Items[1]
+	new.Blocks[0].Items = new.Blocks[0].Items[:1]
+	new.Blocks[1].Items = append(new.Blocks[1].Items, anthem)
+
+	changes := Compare(old, new)
+	if len(changes) != 1 {
+		t.Fatalf("want one change, got %+v", changes)
+	}
+	c := changes[0]
+	if c.Kind != KindMoved || c.ItemID != anthem.ID || c.From != "Открытие" || c.To != "Косплей" {
+		t.Fatalf("unexpected change: %+v", c)
+	}
+	if got := Text(changes, models.LanguageRussian, nil)[0]; got != "Элемент «Гимн» перенесен из блока «Открытие» в блок «Косплей»" {
+		t.Fatalf("unexpected text: %s", got)
+	}
+}
+
+func TestCompareWholeBlocksWithoutTheirItems(t *testing.T) {
+	old := domaintest.NewSavedSchedule("Фестиваль")
+	new := domaintest.NewSavedSchedule("Фестиваль")
+
+	// «Открытие» удалено, но «Гимн» перенесен в «Косплей»; добавлен блок с элементами
+	anthem := new.Blocks[0].Items[1]
+	new.Blocks = new.Blocks[1:]
+	new.Blocks[0].Items = append(new.Blocks[0].Items, anthem)
+	new.Blocks = append(new.Blocks, models.Block{ID: 9, Name: "Группа X", Duration: 40, Items: []models.BlockItem{
+		{ID: 10, Name: "Сет", Duration: 30},
+		{ID: 11, Name: "Бис", Duration: 10},
+	}})
+
+	got := strings.Join(Text(Compare(old, new), models.LanguageEnglish, nil), "\n")
+	want := strings.Join([]string{
+		"Block 'Открытие' removed",
+		"Item 'Гимн' moved from block 'Открытие' to block 'Косплей'",
+		"Block 'Группа X' added",
+	}, "\n")
+	if got != want {
+		t.Fatalf("unexpected changelog:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestCompareFieldsAndTimestamps(t *testing.T) {
+	old := domaintest.NewSavedSchedule("Фестиваль")
+	new := domaintest.NewSavedSchedule("Фестиваль")
+	new.UpdatedAt = time.Now()
+	new.EndDate = new.EndDate.Add(time.Hour)
+	new.Blocks[0].TechBreakDuration = 15
+	new.Blocks[0].Visibility = models.VisibilityPublic // то же, что пустое значение
+	new.Blocks[0].Items[1].Notes = "Фонограмма"
+	new.Blocks[0].Items[1].PerformerIDs = []uint{}
+
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	got := Text(Compare(old, new), models.LanguageRussian, moscow)
+	want := []string{
+		"Расписание: окончание — было 01.04.2024 19:00, стало 01.04.2024 20:00",
+		"Блок «Открытие»: техперерыв, мин — было 10, стало 15",
+		"Элемент «Гимн»: изменено поле «заметки»",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected changelog:\nwant:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestCompareWithoutIDs(t *testing.T) {
+	old := domaintest.NewSchedule("Фестиваль")
+	new := domaintest.NewSchedule("Фестиваль")
+	new.Blocks[1].Duration = 45
+
+	changes := Compare(old, new)
+	if len(changes) != 1 || changes[0].Kind != KindResized || changes[0].Minutes != -15 {
+		t.Fatalf("blocks without IDs must match by name, got %+v", changes)
+	}
+}
+
+func TestItemShifts(t *testing.T) {
+	old := domaintest.NewSavedSchedule("Фестиваль")
+	new := domaintest.NewSavedSchedule("Фестиваль")
+	// Блок «Косплей» начинается на 20 минут позже, а «Приветствие» короче на
+	// 6 минут, и свободное время первого блока распределяется заново: оба его
+	// элемента смещаются на 2 минуты
+	new.Blocks[1].StartTime = new.Blocks[1].StartTime.Add(20 * time.Minute)
+	new.Blocks[1].Items[0].PerformerIDs = []uint{8, 7}
+	new.Blocks[0].Items[0].Duration = 4
+	new.Blocks[1].Items = append(new.Blocks[1].Items, models.BlockItem{Name: "Новый", Duration: 5})
+
+	shifts := ItemShifts(old, new, 5*time.Minute)
+	if len(shifts) != 1 {
+		t.Fatalf("want one shift above the threshold, got %+v", shifts)
+	}
+	s := shifts[0]
+	if s.Name != "Участник 1" || s.To.Sub(s.From) != 20*time.Minute || len(s.PerformerIDs) != 2 || s.PerformerIDs[0] != 7 {
+		t.Fatalf("unexpected shift: %+v", s)
+	}
+
+	if got := ItemShifts(old, new, time.Minute); len(got) != 3 || got[1].Name != "Гимн" || got[1].From.Sub(got[1].To) != 2*time.Minute {
+		t.Fatalf("want the first block items to move with a lower threshold, got %+v", got)
+	}
+}
@@ -0,0 +1,65 @@
+package changelog
+
+import (
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// Shift — элемент, начало которого сместилось
+type Shift struct {
+	BlockID uint   `json:"block_id"`
+	ItemID  uint   `json:"item_id"`
+	Name    string `json:"name"`
+	Block   string `json:"block"`
+	// PerformerIDs — выступающие элемента в новом состоянии
+	PerformerIDs []uint    `json:"performer_ids"`
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+}
+
+// ItemShifts возвращает элементы, которые есть в обоих состояниях и начало
+// которых сместилось больше чем на threshold. Времена элементов
+// рассчитываются заново, поэтому учитываются и сдвиги блоков, и изменения
+// соседних элементов; добавленные и удаленные элементы не считаются.
+func ItemShifts(old, new *models.Schedule, threshold time.Duration) []Shift {
+	oldItems, newItems := flatten(laidOut(old)), flatten(laidOut(new))
+	itemPairs := match(oldItems, newItems,
+		func(i *placedItem) uint { return i.item.ID },
+		func(i *placedItem) string { return i.block.Name + "\x00" + i.item.Name })
+
+	shifts := []Shift{}
+	for j := range newItems {
+		i, ok := itemPairs.byNew[j]
+		if !ok {
+			continue
+		}
+		from, to := oldItems[i].item.StartTime, newItems[j].item.StartTime
+		if delta := to.Sub(from).Abs(); delta <= threshold {
+			continue
+		}
+		placed := newItems[j]
+		shifts = append(shifts, Shift{
+			BlockID:      placed.block.ID,
+			ItemID:       placed.item.ID,
+			Name:         placed.item.Name,
+			Block:        placed.block.Name,
+			PerformerIDs: sortedIDs(placed.item.PerformerIDs),
+			From:         from,
+			To:           to,
+		})
+	}
+	return shifts
+}
+
+// laidOut возвращает копию расписания с рассчитанными временами элементов
+func laidOut(schedule *models.Schedule) *models.Schedule {
+	copied := *schedule
+	copied.Blocks = make([]models.Block, len(schedule.Blocks))
+	for i, block := range schedule.Blocks {
+		block.Items = append([]models.BlockItem{}, block.Items...)
+		block.LayoutItems()
+		copied.Blocks[i] = block
+	}
+	return &copied
+}
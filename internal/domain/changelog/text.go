@@ -0,0 +1,219 @@
+package changelog
+
+import (
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// phrases — формулировки изменений на одном языке
+type phrases struct {
+	quote        func(name string) string
+	schedule     string
+	block        string
+	item         string
+	emptyValue   string
+	timeLayout   string
+	scheduleName string // переименование расписания
+	added        string
+	addedItem    string
+	removed      string
+	removedItem  string
+	renamed      string
+	later        string
+	earlier      string
+	lengthened   string
+	shortened    string
+	moved        string
+	updated      string
+	changed      string
+	fields       map[string]string
+}
+
+var languages = map[string]*phrases{
+	models.LanguageRussian: {
+		quote:        func(name string) string { return "«" + name + "»" },
+		schedule:     "Расписание",
+		block:        "Блок %s",
+		item:         "Элемент %s",
+		emptyValue:   "—",
+		timeLayout:   "02.01.2006 15:04",
+		scheduleName: "Расписание переименовано в %s",
+		added:        "%s добавлен",
+		addedItem:    "%s добавлен в блок %s",
+		removed:      "%s удален",
+		removedItem:  "%s удален из блока %s",
+		renamed:      "%s переименован в %s",
+		later:        "%s сдвинут на %d мин позже",
+		earlier:      "%s сдвинут на %d мин раньше",
+		lengthened:   "%s удлинен с %d до %d мин",
+		shortened:    "%s сокращен с %d до %d мин",
+		moved:        "%s перенесен из блока %s в блок %s",
+		updated:      "%s: %s — было %s, стало %s",
+		changed:      "%s: изменено поле «%s»",
+		fields: map[string]string{
+			"start_date":          "начало",
+			"end_date":            "окончание",
+			"slug":                "адрес страницы",
+			"language":            "язык",
+			"timezone":            "часовой пояс",
+			"type":                "тип",
+			"track":               "трек",
+			"tech_break_duration": "техперерыв, мин",
+			"item_gap":            "интервал между элементами, мин",
+			"slack_policy":        "распределение свободного времени",
+			"visibility":          "видимость",
+			"notes":               "заметки",
+			"resources":           "ресурсы",
+			"description":         "описание",
+			"priority":            "приоритет",
+			"min_duration":        "минимальная длительность, мин",
+			"call_offset":         "сбор до начала, мин",
+			"performer_ids":       "выступающие",
+		},
+	},
+	models.LanguageEnglish: {
+		quote:        func(name string) string { return "'" + name + "'" },
+		schedule:     "Schedule",
+		block:        "Block %s",
+		item:         "Item %s",
+		emptyValue:   "none",
+		timeLayout:   "2006-01-02 15:04",
+		scheduleName: "Schedule renamed to %s",
+		added:        "%s added",
+		addedItem:    "%s added to block %s",
+		removed:      "%s removed",
+		removedItem:  "%s removed from block %s",
+		renamed:      "%s renamed to %s",
+		later:        "%s moved %d min later",
+		earlier:      "%s moved %d min earlier",
+		lengthened:   "%s lengthened from %d to %d min",
+		shortened:    "%s shortened from %d to %d min",
+		moved:        "%s moved from block %s to block %s",
+		updated:      "%s: %s changed from %s to %s",
+		changed:      "%s: %s changed",
+		fields: map[string]string{
+			"start_date":          "start",
+			"end_date":            "end",
+			"slug":                "page address",
+			"language":            "language",
+			"timezone":            "time zone",
+			"type":                "type",
+			"track":               "track",
+			"tech_break_duration": "tech break, min",
+			"item_gap":            "gap between items, min",
+			"slack_policy":        "slack policy",
+			"visibility":          "visibility",
+			"notes":               "notes",
+			"resources":           "resources",
+			"description":         "description",
+			"priority":            "priority",
+			"min_duration":        "minimum duration, min",
+			"call_offset":         "call before start, min",
+			"performer_ids":       "performers",
+		},
+	},
+}
+
+// opaqueFields — поля, значения которых слишком длинны или составны для
+// текста; о них сообщается без значений
+var opaqueFields = map[string]bool{
+	"notes":         true,
+	"description":   true,
+	"resources":     true,
+	"performer_ids": true,
+}
+
+// Supported сообщает, есть ли формулировки на языке language
+func Supported(language string) bool {
+	_, ok := languages[language]
+	return ok
+}
+
+// Text описывает изменения по одному предложению на изменение на языке
+// language (по умолчанию русском); времена выводятся в зоне location
+func Text(changes []Change, language string, location *time.Location) []string {
+	p, ok := languages[language]
+	if !ok {
+		p = languages[models.LanguageRussian]
+	}
+	if location == nil {
+		location = time.UTC
+	}
+
+	lines := make([]string, 0, len(changes))
+	for _, c := range changes {
+		lines = append(lines, p.describe(c, location))
+	}
+	return lines
+}
+
+func (p *phrases) describe(c Change, location *time.Location) string {
+	subject := p.subject(c.Target, c.Name)
+
+	switch c.Kind {
+	case KindAdded:
+		if c.Target == TargetItem {
+			return fmt.Sprintf(p.addedItem, subject, p.quote(c.Block))
+		}
+		return fmt.Sprintf(p.added, subject)
+	case KindRemoved:
+		if c.Target == TargetItem {
+			return fmt.Sprintf(p.removedItem, subject, p.quote(c.Block))
+		}
+		return fmt.Sprintf(p.removed, subject)
+	case KindRenamed:
+		if c.Target == TargetSchedule {
+			return fmt.Sprintf(p.scheduleName, p.quote(fmt.Sprint(c.To)))
+		}
+		// Подлежащее — прежнее название
+		return fmt.Sprintf(p.renamed, p.subject(c.Target, fmt.Sprint(c.From)), p.quote(fmt.Sprint(c.To)))
+	case KindShifted:
+		if c.Minutes < 0 {
+			return fmt.Sprintf(p.earlier, subject, -c.Minutes)
+		}
+		return fmt.Sprintf(p.later, subject, c.Minutes)
+	case KindResized:
+		if c.Minutes < 0 {
+			return fmt.Sprintf(p.shortened, subject, c.From, c.To)
+		}
+		return fmt.Sprintf(p.lengthened, subject, c.From, c.To)
+	case KindMoved:
+		return fmt.Sprintf(p.moved, subject, p.quote(fmt.Sprint(c.From)), p.quote(fmt.Sprint(c.To)))
+	}
+
+	label, ok := p.fields[c.Field]
+	if !ok {
+		label = c.Field
+	}
+	if opaqueFields[c.Field] {
+		return fmt.Sprintf(p.changed, subject, label)
+	}
+	return fmt.Sprintf(p.updated, subject, label, p.value(c.From, location), p.value(c.To, location))
+}
+
+// subject возвращает подлежащее: расписание, блок или элемент с названием
+func (p *phrases) subject(target, name string) string {
+	switch target {
+	case TargetBlock:
+		return fmt.Sprintf(p.block, p.quote(name))
+	case TargetItem:
+		return fmt.Sprintf(p.item, p.quote(name))
+	}
+	return p.schedule
+}
+
+func (p *phrases) value(v any, location *time.Location) string {
+	switch v := v.(type) {
+	case time.Time:
+		return v.In(location).Format(p.timeLayout)
+	case string:
+		if v == "" {
+			return p.emptyValue
+		}
+		return p.quote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
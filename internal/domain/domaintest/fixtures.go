@@ -0,0 +1,109 @@
+package domaintest
+
+import (
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// Тестовые данные, общие для проверок хранилищ и доменных пакетов
+
+// NewSchedule строит расписание из двух блоков с заполненными полями
+func NewSchedule(name string) *models.Schedule {
+	start := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+	schedule := &models.Schedule{
+		Name:      name,
+		StartDate: start,
+		EndDate:   start.Add(6 * time.Hour),
+		Blocks: []models.Block{
+			{
+				Name:              "Открытие",
+				Type:              "opening",
+				Track:             "Главная сцена",
+				StartTime:         start,
+				Duration:          30,
+				TechBreakDuration: 10,
+				ItemGap:           2,
+				SlackPolicy:       models.SlackPolicySpread,
+				Order:             1,
+				Items: []models.BlockItem{
+					{Name: "Приветствие", Type: "speech", Description: "Организатор", Duration: 10, Priority: 2, MinDuration: 5, Order: 1},
+					{Name: "Гимн", Type: "music", Description: "Хор", Duration: 5, Order: 2},
+				},
+			},
+			{
+				Name:      "Косплей",
+				Type:      "contest",
+				StartTime: start.Add(40 * time.Minute),
+				Duration:  60,
+				Order:     2,
+				Items: []models.BlockItem{
+					{Name: "Участник 1", Type: "performance", Description: "Дефиле", Duration: 20, Order: 1},
+				},
+			},
+		},
+		Constraints: []models.BlockConstraint{
+			{Type: models.ConstraintAfter, Block: "Косплей", TargetBlock: "Открытие"},
+			{Type: models.ConstraintMinGap, Block: "Косплей", TargetType: "opening", Minutes: 10},
+		},
+	}
+	for i := range schedule.Blocks {
+		schedule.Blocks[i].LayoutItems()
+	}
+	return schedule
+}
+
+// NewSavedSchedule строит расписание NewSchedule так, будто оно уже сохранено:
+// расписание получает ID 1, блоки и элементы — сквозные ID по порядку
+func NewSavedSchedule(name string) *models.Schedule {
+	schedule := NewSchedule(name)
+	schedule.ID = 1
+	var next uint
+	for i := range schedule.Blocks {
+		next++
+		schedule.Blocks[i].ID = next
+		for j := range schedule.Blocks[i].Items {
+			next++
+			schedule.Blocks[i].Items[j].ID = next
+		}
+	}
+	return schedule
+}
+
+// Festival строит расписание «Фестиваль» с ID 1 от start длиной length. Блоки
+// идут подряд: каждый начинается после техперерыва предыдущего; блоки без ID
+// получают ID и порядок по позиции.
+func Festival(start time.Time, length time.Duration, blocks ...models.Block) *models.Schedule {
+	schedule := &models.Schedule{ID: 1, Name: "Фестиваль", StartDate: start, EndDate: start.Add(length), Blocks: blocks}
+
+	current := start
+	for i := range schedule.Blocks {
+		block := &schedule.Blocks[i]
+		if block.ID == 0 {
+			block.ID = uint(i + 1)
+			block.Order = i + 1
+		}
+		block.StartTime = current
+		current = block.EndTime()
+	}
+	return schedule
+}
+
+// NewPerformer строит выступающего с двумя окнами доступности
+func NewPerformer(name string) *models.Performer {
+	day := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	return &models.Performer{
+		Name:          name,
+		Contact:       "+7 900 000-00-00",
+		BufferMinutes: 30,
+		Availability: []models.PerformerAvailability{
+			{StartTime: day.Add(9 * time.Hour), EndTime: day.Add(13 * time.Hour)},
+			{StartTime: day.Add(15 * time.Hour), EndTime: day.Add(22 * time.Hour)},
+		},
+	}
+}
+
+// NewResource строит ресурс указанной вместимости
+func NewResource(name string, capacity int) *models.Resource {
+	return &models.Resource{Name: name, Type: "equipment", Description: "Общий ресурс", Capacity: capacity}
+}
@@ -0,0 +1,1332 @@
+// Package domaintest содержит общий набор проверок, которому должна
+// соответствовать каждая реализация репозиториев из internal/domain, и
+// тестовые данные, общие для этих проверок и тестов доменных пакетов.
+package domaintest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+)
+
+// Repositories — репозитории одного хранилища, поверх общих данных
+type Repositories struct {
+	Schedules  domain.ScheduleRepository
+	Versions   domain.VersionRepository
+	Search     domain.SearchRepository
+	RuleSets   domain.RuleSetRepository
+	Performers domain.PerformerRepository
+	Resources  domain.ResourceRepository
+	Agenda     domain.AgendaRepository
+	ShareLinks domain.ShareLinkRepository
+	Reminders  domain.ReminderRepository
+}
+
+// Factory создает пустое хранилище для отдельного теста
+type Factory func(t *testing.T) Repositories
+
+// Run запускает все проверки набора на хранилище, созданном factory
+func Run(t *testing.T, factory Factory) {
+	t.Run("CreateAndGet", func(t *testing.T) { testCreateAndGet(t, factory(t)) })
+	t.Run("UpdatePersistsEveryField", func(t *testing.T) { testUpdatePersistsEveryField(t, factory(t)) })
+	t.Run("UpdateAddsAndRemovesChildren", func(t *testing.T) { testUpdateAddsAndRemovesChildren(t, factory(t)) })
+	t.Run("ConstraintsFollowBlocks", func(t *testing.T) { testConstraintsFollowBlocks(t, factory(t)) })
+	t.Run("UpdateIgnoresForeignIDs", func(t *testing.T) { testUpdateIgnoresForeignIDs(t, factory(t)) })
+	t.Run("UpdateMissingSchedule", func(t *testing.T) { testUpdateMissingSchedule(t, factory(t)) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, factory(t)) })
+	t.Run("Slugs", func(t *testing.T) { testSlugs(t, factory(t)) })
+	t.Run("KeysetPagination", func(t *testing.T) { testKeysetPagination(t, factory(t)) })
+	t.Run("Versions", func(t *testing.T) { testVersions(t, factory(t)) })
+	t.Run("UpdateWithVersion", func(t *testing.T) { testUpdateWithVersion(t, factory(t)) })
+	t.Run("SearchFollowsWrites", func(t *testing.T) { testSearchFollowsWrites(t, factory(t)) })
+	t.Run("SearchEscapesSnippets", func(t *testing.T) { testSearchEscapesSnippets(t, factory(t)) })
+	t.Run("RuleSets", func(t *testing.T) { testRuleSets(t, factory(t)) })
+	t.Run("Performers", func(t *testing.T) { testPerformers(t, factory(t)) })
+	t.Run("PerformerAppearances", func(t *testing.T) { testPerformerAppearances(t, factory(t)) })
+	t.Run("Resources", func(t *testing.T) { testResources(t, factory(t)) })
+	t.Run("ResourceUsages", func(t *testing.T) { testResourceUsages(t, factory(t)) })
+	t.Run("Agenda", func(t *testing.T) { testAgenda(t, factory(t)) })
+	t.Run("ShareLinks", func(t *testing.T) { testShareLinks(t, factory(t)) })
+	t.Run("Reminders", func(t *testing.T) { testReminders(t, factory(t)) })
+}
+
+// AssertSameSchedule сравнивает все сохраняемые поля, кроме ID и временных меток записи
+func AssertSameSchedule(t *testing.T, want, got *models.Schedule) {
+	t.Helper()
+
+	if got.Name != want.Name || !got.StartDate.Equal(want.StartDate) || !got.EndDate.Equal(want.EndDate) {
+		t.Fatalf("schedule mismatch: want %q %v-%v, got %q %v-%v",
+			want.Name, want.StartDate, want.EndDate, got.Name, got.StartDate, got.EndDate)
+	}
+	if got.Slug != want.Slug || got.Language != want.Language || got.Timezone != want.Timezone {
+		t.Fatalf("schedule page mismatch: want %q %q %q, got %q %q %q",
+			want.Slug, want.Language, want.Timezone, got.Slug, got.Language, got.Timezone)
+	}
+	if len(got.Blocks) != len(want.Blocks) {
+		t.Fatalf("want %d blocks, got %d", len(want.Blocks), len(got.Blocks))
+	}
+
+	for i := range want.Blocks {
+		wb, gb := want.Blocks[i], got.Blocks[i]
+		if gb.Name != wb.Name || gb.Type != wb.Type || gb.Track != wb.Track || gb.Duration != wb.Duration ||
+			gb.TechBreakDuration != wb.TechBreakDuration || gb.ItemGap != wb.ItemGap ||
+			gb.SlackPolicy != wb.SlackPolicy || gb.Visibility != wb.Visibility || gb.Notes != wb.Notes || gb.Order != wb.Order ||
+			!gb.StartTime.Equal(wb.StartTime) || fmt.Sprint(gb.Resources) != fmt.Sprint(wb.Resources) {
+			t.Fatalf("block %d mismatch:\nwant %+v\n got %+v", i, wb, gb)
+		}
+		if len(gb.Items) != len(wb.Items) {
+			t.Fatalf("block %d: want %d items, got %d", i, len(wb.Items), len(gb.Items))
+		}
+		for j := range wb.Items {
+			wi, gi := wb.Items[j], gb.Items[j]
+			if gi.Name != wi.Name || gi.Type != wi.Type || gi.Description != wi.Description ||
+				gi.Duration != wi.Duration || gi.Priority != wi.Priority ||
+				gi.MinDuration != wi.MinDuration || gi.Order != wi.Order || gi.CallOffset != wi.CallOffset ||
+				gi.Visibility != wi.Visibility || gi.Notes != wi.Notes ||
+				!gi.StartTime.Equal(wi.StartTime) || !gi.EndTime.Equal(wi.EndTime) ||
+				fmt.Sprint(models.SortedIDs(gi.PerformerIDs)) != fmt.Sprint(models.SortedIDs(wi.PerformerIDs)) ||
+				fmt.Sprint(gi.Resources) != fmt.Sprint(wi.Resources) {
+				t.Fatalf("block %d item %d mismatch:\nwant %+v\n got %+v", i, j, wi, gi)
+			}
+		}
+	}
+
+	if len(got.Constraints) != len(want.Constraints) {
+		t.Fatalf("want %d constraints, got %d", len(want.Constraints), len(got.Constraints))
+	}
+	for i := range want.Constraints {
+		wc, gc := want.Constraints[i], got.Constraints[i]
+		if gc.Type != wc.Type || gc.BlockID != wc.BlockID || gc.Block != wc.Block ||
+			gc.TargetBlockID != wc.TargetBlockID || gc.TargetBlock != wc.TargetBlock ||
+			gc.TargetType != wc.TargetType || gc.Minutes != wc.Minutes {
+			t.Fatalf("constraint %d mismatch:\nwant %+v\n got %+v", i, wc, gc)
+		}
+	}
+}
+
+func testCreateAndGet(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	schedule := NewSchedule("Create")
+
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if schedule.ID == 0 || schedule.CreatedAt.IsZero() {
+		t.Fatalf("create must assign ID and timestamps, got %+v", schedule)
+	}
+	for i, block := range schedule.Blocks {
+		if block.ID == 0 || block.ScheduleID != schedule.ID || block.Order != i+1 {
+			t.Fatalf("block %d not assigned: %+v", i, block)
+		}
+		for j, item := range block.Items {
+			if item.ID == 0 || item.BlockID != block.ID || item.Order != j+1 {
+				t.Fatalf("block %d item %d not assigned: %+v", i, j, item)
+			}
+		}
+	}
+	for i, constraint := range schedule.Constraints {
+		if constraint.ID == 0 || constraint.ScheduleID != schedule.ID {
+			t.Fatalf("constraint %d not assigned: %+v", i, constraint)
+		}
+	}
+
+	got, err := repos.Schedules.GetByID(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	AssertSameSchedule(t, schedule, got)
+
+	if _, err := repos.Schedules.GetByID(ctx, schedule.ID+1000); err == nil {
+		t.Fatal("get of a missing schedule must fail")
+	}
+}
+
+func testUpdatePersistsEveryField(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	schedule := NewSchedule("Before")
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	schedule.Name = "After"
+	schedule.Slug = "after"
+	schedule.Language = models.LanguageEnglish
+	schedule.Timezone = "Europe/Moscow"
+	schedule.EndDate = schedule.EndDate.Add(time.Hour)
+	block := &schedule.Blocks[0]
+	block.Name = "Церемония"
+	block.Type = "ceremony"
+	block.Track = "Малая сцена"
+	block.StartTime = block.StartTime.Add(5 * time.Minute)
+	block.Duration = 35
+	block.TechBreakDuration = 15
+	block.ItemGap = 1
+	block.SlackPolicy = models.SlackPolicyStart
+	block.Visibility = models.VisibilityPerformers
+	block.Notes = "Проверить микрофоны"
+	item := &block.Items[1]
+	item.Name = "Гимн России"
+	item.Type = "video"
+	item.Description = "Запись"
+	item.Duration = 7
+	item.Priority = 1
+	item.MinDuration = 3
+	item.CallOffset = 20
+	item.Visibility = models.VisibilityCrew
+	item.Notes = "Звук с флешки"
+	block.LayoutItems()
+
+	if err := repos.Schedules.Update(ctx, schedule); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	got, err := repos.Schedules.GetByID(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	AssertSameSchedule(t, schedule, got)
+}
+
+func testUpdateAddsAndRemovesChildren(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	schedule := NewSchedule("Children")
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	keptItemID := schedule.Blocks[0].Items[0].ID
+
+	// Убираем второй блок и второй элемент первого блока, добавляем новый блок и элемент
+	schedule.Blocks[0].Items = schedule.Blocks[0].Items[:1]
+	schedule.Blocks[0].Items = append(schedule.Blocks[0].Items, models.BlockItem{
+		Name: "Новый", Type: "speech", Description: "Добавлен", Duration: 5, Order: 2,
+	})
+	schedule.Blocks = []models.Block{schedule.Blocks[0], {
+		Name: "Финал", Type: "closing", StartTime: schedule.StartDate.Add(2 * time.Hour), Duration: 20, Order: 2,
+		Items: []models.BlockItem{{Name: "Награждение", Type: "ceremony", Duration: 20, Order: 1}},
+	}}
+	// Ограничения заменяются целиком
+	schedule.Constraints = []models.BlockConstraint{
+		{Type: models.ConstraintAdjacent, Block: "Финал", TargetBlock: "Открытие"},
+	}
+
+	if err := repos.Schedules.Update(ctx, schedule); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if schedule.Constraints[0].ID == 0 || schedule.Constraints[0].ScheduleID != schedule.ID {
+		t.Fatalf("constraint not assigned: %+v", schedule.Constraints[0])
+	}
+	for i, block := range schedule.Blocks {
+		if block.ID == 0 {
+			t.Fatalf("block %d must get an ID", i)
+		}
+		for j, item := range block.Items {
+			if item.ID == 0 || item.BlockID != block.ID {
+				t.Fatalf("block %d item %d not assigned: %+v", i, j, item)
+			}
+		}
+	}
+	if schedule.Blocks[0].Items[0].ID != keptItemID {
+		t.Fatal("existing item must keep its ID")
+	}
+
+	got, err := repos.Schedules.GetByID(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	AssertSameSchedule(t, schedule, got)
+}
+
+func testConstraintsFollowBlocks(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	schedule := NewSchedule("Constraints")
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Ограничения, заданные по имени, получают ID блоков
+	opening, cosplay := schedule.Blocks[0].ID, schedule.Blocks[1].ID
+	if c := schedule.Constraints[0]; c.BlockID != cosplay || c.TargetBlockID != opening {
+		t.Fatalf("constraint must reference blocks %d and %d by ID, got %+v", cosplay, opening, c)
+	}
+	if c := schedule.Constraints[1]; c.BlockID != cosplay || c.TargetBlockID != 0 {
+		t.Fatalf("constraint on a block type must reference only its block, got %+v", c)
+	}
+
+	// Переименование блока не ломает ссылку по ID, а имя в ограничении обновляется;
+	// новый блок без ID задается по имени
+	schedule.Blocks[0].Name = "Церемония открытия"
+	schedule.Blocks = append(schedule.Blocks, models.Block{
+		Name: "Финал", Type: "closing", StartTime: schedule.StartDate.Add(3 * time.Hour), Duration: 20, Order: 3,
+		Items: []models.BlockItem{{Name: "Награждение", Type: "ceremony", Duration: 20, Order: 1}},
+	})
+	schedule.Constraints = append(schedule.Constraints,
+		models.BlockConstraint{Type: models.ConstraintAfter, Block: "Финал", TargetBlockID: cosplay})
+
+	if err := repos.Schedules.Update(ctx, schedule); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if c := schedule.Constraints[0]; c.TargetBlockID != opening || c.TargetBlock != "Церемония открытия" {
+		t.Fatalf("renamed target must keep its ID and get the new name, got %+v", c)
+	}
+	if c := schedule.Constraints[2]; c.BlockID != schedule.Blocks[2].ID || c.TargetBlock != "Косплей" {
+		t.Fatalf("constraint on a new block must get its ID, got %+v", c)
+	}
+
+	got, err := repos.Schedules.GetByID(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	AssertSameSchedule(t, schedule, got)
+}
+
+func testUpdateIgnoresForeignIDs(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	first := NewSchedule("First")
+	second := NewSchedule("Second")
+	for _, s := range []*models.Schedule{first, second} {
+		if err := repos.Schedules.Create(ctx, s); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	// Блок с ID из другого расписания должен стать новой записью, а не перезаписать чужой
+	foreignID := second.Blocks[0].ID
+	first.Blocks[0].ID = foreignID
+	first.Blocks[0].Name = "Чужой"
+	if err := repos.Schedules.Update(ctx, first); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if first.Blocks[0].ID == foreignID {
+		t.Fatal("foreign block ID must not be reused")
+	}
+
+	got, err := repos.Schedules.GetByID(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	AssertSameSchedule(t, second, got)
+}
+
+func testUpdateMissingSchedule(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	schedule := NewSchedule("Ghost")
+	schedule.ID = 999999
+
+	if err := repos.Schedules.Update(ctx, schedule); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("update of a missing schedule must fail with ErrNotFound, got %v", err)
+	}
+
+	// Неудачное обновление не должно ничего создать
+	summaries, err := repos.Schedules.ListSummaries(ctx, 0, 10)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(summaries) != 0 {
+		t.Fatalf("failed update left %d schedules behind", len(summaries))
+	}
+}
+
+func testDelete(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	schedule := NewSchedule("Delete")
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if err := repos.Schedules.Delete(ctx, schedule.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := repos.Schedules.GetByID(ctx, schedule.ID); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("deleted schedule must not be found, got %v", err)
+	}
+	if err := repos.Schedules.Delete(ctx, schedule.ID); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("second delete must fail with ErrNotFound, got %v", err)
+	}
+}
+
+func testSlugs(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	festival := NewSchedule("Фестиваль")
+	festival.Slug = "festival"
+	if err := repos.Schedules.Create(ctx, festival); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	// Расписания без адреса не конфликтуют друг с другом
+	for _, name := range []string{"Без адреса 1", "Без адреса 2"} {
+		if err := repos.Schedules.Create(ctx, NewSchedule(name)); err != nil {
+			t.Fatalf("create without slug: %v", err)
+		}
+	}
+
+	got, err := repos.Schedules.GetBySlug(ctx, "festival")
+	if err != nil {
+		t.Fatalf("get by slug: %v", err)
+	}
+	if got.ID != festival.ID {
+		t.Fatalf("want schedule %d, got %d", festival.ID, got.ID)
+	}
+	AssertSameSchedule(t, festival, got)
+	for _, slug := range []string{"missing", ""} {
+		if _, err := repos.Schedules.GetBySlug(ctx, slug); !errors.Is(err, utils.ErrNotFound) {
+			t.Fatalf("slug %q must not be found, got %v", slug, err)
+		}
+	}
+
+	duplicate := NewSchedule("Дубликат")
+	duplicate.Slug = "festival"
+	if err := repos.Schedules.Create(ctx, duplicate); !errors.Is(err, utils.ErrConflict) {
+		t.Fatalf("create with a taken slug must fail with ErrConflict, got %v", err)
+	}
+	duplicate.Slug = "other"
+	if err := repos.Schedules.Create(ctx, duplicate); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	duplicate.Slug = "festival"
+	if err := repos.Schedules.Update(ctx, duplicate); !errors.Is(err, utils.ErrConflict) {
+		t.Fatalf("update to a taken slug must fail with ErrConflict, got %v", err)
+	}
+
+	// Адрес удаленного расписания можно занять снова
+	if err := repos.Schedules.Delete(ctx, festival.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := repos.Schedules.Update(ctx, duplicate); err != nil {
+		t.Fatalf("update to a released slug: %v", err)
+	}
+	got, err = repos.Schedules.GetBySlug(ctx, "festival")
+	if err != nil || got.ID != duplicate.ID {
+		t.Fatalf("released slug must point to schedule %d, got %+v, %v", duplicate.ID, got, err)
+	}
+}
+
+func testKeysetPagination(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	var created []*models.Schedule
+	for i := 0; i < 5; i++ {
+		schedule := NewSchedule(fmt.Sprintf("Page %d", i+1))
+		if err := repos.Schedules.Create(ctx, schedule); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		created = append(created, schedule)
+	}
+
+	first, err := repos.Schedules.ListSummaries(ctx, 0, 2)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(first) != 2 || first[0].ID != created[0].ID || first[1].ID != created[1].ID {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+	if first[0].BlockCount != 2 || first[0].TotalDuration != 100 || first[0].Name != "Page 1" {
+		t.Fatalf("unexpected summary: %+v", first[0])
+	}
+
+	// Вставка во время обхода не сдвигает следующую страницу
+	if err := repos.Schedules.Create(ctx, NewSchedule("Late")); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	second, err := repos.Schedules.List(ctx, first[1].ID, 2)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(second) != 2 || second[0].ID != created[2].ID || second[1].ID != created[3].ID {
+		t.Fatalf("unexpected second page: %+v", second)
+	}
+	AssertSameSchedule(t, created[2], &second[0])
+}
+
+func testVersions(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	schedule := NewSchedule("Versions")
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if _, err := repos.Versions.GetLatestVersion(ctx, schedule.ID); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("latest version of a schedule without versions must fail with ErrNotFound, got %v", err)
+	}
+
+	restoredFrom := 1
+	for i := 1; i <= 3; i++ {
+		version := &models.ScheduleVersion{
+			ScheduleID: schedule.ID,
+			Version:    i,
+			Data:       []byte(fmt.Sprintf(`{"name":"v%d"}`, i)),
+			Changes:    fmt.Sprintf("change %d", i),
+			CreatedAt:  time.Now(),
+		}
+		if i == 3 {
+			version.RestoredFrom = &restoredFrom
+		}
+		if err := repos.Versions.CreateVersion(ctx, version); err != nil {
+			t.Fatalf("create version: %v", err)
+		}
+		if version.ID == 0 {
+			t.Fatal("create version must assign ID")
+		}
+	}
+
+	latest, err := repos.Versions.GetLatestVersion(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("latest version: %v", err)
+	}
+	if latest.Version != 3 || latest.RestoredFrom == nil || *latest.RestoredFrom != 1 {
+		t.Fatalf("unexpected latest version: %+v", latest)
+	}
+
+	versions, err := repos.Versions.GetVersionsByScheduleID(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("versions: %v", err)
+	}
+	if len(versions) != 3 || versions[0].Version != 3 || versions[2].Version != 1 {
+		t.Fatalf("versions must be ordered newest first: %+v", versions)
+	}
+}
+
+func testUpdateWithVersion(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	schedule := NewSchedule("Versions")
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	record := func(saved *models.Schedule, latest *models.ScheduleVersion) (*models.ScheduleVersion, error) {
+		number := 1
+		if latest != nil {
+			number = latest.Version + 1
+		}
+		return &models.ScheduleVersion{
+			ScheduleID: saved.ID,
+			Version:    number,
+			Data:       []byte(fmt.Sprintf(`{"name":%q}`, saved.Name)),
+			CreatedAt:  time.Now(),
+		}, nil
+	}
+
+	// Версия строится по уже сохраненному расписанию
+	schedule.Name = "Первая правка"
+	schedule.Blocks = schedule.Blocks[:1]
+	err := repos.Schedules.UpdateWithVersion(ctx, schedule, func(saved *models.Schedule, latest *models.ScheduleVersion) (*models.ScheduleVersion, error) {
+		if latest != nil || saved.Name != "Первая правка" || len(saved.Blocks) != 1 || saved.Blocks[0].ID == 0 {
+			return nil, fmt.Errorf("unexpected saved schedule %+v and latest version %+v", saved, latest)
+		}
+		return record(saved, latest)
+	})
+	if err != nil {
+		t.Fatalf("update with version: %v", err)
+	}
+	if err := repos.Schedules.UpdateWithVersion(ctx, schedule, record); err != nil {
+		t.Fatalf("second update with version: %v", err)
+	}
+	latest, err := repos.Versions.GetLatestVersion(ctx, schedule.ID)
+	if err != nil || latest.Version != 2 || latest.ID == 0 {
+		t.Fatalf("want version 2 after two updates, got %+v, %v", latest, err)
+	}
+
+	// Ошибка построения версии отменяет и обновление
+	errVersion := errors.New("version failed")
+	schedule.Name = "Отмененная правка"
+	err = repos.Schedules.UpdateWithVersion(ctx, schedule, func(*models.Schedule, *models.ScheduleVersion) (*models.ScheduleVersion, error) {
+		return nil, errVersion
+	})
+	if !errors.Is(err, errVersion) {
+		t.Fatalf("want version error, got %v", err)
+	}
+	stored, err := repos.Schedules.GetByID(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if stored.Name != "Первая правка" {
+		t.Fatalf("failed update must be rolled back, got name %q", stored.Name)
+	}
+	if versions, err := repos.Versions.GetVersionsByScheduleID(ctx, schedule.ID); err != nil || len(versions) != 2 {
+		t.Fatalf("failed update must not add a version, got %d, %v", len(versions), err)
+	}
+
+	missing := NewSchedule("Missing")
+	missing.ID = 999
+	if err := repos.Schedules.UpdateWithVersion(ctx, missing, record); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("update of a missing schedule must fail with ErrNotFound, got %v", err)
+	}
+}
+
+func testSearchFollowsWrites(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	schedule := NewSchedule("Весенний фестиваль")
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	hits, err := repos.Search.Search(ctx, "Дефиле", 0, 10)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	item := schedule.Blocks[1].Items[0]
+	if len(hits) != 1 || hits[0].Kind != models.SearchKindItem ||
+		hits[0].ItemID == nil || *hits[0].ItemID != item.ID ||
+		hits[0].BlockID == nil || *hits[0].BlockID != schedule.Blocks[1].ID ||
+		hits[0].ScheduleID != schedule.ID || hits[0].ItemName != item.Name {
+		t.Fatalf("unexpected hits for item description: %+v", hits)
+	}
+
+	// Переименование элемента попадает в индекс в той же операции записи
+	schedule.Blocks[1].Items[0].Description = "Фехтование"
+	if err := repos.Schedules.Update(ctx, schedule); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if hits, err = repos.Search.Search(ctx, "Дефиле", 0, 10); err != nil || len(hits) != 0 {
+		t.Fatalf("stale hits after update: %+v, %v", hits, err)
+	}
+	if hits, err = repos.Search.Search(ctx, "Фехтование", 0, 10); err != nil || len(hits) != 1 {
+		t.Fatalf("missing hits after update: %+v, %v", hits, err)
+	}
+
+	if err := repos.Schedules.Delete(ctx, schedule.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if hits, err = repos.Search.Search(ctx, "Фехтование", 0, 10); err != nil || len(hits) != 0 {
+		t.Fatalf("hits for deleted schedule: %+v, %v", hits, err)
+	}
+}
+
+func testSearchEscapesSnippets(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	schedule := NewSchedule("Фестиваль")
+	schedule.Blocks[1].Items[0].Description = `Дефиле <img src=x onerror="alert(1)">`
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	hits, err := repos.Search.Search(ctx, "Дефиле", 0, 10)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(hits) != 1 {
+		t.Fatalf("want 1 hit, got %+v", hits)
+	}
+	// Текст пользователя экранирован, разметка — только подсветка совпадений
+	snippet := hits[0].Snippet
+	if !strings.Contains(snippet, "<mark>Дефиле</mark>") {
+		t.Fatalf("snippet must highlight the match: %q", snippet)
+	}
+	if rest := strings.ReplaceAll(strings.ReplaceAll(snippet, "<mark>", ""), "</mark>", ""); strings.ContainsAny(rest, `<>"`) {
+		t.Fatalf("snippet must escape user text: %q", snippet)
+	}
+}
+
+func testRuleSets(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	schedule := NewSchedule("Rules")
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if _, err := repos.RuleSets.GetRuleSet(ctx, schedule.ID); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("missing rule set must fail with ErrNotFound, got %v", err)
+	}
+
+	organization := &models.RuleSet{ScheduleID: models.OrganizationRuleSetID, Source: "rules: []"}
+	if err := repos.RuleSets.SaveRuleSet(ctx, organization); err != nil {
+		t.Fatalf("save organization rule set: %v", err)
+	}
+
+	own := &models.RuleSet{ScheduleID: schedule.ID, Source: "rules:\n  - type: max_block_duration\n    minutes: 90\n"}
+	if err := repos.RuleSets.SaveRuleSet(ctx, own); err != nil {
+		t.Fatalf("save rule set: %v", err)
+	}
+	own.Source = "rules:\n  - type: max_block_duration\n    minutes: 60\n"
+	if err := repos.RuleSets.SaveRuleSet(ctx, own); err != nil {
+		t.Fatalf("replace rule set: %v", err)
+	}
+
+	got, err := repos.RuleSets.GetRuleSet(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get rule set: %v", err)
+	}
+	if got.Source != own.Source || got.UpdatedAt.IsZero() {
+		t.Fatalf("unexpected rule set: %+v", got)
+	}
+
+	// Набор расписания удаляется вместе с ним, набор организации остается
+	if err := repos.Schedules.Delete(ctx, schedule.ID); err != nil {
+		t.Fatalf("delete schedule: %v", err)
+	}
+	if _, err := repos.RuleSets.GetRuleSet(ctx, schedule.ID); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("rule set of a deleted schedule must be gone, got %v", err)
+	}
+	if _, err := repos.RuleSets.GetRuleSet(ctx, models.OrganizationRuleSetID); err != nil {
+		t.Fatalf("organization rule set must survive: %v", err)
+	}
+
+	if err := repos.RuleSets.DeleteRuleSet(ctx, models.OrganizationRuleSetID); err != nil {
+		t.Fatalf("delete organization rule set: %v", err)
+	}
+	if err := repos.RuleSets.DeleteRuleSet(ctx, models.OrganizationRuleSetID); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("second delete must fail with ErrNotFound, got %v", err)
+	}
+}
+
+// AssertSamePerformer сравнивает сохраняемые поля выступающего
+func AssertSamePerformer(t *testing.T, want, got *models.Performer) {
+	t.Helper()
+
+	if got.ID != want.ID || got.Name != want.Name || got.Contact != want.Contact ||
+		got.BufferMinutes != want.BufferMinutes || got.CreatedAt.IsZero() || got.UpdatedAt.IsZero() {
+		t.Fatalf("performer mismatch:\nwant %+v\n got %+v", want, got)
+	}
+	if len(got.Availability) != len(want.Availability) {
+		t.Fatalf("want %d availability windows, got %d", len(want.Availability), len(got.Availability))
+	}
+	for i := range want.Availability {
+		w, g := want.Availability[i], got.Availability[i]
+		if g.ID == 0 || g.PerformerID != want.ID || !g.StartTime.Equal(w.StartTime) || !g.EndTime.Equal(w.EndTime) {
+			t.Fatalf("availability %d mismatch:\nwant %+v\n got %+v", i, w, g)
+		}
+	}
+}
+
+func testPerformers(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	first := NewPerformer("Хор")
+	if err := repos.Performers.CreatePerformer(ctx, first); err != nil {
+		t.Fatalf("create performer: %v", err)
+	}
+	if first.ID == 0 || first.Availability[0].ID == 0 {
+		t.Fatalf("create must assign IDs: %+v", first)
+	}
+	second := NewPerformer("Ведущий")
+	second.Availability = nil
+	if err := repos.Performers.CreatePerformer(ctx, second); err != nil {
+		t.Fatalf("create performer: %v", err)
+	}
+
+	got, err := repos.Performers.GetPerformer(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("get performer: %v", err)
+	}
+	AssertSamePerformer(t, first, got)
+
+	first.Name = "Детский хор"
+	first.BufferMinutes = 45
+	first.Availability = first.Availability[1:]
+	if err := repos.Performers.UpdatePerformer(ctx, first); err != nil {
+		t.Fatalf("update performer: %v", err)
+	}
+	got, err = repos.Performers.GetPerformer(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("get performer: %v", err)
+	}
+	AssertSamePerformer(t, first, got)
+
+	missing := NewPerformer("Нет")
+	missing.ID = 999
+	if err := repos.Performers.UpdatePerformer(ctx, missing); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("update of a missing performer must fail with ErrNotFound, got %v", err)
+	}
+
+	some, err := repos.Performers.GetPerformers(ctx, []uint{second.ID, 999, first.ID})
+	if err != nil {
+		t.Fatalf("get performers: %v", err)
+	}
+	if len(some) != 2 || some[0].ID != first.ID || some[1].ID != second.ID {
+		t.Fatalf("want performers %d and %d, got %+v", first.ID, second.ID, some)
+	}
+
+	all, err := repos.Performers.ListPerformers(ctx)
+	if err != nil {
+		t.Fatalf("list performers: %v", err)
+	}
+	if len(all) != 2 || all[0].ID != first.ID || all[1].Availability == nil {
+		t.Fatalf("unexpected performers: %+v", all)
+	}
+
+	if err := repos.Performers.DeletePerformer(ctx, first.ID); err != nil {
+		t.Fatalf("delete performer: %v", err)
+	}
+	if _, err := repos.Performers.GetPerformer(ctx, first.ID); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("deleted performer must be gone, got %v", err)
+	}
+	if err := repos.Performers.DeletePerformer(ctx, first.ID); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("second delete must fail with ErrNotFound, got %v", err)
+	}
+}
+
+func testPerformerAppearances(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	choir, host := NewPerformer("Хор"), NewPerformer("Ведущий")
+	for _, performer := range []*models.Performer{choir, host} {
+		if err := repos.Performers.CreatePerformer(ctx, performer); err != nil {
+			t.Fatalf("create performer: %v", err)
+		}
+	}
+
+	schedule := NewSchedule("Appearances")
+	schedule.Blocks[0].Items[0].PerformerIDs = []uint{host.ID}
+	schedule.Blocks[0].Items[1].PerformerIDs = []uint{host.ID, choir.ID, choir.ID}
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if ids := schedule.Blocks[0].Items[1].PerformerIDs; len(ids) != 2 || ids[0] != choir.ID {
+		t.Fatalf("performer IDs must be stored sorted without duplicates, got %v", ids)
+	}
+
+	got, err := repos.Schedules.GetByID(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	AssertSameSchedule(t, schedule, got)
+	if got.Blocks[1].Items[0].PerformerIDs == nil {
+		t.Fatal("items without performers must have an empty list")
+	}
+
+	appearances, err := repos.Performers.ListAppearances(ctx, []uint{host.ID})
+	if err != nil {
+		t.Fatalf("list appearances: %v", err)
+	}
+	if len(appearances) != 2 {
+		t.Fatalf("want 2 appearances, got %+v", appearances)
+	}
+	first := appearances[0]
+	item := schedule.Blocks[0].Items[0]
+	if first.PerformerID != host.ID || first.ScheduleID != schedule.ID || first.ScheduleName != schedule.Name ||
+		first.BlockName != schedule.Blocks[0].Name || first.ItemID != item.ID || first.ItemName != item.Name ||
+		!first.StartTime.Equal(item.StartTime) || !first.EndTime.Equal(item.EndTime) {
+		t.Fatalf("unexpected appearance: %+v", first)
+	}
+	if !appearances[0].StartTime.Before(appearances[1].StartTime) {
+		t.Fatalf("appearances must be ordered by start time: %+v", appearances)
+	}
+
+	// Обновление заменяет связи элементов
+	schedule.Blocks[0].Items[0].PerformerIDs = nil
+	if err := repos.Schedules.Update(ctx, schedule); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	appearances, err = repos.Performers.ListAppearances(ctx, []uint{host.ID})
+	if err != nil {
+		t.Fatalf("list appearances: %v", err)
+	}
+	if len(appearances) != 1 || appearances[0].ItemID != schedule.Blocks[0].Items[1].ID {
+		t.Fatalf("want only the second item, got %+v", appearances)
+	}
+
+	// Удаление выступающего удаляет его связи с элементами
+	if err := repos.Performers.DeletePerformer(ctx, host.ID); err != nil {
+		t.Fatalf("delete performer: %v", err)
+	}
+	got, err = repos.Schedules.GetByID(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if ids := got.Blocks[0].Items[1].PerformerIDs; len(ids) != 1 || ids[0] != choir.ID {
+		t.Fatalf("want only the choir left, got %v", ids)
+	}
+
+	// Выступления удаленного расписания не возвращаются
+	if err := repos.Schedules.Delete(ctx, schedule.ID); err != nil {
+		t.Fatalf("delete schedule: %v", err)
+	}
+	appearances, err = repos.Performers.ListAppearances(ctx, []uint{choir.ID})
+	if err != nil {
+		t.Fatalf("list appearances: %v", err)
+	}
+	if len(appearances) != 0 {
+		t.Fatalf("appearances of a deleted schedule must be gone, got %+v", appearances)
+	}
+}
+
+// AssertSameResource сравнивает сохраняемые поля ресурса
+func AssertSameResource(t *testing.T, want, got *models.Resource) {
+	t.Helper()
+
+	if got.ID != want.ID || got.Name != want.Name || got.Type != want.Type ||
+		got.Description != want.Description || got.Capacity != want.Capacity {
+		t.Fatalf("resource mismatch:\nwant %+v\n got %+v", want, got)
+	}
+}
+
+func testResources(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	stage := NewResource("Сцена", 1)
+	if err := repos.Resources.CreateResource(ctx, stage); err != nil {
+		t.Fatalf("create resource: %v", err)
+	}
+	if stage.ID == 0 || stage.CreatedAt.IsZero() {
+		t.Fatalf("create must fill ID and timestamps, got %+v", stage)
+	}
+	projectors := NewResource("Проекторы", 3)
+	if err := repos.Resources.CreateResource(ctx, projectors); err != nil {
+		t.Fatalf("create resource: %v", err)
+	}
+
+	stage.Name = "Большая сцена"
+	stage.Type = "stage"
+	stage.Capacity = 2
+	if err := repos.Resources.UpdateResource(ctx, stage); err != nil {
+		t.Fatalf("update resource: %v", err)
+	}
+	got, err := repos.Resources.GetResource(ctx, stage.ID)
+	if err != nil {
+		t.Fatalf("get resource: %v", err)
+	}
+	AssertSameResource(t, stage, got)
+
+	missing := NewResource("Нет", 1)
+	missing.ID = 999
+	if err := repos.Resources.UpdateResource(ctx, missing); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("update of a missing resource must fail with ErrNotFound, got %v", err)
+	}
+
+	some, err := repos.Resources.GetResources(ctx, []uint{projectors.ID, 999, stage.ID})
+	if err != nil {
+		t.Fatalf("get resources: %v", err)
+	}
+	if len(some) != 2 || some[0].ID != stage.ID || some[1].ID != projectors.ID {
+		t.Fatalf("want resources %d and %d, got %+v", stage.ID, projectors.ID, some)
+	}
+
+	all, err := repos.Resources.ListResources(ctx)
+	if err != nil {
+		t.Fatalf("list resources: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("unexpected resources: %+v", all)
+	}
+
+	if err := repos.Resources.DeleteResource(ctx, stage.ID); err != nil {
+		t.Fatalf("delete resource: %v", err)
+	}
+	if _, err := repos.Resources.GetResource(ctx, stage.ID); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("deleted resource must be gone, got %v", err)
+	}
+	if err := repos.Resources.DeleteResource(ctx, stage.ID); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("second delete must fail with ErrNotFound, got %v", err)
+	}
+}
+
+func testResourceUsages(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	stage, projectors := NewResource("Сцена", 1), NewResource("Проекторы", 3)
+	for _, resource := range []*models.Resource{stage, projectors} {
+		if err := repos.Resources.CreateResource(ctx, resource); err != nil {
+			t.Fatalf("create resource: %v", err)
+		}
+	}
+
+	schedule := NewSchedule("Usages")
+	schedule.Blocks[0].Resources = []models.ResourceAssignment{{ResourceID: stage.ID, Quantity: 1}}
+	schedule.Blocks[0].Items[1].Resources = []models.ResourceAssignment{{ResourceID: projectors.ID, Quantity: 2}}
+	schedule.Blocks[1].Resources = []models.ResourceAssignment{
+		{ResourceID: projectors.ID, Quantity: 1},
+		{ResourceID: stage.ID, Quantity: 1},
+	}
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	day := schedule.StartDate
+	listUsages := func(id uint) ([]models.ResourceUsage, error) {
+		return repos.Resources.ListUsages(ctx, []uint{id}, day.Add(-24*time.Hour), day.Add(24*time.Hour))
+	}
+
+	got, err := repos.Schedules.GetByID(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	AssertSameSchedule(t, schedule, got)
+	if got.Blocks[0].Items[0].Resources == nil {
+		t.Fatal("items without bookings must have an empty list")
+	}
+
+	usages, err := listUsages(stage.ID)
+	if err != nil {
+		t.Fatalf("list usages: %v", err)
+	}
+	if len(usages) != 2 {
+		t.Fatalf("want 2 stage usages, got %+v", usages)
+	}
+	first, block := usages[0], schedule.Blocks[0]
+	if first.ResourceID != stage.ID || first.ScheduleID != schedule.ID || first.ScheduleName != schedule.Name ||
+		first.BlockID != block.ID || first.BlockName != block.Name || first.ItemID != 0 || first.Quantity != 1 ||
+		!first.StartTime.Equal(block.StartTime) || !first.EndTime.Equal(block.EndTime()) {
+		t.Fatalf("unexpected block usage: %+v", first)
+	}
+
+	usages, err = listUsages(projectors.ID)
+	if err != nil {
+		t.Fatalf("list usages: %v", err)
+	}
+	item := schedule.Blocks[0].Items[1]
+	if len(usages) != 2 || usages[0].ItemID != item.ID || usages[0].ItemName != item.Name || usages[0].Quantity != 2 ||
+		!usages[0].StartTime.Equal(item.StartTime) || !usages[0].EndTime.Equal(item.EndTime) {
+		t.Fatalf("unexpected item usage: %+v", usages)
+	}
+
+	// Интервал полуоткрытый, бронь блока включает техперерыв: блоки 10:00–10:40
+	// и 10:40–11:40, бронь элемента 10:21–10:26
+	window := func(id uint, from, to time.Duration) int {
+		t.Helper()
+		usages, err := repos.Resources.ListUsages(ctx, []uint{id}, day.Add(from), day.Add(to))
+		if err != nil {
+			t.Fatalf("list usages: %v", err)
+		}
+		return len(usages)
+	}
+	for _, c := range []struct {
+		id       uint
+		from, to time.Duration
+		want     int
+	}{
+		{stage.ID, 35 * time.Minute, 40 * time.Minute, 1},
+		{stage.ID, 40 * time.Minute, 45 * time.Minute, 1},
+		{stage.ID, 35 * time.Minute, 45 * time.Minute, 2},
+		{stage.ID, 100 * time.Minute, 2 * time.Hour, 0},
+		{stage.ID, -time.Hour, 0, 0},
+		{projectors.ID, 25 * time.Minute, 26 * time.Minute, 1},
+		{projectors.ID, 26 * time.Minute, 40 * time.Minute, 0},
+	} {
+		if got := window(c.id, c.from, c.to); got != c.want {
+			t.Errorf("usages of %d in [%v, %v): got %d, want %d", c.id, c.from, c.to, got, c.want)
+		}
+	}
+
+	// Обновление заменяет брони блоков и элементов
+	schedule.Blocks[0].Items[1].Resources = nil
+	schedule.Blocks[1].Resources = schedule.Blocks[1].Resources[1:]
+	if err := repos.Schedules.Update(ctx, schedule); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	usages, err = listUsages(projectors.ID)
+	if err != nil {
+		t.Fatalf("list usages: %v", err)
+	}
+	if len(usages) != 0 {
+		t.Fatalf("projector bookings must be gone, got %+v", usages)
+	}
+
+	// Удаление ресурса удаляет его брони
+	if err := repos.Resources.DeleteResource(ctx, stage.ID); err != nil {
+		t.Fatalf("delete resource: %v", err)
+	}
+	got, err = repos.Schedules.GetByID(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(got.Blocks[0].Resources) != 0 || len(got.Blocks[1].Resources) != 0 {
+		t.Fatalf("bookings of a deleted resource must be gone, got %+v and %+v",
+			got.Blocks[0].Resources, got.Blocks[1].Resources)
+	}
+
+	// Брони удаленного расписания не возвращаются
+	schedule.Blocks[0].Resources = []models.ResourceAssignment{{ResourceID: projectors.ID, Quantity: 1}}
+	schedule.Blocks[1].Resources = nil
+	if err := repos.Schedules.Update(ctx, schedule); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := repos.Schedules.Delete(ctx, schedule.ID); err != nil {
+		t.Fatalf("delete schedule: %v", err)
+	}
+	usages, err = listUsages(projectors.ID)
+	if err != nil {
+		t.Fatalf("list usages: %v", err)
+	}
+	if len(usages) != 0 {
+		t.Fatalf("usages of a deleted schedule must be gone, got %+v", usages)
+	}
+}
+
+// agendaNames возвращает названия записей ленты для сообщений теста
+func agendaNames(entries []models.AgendaEntry) []string {
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = fmt.Sprintf("%s/%s", entry.ScheduleName, entry.BlockName)
+		if entry.Kind == models.AgendaKindItem {
+			names[i] += "/" + entry.ItemName
+		}
+	}
+	return names
+}
+
+func testAgenda(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	// Блоки: 10:00–10:30 и 10:40–11:40; элементы первого блока 10:05–10:15 и
+	// 10:21–10:26, второго — 10:40–11:00
+	main, side := NewSchedule("A"), NewSchedule("B")
+	for i := range side.Blocks {
+		side.Blocks[i].Track = "Малая сцена"
+	}
+	for _, schedule := range []*models.Schedule{main, side} {
+		if err := repos.Schedules.Create(ctx, schedule); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	day := main.StartDate
+	list := func(filter models.AgendaFilter) []string {
+		t.Helper()
+		entries, err := repos.Agenda.ListAgenda(ctx, filter)
+		if err != nil {
+			t.Fatalf("list agenda: %v", err)
+		}
+		for _, entry := range entries {
+			if entry.ScheduleID == 0 || entry.BlockID == 0 || (entry.Kind == models.AgendaKindItem) != (entry.ItemID != 0) {
+				t.Fatalf("incomplete entry %+v", entry)
+			}
+		}
+		return agendaNames(entries)
+	}
+	expect := func(filter models.AgendaFilter, want ...string) {
+		t.Helper()
+		if got := list(filter); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("agenda %+v:\nwant %q\n got %q", filter, want, got)
+		}
+	}
+
+	from, to := day.Add(20*time.Minute), day.Add(45*time.Minute)
+	expect(models.AgendaFilter{From: from, To: to},
+		"A/Открытие", "B/Открытие", "A/Косплей", "B/Косплей")
+	expect(models.AgendaFilter{From: from, To: to, Items: true},
+		"A/Открытие", "B/Открытие", "A/Открытие/Гимн", "B/Открытие/Гимн",
+		"A/Косплей", "B/Косплей", "A/Косплей/Участник 1", "B/Косплей/Участник 1")
+
+	// Фильтры по расписанию, типу и треку блока
+	expect(models.AgendaFilter{From: from, To: to, Items: true, ScheduleIDs: []uint{side.ID}},
+		"B/Открытие", "B/Открытие/Гимн", "B/Косплей", "B/Косплей/Участник 1")
+	expect(models.AgendaFilter{From: from, To: to, Types: []string{"contest"}},
+		"A/Косплей", "B/Косплей")
+	expect(models.AgendaFilter{From: from, To: to, Items: true, Tracks: []string{"Главная сцена"}},
+		"A/Открытие", "A/Открытие/Гимн")
+
+	// Интервал полуоткрытый, техперерыв в блок не входит
+	expect(models.AgendaFilter{From: day.Add(30 * time.Minute), To: day.Add(40 * time.Minute), Items: true})
+
+	// Лента следует за изменением и удалением расписаний
+	main.Blocks[1].StartTime = day.Add(3 * time.Hour)
+	main.Blocks[1].LayoutItems()
+	if err := repos.Schedules.Update(ctx, main); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	expect(models.AgendaFilter{From: from, To: to}, "A/Открытие", "B/Открытие", "B/Косплей")
+	if err := repos.Schedules.Delete(ctx, side.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	expect(models.AgendaFilter{From: from, To: to}, "A/Открытие")
+
+	// Время со смещением сравнивается как момент, а не как текст
+	moscow := time.FixedZone("MSK", 3*60*60)
+	local := NewSchedule("C")
+	local.StartDate, local.EndDate = local.StartDate.In(moscow), local.EndDate.In(moscow)
+	for i := range local.Blocks {
+		block := &local.Blocks[i]
+		block.StartTime = block.StartTime.In(moscow)
+		block.LayoutItems()
+	}
+	if err := repos.Schedules.Create(ctx, local); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	expect(models.AgendaFilter{From: from.UTC(), To: to.UTC(), Items: true, ScheduleIDs: []uint{local.ID}},
+		"C/Открытие", "C/Открытие/Гимн", "C/Косплей", "C/Косплей/Участник 1")
+	expect(models.AgendaFilter{From: from.In(moscow), To: to.In(moscow), ScheduleIDs: []uint{local.ID}},
+		"C/Открытие", "C/Косплей")
+}
+
+func testShareLinks(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	schedule := NewSchedule("Фестиваль")
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	expiresAt := time.Date(2024, 4, 8, 10, 0, 0, 0, time.UTC)
+	version := 2
+	link := &models.ShareLink{ScheduleID: schedule.ID, View: "crew", Version: &version, Label: "Техники", ExpiresAt: expiresAt}
+	if err := repos.ShareLinks.CreateShareLink(ctx, link); err != nil {
+		t.Fatalf("create share link: %v", err)
+	}
+	if link.ID == 0 || link.CreatedAt.IsZero() {
+		t.Fatalf("create must fill ID and created_at, got %+v", link)
+	}
+	current := &models.ShareLink{ScheduleID: schedule.ID, View: "public", ExpiresAt: expiresAt}
+	if err := repos.ShareLinks.CreateShareLink(ctx, current); err != nil {
+		t.Fatalf("create share link: %v", err)
+	}
+
+	got, err := repos.ShareLinks.GetShareLink(ctx, link.ID)
+	if err != nil {
+		t.Fatalf("get share link: %v", err)
+	}
+	if got.ScheduleID != schedule.ID || got.View != "crew" || got.Version == nil || *got.Version != 2 ||
+		got.Label != "Техники" || !got.ExpiresAt.Equal(expiresAt) || got.RevokedAt != nil || got.AccessCount != 0 {
+		t.Fatalf("unexpected share link: %+v", got)
+	}
+
+	accessedAt := expiresAt.Add(-48 * time.Hour)
+	for i := 0; i < 2; i++ {
+		if err := repos.ShareLinks.RecordShareAccess(ctx, link.ID, accessedAt.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatalf("record access: %v", err)
+		}
+	}
+	got, err = repos.ShareLinks.GetShareLink(ctx, link.ID)
+	if err != nil {
+		t.Fatalf("get share link: %v", err)
+	}
+	if got.AccessCount != 2 || got.LastAccessedAt == nil || !got.LastAccessedAt.Equal(accessedAt.Add(time.Hour)) {
+		t.Fatalf("want 2 accesses, last at %v, got %+v", accessedAt.Add(time.Hour), got)
+	}
+
+	revokedAt := accessedAt.Add(2 * time.Hour)
+	if err := repos.ShareLinks.RevokeShareLink(ctx, link.ID, revokedAt); err != nil {
+		t.Fatalf("revoke share link: %v", err)
+	}
+	// Повторный отзыв не переписывает время первого
+	if err := repos.ShareLinks.RevokeShareLink(ctx, link.ID, revokedAt.Add(time.Hour)); err != nil {
+		t.Fatalf("second revoke: %v", err)
+	}
+
+	links, err := repos.ShareLinks.ListShareLinks(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("list share links: %v", err)
+	}
+	if len(links) != 2 || links[0].ID != link.ID || links[1].ID != current.ID {
+		t.Fatalf("want links %d and %d, got %+v", link.ID, current.ID, links)
+	}
+	if links[0].RevokedAt == nil || !links[0].RevokedAt.Equal(revokedAt) || links[1].RevokedAt != nil || links[1].Version != nil {
+		t.Fatalf("unexpected listed links: %+v", links)
+	}
+
+	if _, err := repos.ShareLinks.GetShareLink(ctx, 999); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("missing link must fail with ErrNotFound, got %v", err)
+	}
+	if err := repos.ShareLinks.RevokeShareLink(ctx, 999, revokedAt); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("revoke of a missing link must fail with ErrNotFound, got %v", err)
+	}
+	if err := repos.ShareLinks.RecordShareAccess(ctx, 999, accessedAt); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("access to a missing link must fail with ErrNotFound, got %v", err)
+	}
+
+	// Ссылки удаляются вместе с расписанием
+	if err := repos.Schedules.Delete(ctx, schedule.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := repos.ShareLinks.GetShareLink(ctx, current.ID); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("links of a deleted schedule must be gone, got %v", err)
+	}
+}
+
+func testReminders(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	schedule := NewSchedule("Фестиваль")
+	if err := repos.Schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	block, item := schedule.Blocks[0], schedule.Blocks[1].Items[0]
+	// Сроки записываются в разных зонах: наступление сравнивается как момент
+	moscow, newYork := time.FixedZone("MSK", 3*60*60), time.FixedZone("EST", -5*60*60)
+
+	reminder := func(blockID, itemID uint, minutes int, startsAt time.Time) models.Reminder {
+		return models.Reminder{
+			ScheduleID: schedule.ID, BlockID: blockID, ItemID: itemID, Minutes: minutes,
+			StartsAt: startsAt, FireAt: startsAt.Add(-time.Duration(minutes) * time.Minute),
+		}
+	}
+	planned := []models.Reminder{
+		reminder(block.ID, 0, 30, block.StartTime.In(moscow)),
+		reminder(block.ID, 0, 10, block.StartTime.In(moscow)),
+		reminder(schedule.Blocks[1].ID, item.ID, 10, item.StartTime.In(newYork)),
+	}
+	if err := repos.Reminders.SyncReminders(ctx, schedule.ID, planned, block.StartTime.Add(-time.Hour)); err != nil {
+		t.Fatalf("sync reminders: %v", err)
+	}
+
+	reminders, err := repos.Reminders.ListReminders(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("list reminders: %v", err)
+	}
+	if len(reminders) != 3 || reminders[0].Minutes != 30 || reminders[1].Minutes != 10 || reminders[2].ItemID != item.ID {
+		t.Fatalf("want reminders ordered by fire time, got %+v", reminders)
+	}
+	if reminders[0].ID == 0 || reminders[0].CreatedAt.IsZero() || !reminders[0].FireAt.Equal(block.StartTime.Add(-30*time.Minute)) {
+		t.Fatalf("unexpected reminder: %+v", reminders[0])
+	}
+
+	// Наступили только напоминания блока
+	now := block.StartTime.Add(-5 * time.Minute)
+	due, err := repos.Reminders.DueReminders(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("due reminders: %v", err)
+	}
+	if len(due) != 2 || due[0].ID != reminders[0].ID || due[1].ID != reminders[1].ID {
+		t.Fatalf("want 2 due block reminders, got %+v", due)
+	}
+	if due, _ := repos.Reminders.DueReminders(ctx, now, 1); len(due) != 1 || due[0].ID != reminders[0].ID {
+		t.Fatalf("limit must keep the earliest reminder, got %+v", due)
+	}
+
+	// Напоминание берется на отправку один раз
+	claimed, err := repos.Reminders.ClaimReminder(ctx, reminders[0].ID, now)
+	if err != nil || !claimed {
+		t.Fatalf("first claim: %v, %v", claimed, err)
+	}
+	if claimed, err := repos.Reminders.ClaimReminder(ctx, reminders[0].ID, now); err != nil || claimed {
+		t.Fatalf("second claim must fail: %v, %v", claimed, err)
+	}
+	if claimed, err := repos.Reminders.ClaimReminder(ctx, 999, now); err != nil || claimed {
+		t.Fatalf("claim of a missing reminder must fail: %v, %v", claimed, err)
+	}
+	if _, err := repos.Reminders.ClaimReminder(ctx, reminders[1].ID, now); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+
+	// Повторная синхронизация: блок сдвинут на минуту и на 15 минут. Уже
+	// прошедшее напоминание за 30 мин остается отправленным, напоминание за
+	// 10 мин переносится в будущее и снова ждет отправки; элемент убран,
+	// добавлен новый срок
+	shifted, moved := block.StartTime.Add(time.Minute), block.StartTime.Add(15*time.Minute)
+	replanned := []models.Reminder{
+		reminder(block.ID, 0, 30, shifted),
+		reminder(block.ID, 0, 10, moved),
+		reminder(block.ID, 0, 5, moved),
+	}
+	if err := repos.Reminders.SyncReminders(ctx, schedule.ID, replanned, now); err != nil {
+		t.Fatalf("resync reminders: %v", err)
+	}
+	reminders, err = repos.Reminders.ListReminders(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("list reminders: %v", err)
+	}
+	if len(reminders) != 3 {
+		t.Fatalf("want 3 reminders after resync, got %+v", reminders)
+	}
+	if reminders[0].Minutes != 30 || reminders[0].SentAt == nil || !reminders[0].SentAt.Equal(now) || !reminders[0].StartsAt.Equal(shifted) {
+		t.Fatalf("past reminder must stay sent, got %+v", reminders[0])
+	}
+	if reminders[1].Minutes != 10 || reminders[1].SentAt != nil || !reminders[1].StartsAt.Equal(moved) {
+		t.Fatalf("moved reminder must wait again, got %+v", reminders[1])
+	}
+	if reminders[2].Minutes != 5 || reminders[2].SentAt != nil {
+		t.Fatalf("unexpected new reminder: %+v", reminders[2])
+	}
+
+	// Напоминания удаляются вместе с расписанием
+	if err := repos.Schedules.Delete(ctx, schedule.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if reminders, err := repos.Reminders.ListReminders(ctx, schedule.ID); err != nil || len(reminders) != 0 {
+		t.Fatalf("reminders of a deleted schedule must be gone, got %+v, %v", reminders, err)
+	}
+}
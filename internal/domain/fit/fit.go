@@ -0,0 +1,340 @@
+// Package fit сжимает или растягивает расписание так, чтобы оно точно
+// укладывалось в новое окончание. Меняются длительности элементов, запас
+// блоков сверх их элементов и техперерывы; порядок блоков и перестановки
+// между элементами не меняются.
+// Длительности округляются до целых минут методом наибольшего остатка,
+// поэтому их сумма точно равна новому окну расписания.
+package fit
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/validation"
+	"cor-events-scheduler/pkg/utils"
+)
+
+// Стратегии распределения изменения между длительностями
+const (
+	// StrategyProportional меняет все длительности в одной пропорции
+	StrategyProportional = "proportional"
+	// StrategyPriority меняет длительности обратно пропорционально приоритету
+	// элемента: элемент с приоритетом p меняется в 1+p раз меньше элемента без приоритета.
+	// Запас блоков и техперерывы имеют нулевой приоритет.
+	StrategyPriority = "priority"
+	// StrategyMinimum меняет длительности пропорционально, но не сжимает
+	// элементы ниже их MinDuration
+	StrategyMinimum = "minimum"
+)
+
+// Виды изменяемых длительностей
+const (
+	KindItem      = "item"
+	KindBlock     = "block"
+	KindTechBreak = "tech_break"
+)
+
+// Change описывает одну измененную длительность
+type Change struct {
+	Kind string `json:"kind"`
+	// Path — JSON Pointer на измененное поле расписания
+	Path    string `json:"path"`
+	BlockID uint   `json:"block_id"`
+	ItemID  uint   `json:"item_id,omitempty"`
+	Name    string `json:"name"`
+	From    int    `json:"from"`
+	To      int    `json:"to"`
+}
+
+// Result — подогнанное расписание и его отличия от текущего
+type Result struct {
+	Schedule *models.Schedule `json:"schedule"`
+	Strategy string           `json:"strategy"`
+	// FromMinutes и ToMinutes — сумма длительностей блоков и техперерывов до и после подгонки
+	FromMinutes int       `json:"from_minutes"`
+	ToMinutes   int       `json:"to_minutes"`
+	FromEndDate time.Time `json:"from_end_date"`
+	ToEndDate   time.Time `json:"to_end_date"`
+	Changes     []Change  `json:"changes"`
+}
+
+// unit — длительность, которую можно изменить
+type unit struct {
+	block int
+	// item — индекс элемента; -1 для запаса блока и техперерыва
+	item     int
+	kind     string
+	duration int
+	floor    int
+	weight   float64
+	value    float64
+}
+
+// Fit подгоняет расписание под новое окончание endDate. Входное расписание
+// не изменяется.
+func Fit(schedule *models.Schedule, endDate time.Time, strategy string) (*Result, error) {
+	if strategy == "" {
+		strategy = StrategyProportional
+	}
+	switch strategy {
+	case StrategyProportional, StrategyPriority, StrategyMinimum:
+	default:
+		return nil, fmt.Errorf("%w: unknown strategy %q, expected %s, %s or %s",
+			utils.ErrInvalidInput, strategy, StrategyProportional, StrategyPriority, StrategyMinimum)
+	}
+	if endDate.IsZero() {
+		return nil, fmt.Errorf("%w: end_date is required", utils.ErrInvalidInput)
+	}
+	if !endDate.After(schedule.StartDate) {
+		return nil, fmt.Errorf("%w: end_date must be after the schedule start %s",
+			utils.ErrInvalidInput, schedule.StartDate.Format(time.RFC3339))
+	}
+	if len(schedule.Blocks) == 0 {
+		return nil, fmt.Errorf("%w: schedule has no blocks to fit", utils.ErrConflict)
+	}
+
+	target := int(endDate.Sub(schedule.StartDate) / time.Minute)
+	units := collectUnits(schedule, strategy)
+
+	// Перестановки между элементами входят в окно, но не меняются
+	gaps := 0
+	for _, block := range schedule.Blocks {
+		gaps += block.ContentDuration() - block.ItemsDuration()
+	}
+
+	current, minimum := gaps, gaps
+	for _, u := range units {
+		current += u.duration
+		minimum += u.floor
+	}
+	if target < minimum {
+		return nil, fmt.Errorf("%w: the schedule needs at least %d minutes with the %s strategy, the new window has %d",
+			utils.ErrConflict, minimum, strategy, target)
+	}
+
+	if err := distribute(units, target-gaps); err != nil {
+		return nil, err
+	}
+	values := roundPreservingTotal(units, target-gaps)
+
+	proposed := apply(schedule, units, values)
+	proposed.EndDate = endDate
+
+	return &Result{
+		Schedule:    proposed,
+		Strategy:    strategy,
+		FromMinutes: current,
+		ToMinutes:   target,
+		FromEndDate: schedule.EndDate,
+		ToEndDate:   endDate,
+		Changes:     diff(schedule, proposed),
+	}, nil
+}
+
+// collectUnits раскладывает расписание на изменяемые длительности: элементы,
+// запас каждого блока сверх его элементов и техперерывы
+func collectUnits(schedule *models.Schedule, strategy string) []unit {
+	var units []unit
+	for i, block := range schedule.Blocks {
+		for j, item := range block.Items {
+			floor := 1
+			if strategy == StrategyMinimum {
+				floor = max(floor, item.MinDuration)
+			}
+			weight := float64(item.Duration)
+			if strategy == StrategyPriority {
+				weight /= float64(1 + max(item.Priority, 0))
+			}
+			units = append(units, unit{block: i, item: j, kind: KindItem, duration: item.Duration, floor: floor, weight: weight})
+		}
+
+		// Блок без элементов сжимается целиком, но не до нуля
+		slack, floor := blockDuration(block)-block.ContentDuration(), 0
+		if len(block.Items) == 0 {
+			floor = 1
+		}
+		units = append(units, unit{block: i, item: -1, kind: KindBlock, duration: max(slack, 0), floor: floor, weight: float64(max(slack, 0))})
+		units = append(units, unit{block: i, item: -1, kind: KindTechBreak, duration: block.TechBreakDuration, floor: 0, weight: float64(block.TechBreakDuration)})
+	}
+	return units
+}
+
+// distribute находит значения value = max(floor, duration + λ·weight), сумма
+// которых равна target. Сумма не убывает по λ и линейна между точками, где
+// длительности упираются в нижнюю границу, поэтому λ ищется перебором этих точек.
+func distribute(units []unit, target int) error {
+	type breakpoint struct {
+		unit   int
+		lambda float64
+	}
+	var breakpoints []breakpoint
+	fixed := 0.0
+	for i, u := range units {
+		if u.weight <= 0 {
+			units[i].value = float64(max(u.duration, u.floor))
+			fixed += units[i].value
+			continue
+		}
+		breakpoints = append(breakpoints, breakpoint{unit: i, lambda: float64(u.floor-u.duration) / u.weight})
+	}
+	sort.SliceStable(breakpoints, func(i, j int) bool { return breakpoints[i].lambda < breakpoints[j].lambda })
+
+	// Пока λ меньше всех точек, все длительности на нижней границе
+	base := fixed
+	for _, bp := range breakpoints {
+		base += float64(units[bp.unit].floor)
+	}
+
+	lambda, found := 0.0, false
+	weight := 0.0
+	for k := 0; k <= len(breakpoints) && !found; k++ {
+		if k > 0 {
+			// Длительность k-1 отрывается от нижней границы
+			u := units[breakpoints[k-1].unit]
+			base += float64(u.duration - u.floor)
+			weight += u.weight
+		}
+		if weight == 0 {
+			continue
+		}
+		lambda = (float64(target) - base) / weight
+		low, high := breakpoints[k-1].lambda, math.Inf(1)
+		if k < len(breakpoints) {
+			high = breakpoints[k].lambda
+		}
+		found = lambda >= low && lambda <= high
+	}
+	if !found {
+		return fmt.Errorf("%w: the schedule has no durations that can be rescaled to %d minutes",
+			utils.ErrConflict, target)
+	}
+
+	for _, bp := range breakpoints {
+		u := &units[bp.unit]
+		u.value = math.Max(float64(u.floor), float64(u.duration)+lambda*u.weight)
+	}
+	return nil
+}
+
+// roundPreservingTotal округляет значения вниз и раздает оставшиеся минуты
+// длительностям с наибольшей дробной частью, так что сумма равна target
+func roundPreservingTotal(units []unit, target int) []int {
+	const epsilon = 1e-9
+
+	values := make([]int, len(units))
+	total := 0
+	for i, u := range units {
+		values[i] = max(int(math.Floor(u.value+epsilon)), u.floor)
+		total += values[i]
+	}
+
+	order := make([]int, len(units))
+	for i := range order {
+		order[i] = i
+	}
+	fraction := func(i int) float64 { return units[i].value - float64(values[i]) }
+	sort.SliceStable(order, func(a, b int) bool { return fraction(order[a]) > fraction(order[b]) })
+
+	// Остаток меньше числа длительностей; при погрешности вычислений
+	// проходим список повторно
+	for total < target {
+		for _, i := range order {
+			if total == target {
+				break
+			}
+			if units[i].weight > 0 {
+				values[i]++
+				total++
+			}
+		}
+	}
+	for total > target {
+		progressed := false
+		for k := len(order) - 1; k >= 0 && total > target; k-- {
+			i := order[k]
+			if values[i] > units[i].floor {
+				values[i]--
+				total--
+				progressed = true
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+	return values
+}
+
+// apply копирует расписание с новыми длительностями и пересчитывает времена блоков
+func apply(schedule *models.Schedule, units []unit, values []int) *models.Schedule {
+	proposed := *schedule
+	proposed.Blocks = make([]models.Block, len(schedule.Blocks))
+	for i, block := range schedule.Blocks {
+		block.Items = append([]models.BlockItem(nil), block.Items...)
+		// Перестановки между элементами не меняются
+		block.Duration = block.ContentDuration() - block.ItemsDuration()
+		proposed.Blocks[i] = block
+	}
+
+	for k, u := range units {
+		block := &proposed.Blocks[u.block]
+		switch u.kind {
+		case KindItem:
+			block.Items[u.item].Duration = values[k]
+			block.Duration += values[k]
+		case KindBlock:
+			block.Duration += values[k]
+		case KindTechBreak:
+			block.TechBreakDuration = values[k]
+		}
+	}
+
+	current := proposed.StartDate
+	for i := range proposed.Blocks {
+		proposed.Blocks[i].StartTime = current
+		proposed.Blocks[i].LayoutItems()
+		current = proposed.Blocks[i].EndTime()
+	}
+	return &proposed
+}
+
+// diff перечисляет измененные длительности элементов, блоков и техперерывов
+func diff(current, proposed *models.Schedule) []Change {
+	changes := []Change{}
+	for i, before := range current.Blocks {
+		after := proposed.Blocks[i]
+		for j, item := range before.Items {
+			if to := after.Items[j].Duration; to != item.Duration {
+				changes = append(changes, Change{
+					Kind: KindItem, Path: validation.Pointer("blocks", i, "items", j, "duration"),
+					BlockID: before.ID, ItemID: item.ID, Name: item.Name, From: item.Duration, To: to,
+				})
+			}
+		}
+		if from := blockDuration(before); from != after.Duration {
+			changes = append(changes, Change{
+				Kind: KindBlock, Path: validation.Pointer("blocks", i, "duration"),
+				BlockID: before.ID, Name: before.Name, From: from, To: after.Duration,
+			})
+		}
+		if before.TechBreakDuration != after.TechBreakDuration {
+			changes = append(changes, Change{
+				Kind: KindTechBreak, Path: validation.Pointer("blocks", i, "tech_break_duration"),
+				BlockID: before.ID, Name: before.Name, From: before.TechBreakDuration, To: after.TechBreakDuration,
+			})
+		}
+	}
+	return changes
+}
+
+// blockDuration возвращает длительность блока так же, как при сохранении:
+// блок без длительности длится столько, сколько его элементы
+func blockDuration(block models.Block) int {
+	if block.Duration > 0 {
+		return block.Duration
+	}
+	return block.ContentDuration()
+}
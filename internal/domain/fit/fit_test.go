@@ -0,0 +1,173 @@
+package fit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+)
+
+// twoBlocks строит сохраненное расписание из 200 минут: два блока по 90 минут
+// с техперерывами по 10 минут
+func twoBlocks() *models.Schedule {
+	return domaintest.Festival(time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC), 200*time.Minute,
+		models.Block{
+			ID: 1, Name: "Открытие", Type: "opening", Duration: 90, TechBreakDuration: 10, Order: 1,
+			Items: []models.BlockItem{
+				{ID: 1, Name: "Приветствие", Duration: 30, Order: 1},
+				{ID: 2, Name: "Хедлайнер", Duration: 50, Priority: 4, MinDuration: 45, Order: 2},
+			},
+		},
+		models.Block{
+			ID: 2, Name: "Косплей", Type: "contest", Duration: 90, TechBreakDuration: 10, Order: 2,
+			Items: []models.BlockItem{
+				{ID: 3, Name: "Дефиле", Duration: 45, Order: 1},
+				{ID: 4, Name: "Награждение", Duration: 45, Order: 2},
+			},
+		},
+	)
+}
+
+func total(schedule *models.Schedule) int {
+	minutes := 0
+	for _, block := range schedule.Blocks {
+		minutes += block.Duration + block.TechBreakDuration
+	}
+	return minutes
+}
+
+func item(schedule *models.Schedule, block, index int) int {
+	return schedule.Blocks[block].Items[index].Duration
+}
+
+func TestFitProportionalCompression(t *testing.T) {
+	schedule := twoBlocks()
+	end := schedule.StartDate.Add(150 * time.Minute)
+
+	result, err := Fit(schedule, end, StrategyProportional)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposed := result.Schedule
+	if got := total(proposed); got != 150 {
+		t.Fatalf("want 150 minutes, got %d", got)
+	}
+	if !proposed.Blocks[1].EndTime().Equal(end) || !proposed.EndDate.Equal(end) {
+		t.Fatalf("schedule does not end at %s: %s", end, proposed.Blocks[1].EndTime())
+	}
+	if got := item(proposed, 1, 0); got != 34 {
+		t.Fatalf("45 minutes scaled by 3/4 should round to 34, got %d", got)
+	}
+	if got := proposed.Blocks[0].TechBreakDuration; got < 7 || got > 8 {
+		t.Fatalf("tech break should shrink to 7 or 8 minutes, got %d", got)
+	}
+	for i, block := range proposed.Blocks {
+		if block.ItemsDuration() > block.Duration {
+			t.Fatalf("block %d: items %d exceed duration %d", i, block.ItemsDuration(), block.Duration)
+		}
+	}
+
+	// Исходное расписание не изменилось
+	if item(schedule, 1, 0) != 45 || schedule.Blocks[0].Duration != 90 {
+		t.Fatal("input schedule was modified")
+	}
+	if result.FromMinutes != 200 || result.ToMinutes != 150 || len(result.Changes) == 0 {
+		t.Fatalf("unexpected summary: %+v", result)
+	}
+}
+
+func TestFitStretching(t *testing.T) {
+	schedule := twoBlocks()
+
+	result, err := Fit(schedule, schedule.StartDate.Add(250*time.Minute), StrategyProportional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := total(result.Schedule); got != 250 {
+		t.Fatalf("want 250 minutes, got %d", got)
+	}
+	if got := item(result.Schedule, 0, 1); got <= 50 {
+		t.Fatalf("items should grow, got %d", got)
+	}
+}
+
+func TestFitPriorityProtectsImportantItems(t *testing.T) {
+	schedule := twoBlocks()
+	end := schedule.StartDate.Add(150 * time.Minute)
+
+	proportional, err := Fit(schedule, end, StrategyProportional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weighted, err := Fit(schedule, end, StrategyPriority)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := total(weighted.Schedule); got != 150 {
+		t.Fatalf("want 150 minutes, got %d", got)
+	}
+	headliner, plain := item(weighted.Schedule, 0, 1), item(weighted.Schedule, 0, 0)
+	if headliner <= item(proportional.Schedule, 0, 1) {
+		t.Fatalf("priority item should keep more time than with proportional scaling: %d", headliner)
+	}
+	if 50-headliner >= 30-plain {
+		t.Fatalf("priority item lost %d minutes, plain item lost %d", 50-headliner, 30-plain)
+	}
+}
+
+func TestFitMinimumKeepsItemsAboveMinimum(t *testing.T) {
+	schedule := twoBlocks()
+	end := schedule.StartDate.Add(120 * time.Minute)
+
+	proportional, err := Fit(schedule, end, StrategyProportional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := item(proportional.Schedule, 0, 1); got >= 45 {
+		t.Fatalf("proportional scaling should shrink the headliner below its minimum, got %d", got)
+	}
+
+	result, err := Fit(schedule, end, StrategyMinimum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := item(result.Schedule, 0, 1); got != 45 {
+		t.Fatalf("headliner should stop at its minimum of 45, got %d", got)
+	}
+	if got := total(result.Schedule); got != 120 {
+		t.Fatalf("want 120 minutes, got %d", got)
+	}
+}
+
+func TestFitRejectsImpossibleWindows(t *testing.T) {
+	schedule := twoBlocks()
+
+	// Четыре элемента по минуте и блок с минимумом 45 не помещаются в 40 минут
+	if _, err := Fit(schedule, schedule.StartDate.Add(40*time.Minute), StrategyMinimum); !errors.Is(err, utils.ErrConflict) {
+		t.Fatalf("want ErrConflict, got %v", err)
+	}
+	if _, err := Fit(schedule, schedule.StartDate, StrategyProportional); !errors.Is(err, utils.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput for an end before the start, got %v", err)
+	}
+	if _, err := Fit(schedule, schedule.EndDate, "random"); !errors.Is(err, utils.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput for an unknown strategy, got %v", err)
+	}
+}
+
+func TestRoundPreservingTotal(t *testing.T) {
+	units := []unit{
+		{value: 3.4, weight: 1, floor: 1},
+		{value: 3.3, weight: 1, floor: 1},
+		{value: 3.3, weight: 1, floor: 1},
+	}
+
+	values := roundPreservingTotal(units, 10)
+	if values[0] != 4 || values[1] != 3 || values[2] != 3 {
+		t.Fatalf("want [4 3 3], got %v", values)
+	}
+}
@@ -0,0 +1,134 @@
+// Package live определяет, что идет на сцене в заданный момент по плановому
+// таймлайну расписания: текущие и следующие блок и элемент, сколько им
+// осталось и через сколько они начнутся. Блок идет от StartTime до конца своей
+// длительности, затем до EndTime() длится техперерыв.
+package live
+
+import (
+	"sort"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// Состояния расписания в момент запроса
+const (
+	// StateNotStarted — первый блок еще не начался
+	StateNotStarted = "not_started"
+	// StateRunning — идет блок
+	StateRunning = "running"
+	// StateTechBreak — идет техперерыв после блока
+	StateTechBreak = "tech_break"
+	// StateIdle — между блоками есть время, не занятое ни блоком, ни техперерывом
+	StateIdle = "idle"
+	// StateFinished — все блоки и техперерывы закончились
+	StateFinished = "finished"
+)
+
+// Slot — блок или элемент на таймлайне
+type Slot struct {
+	BlockID   uint      `json:"block_id"`
+	ItemID    uint      `json:"item_id,omitempty"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	// StartsInSeconds — через сколько секунд начнется; 0, если уже идет
+	StartsInSeconds int `json:"starts_in_seconds"`
+	// RemainingSeconds — сколько секунд продлится начиная с момента запроса
+	RemainingSeconds int `json:"remaining_seconds"`
+}
+
+// Status — что идет и что будет следующим в момент At
+type Status struct {
+	ScheduleID   uint      `json:"schedule_id"`
+	ScheduleName string    `json:"schedule_name"`
+	At           time.Time `json:"at"`
+	State        string    `json:"state"`
+	CurrentBlock *Slot     `json:"current_block"`
+	CurrentItem  *Slot     `json:"current_item"`
+	NextBlock    *Slot     `json:"next_block"`
+	NextItem     *Slot     `json:"next_item"`
+	// NextChange — ближайшее начало или конец блока, элемента или техперерыва
+	// после At, когда статус изменится; nil, если расписание закончилось
+	NextChange *time.Time `json:"next_change"`
+}
+
+// At возвращает статус расписания в момент at. Времена элементов должны быть
+// рассчитаны LayoutItems.
+func At(schedule *models.Schedule, at time.Time) *Status {
+	status := &Status{ScheduleID: schedule.ID, ScheduleName: schedule.Name, At: at, State: StateFinished}
+
+	blocks := make([]*models.Block, len(schedule.Blocks))
+	for i := range schedule.Blocks {
+		blocks[i] = &schedule.Blocks[i]
+	}
+	sort.SliceStable(blocks, func(i, j int) bool { return blocks[i].StartTime.Before(blocks[j].StartTime) })
+
+	var boundaries []time.Time
+	for _, block := range blocks {
+		start, end := block.StartTime, blockEnd(block)
+		boundaries = append(boundaries, start, end, block.EndTime())
+
+		switch {
+		case !at.Before(start) && at.Before(end):
+			status.State = StateRunning
+			status.CurrentBlock = newSlot(block.ID, 0, block.Name, block.Type, start, end, at)
+		case !at.Before(end) && at.Before(block.EndTime()):
+			status.State = StateTechBreak
+		case at.Before(start) && status.NextBlock == nil:
+			status.NextBlock = newSlot(block.ID, 0, block.Name, block.Type, start, end, at)
+		}
+
+		for _, item := range block.Items {
+			if item.StartTime.IsZero() || !item.StartTime.Before(item.EndTime) {
+				continue
+			}
+			boundaries = append(boundaries, item.StartTime, item.EndTime)
+
+			switch {
+			case !at.Before(item.StartTime) && at.Before(item.EndTime):
+				status.CurrentItem = newSlot(block.ID, item.ID, item.Name, item.Type, item.StartTime, item.EndTime, at)
+			case at.Before(item.StartTime) && status.NextItem == nil:
+				status.NextItem = newSlot(block.ID, item.ID, item.Name, item.Type, item.StartTime, item.EndTime, at)
+			}
+		}
+	}
+
+	if status.State == StateFinished && status.NextBlock != nil {
+		status.State = StateIdle
+		if at.Before(blocks[0].StartTime) {
+			status.State = StateNotStarted
+		}
+	}
+
+	for _, boundary := range boundaries {
+		if boundary.After(at) && (status.NextChange == nil || boundary.Before(*status.NextChange)) {
+			next := boundary
+			status.NextChange = &next
+		}
+	}
+	return status
+}
+
+// blockEnd возвращает конец блока без техперерыва
+func blockEnd(block *models.Block) time.Time {
+	return block.StartTime.Add(time.Duration(block.Duration) * time.Minute)
+}
+
+func newSlot(blockID, itemID uint, name, kind string, start, end, at time.Time) *Slot {
+	from := start
+	if at.After(from) {
+		from = at
+	}
+	return &Slot{
+		BlockID:          blockID,
+		ItemID:           itemID,
+		Name:             name,
+		Type:             kind,
+		StartTime:        start,
+		EndTime:          end,
+		StartsInSeconds:  int(max(start.Sub(at), 0) / time.Second),
+		RemainingSeconds: int(end.Sub(from) / time.Second),
+	}
+}
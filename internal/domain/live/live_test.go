@@ -0,0 +1,102 @@
+package live
+
+import (
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+)
+
+// clock — время 1 апреля 2024 года. В расписании domaintest.NewSavedSchedule
+// «Открытие» 10:00–10:30 и техперерыв до 10:40 с элементами 10:05–10:15 и
+// 10:21–10:26, «Косплей» 10:40–11:40 с элементом 10:40–11:00
+func clock(hour, minute int) time.Time {
+	return time.Date(2024, 4, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func name(slot *Slot) string {
+	if slot == nil {
+		return ""
+	}
+	return slot.Name
+}
+
+func TestAt(t *testing.T) {
+	tests := []struct {
+		name         string
+		at           time.Time
+		state        string
+		currentBlock string
+		currentItem  string
+		nextBlock    string
+		nextItem     string
+		nextChange   time.Time
+	}{
+		{"before start", clock(9, 50), StateNotStarted, "", "", "Открытие", "Приветствие", clock(10, 0)},
+		{"block start without item", clock(10, 0), StateRunning, "Открытие", "", "Косплей", "Приветствие", clock(10, 5)},
+		{"item running", clock(10, 7), StateRunning, "Открытие", "Приветствие", "Косплей", "Гимн", clock(10, 15)},
+		{"between items", clock(10, 17), StateRunning, "Открытие", "", "Косплей", "Гимн", clock(10, 21)},
+		{"tech break", clock(10, 35), StateTechBreak, "", "", "Косплей", "Участник 1", clock(10, 40)},
+		{"next block", clock(10, 40), StateRunning, "Косплей", "Участник 1", "", "", clock(11, 0)},
+		{"finished", clock(11, 40), StateFinished, "", "", "", "", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := At(domaintest.NewSavedSchedule("Фестиваль"), tt.at)
+
+			if status.State != tt.state {
+				t.Fatalf("want state %s, got %s", tt.state, status.State)
+			}
+			if name(status.CurrentBlock) != tt.currentBlock || name(status.CurrentItem) != tt.currentItem {
+				t.Fatalf("want current %q/%q, got %q/%q",
+					tt.currentBlock, tt.currentItem, name(status.CurrentBlock), name(status.CurrentItem))
+			}
+			if name(status.NextBlock) != tt.nextBlock || name(status.NextItem) != tt.nextItem {
+				t.Fatalf("want next %q/%q, got %q/%q",
+					tt.nextBlock, tt.nextItem, name(status.NextBlock), name(status.NextItem))
+			}
+
+			if tt.nextChange.IsZero() {
+				if status.NextChange != nil {
+					t.Fatalf("finished schedule must have no next change, got %v", *status.NextChange)
+				}
+				return
+			}
+			if status.NextChange == nil || !status.NextChange.Equal(tt.nextChange) {
+				t.Fatalf("want next change %v, got %v", tt.nextChange, status.NextChange)
+			}
+		})
+	}
+}
+
+func TestAtCountdown(t *testing.T) {
+	status := At(domaintest.NewSavedSchedule("Фестиваль"), clock(10, 7).Add(30*time.Second))
+
+	block, item := status.CurrentBlock, status.CurrentItem
+	if block.BlockID != 1 || block.StartsInSeconds != 0 || block.RemainingSeconds != 22*60+30 {
+		t.Fatalf("unexpected current block %+v", block)
+	}
+	if item.BlockID != 1 || item.ItemID != 2 || item.RemainingSeconds != 7*60+30 {
+		t.Fatalf("unexpected current item %+v", item)
+	}
+
+	next := status.NextItem
+	if next.ItemID != 3 || next.StartsInSeconds != 13*60+30 || next.RemainingSeconds != 5*60 {
+		t.Fatalf("unexpected next item %+v", next)
+	}
+}
+
+func TestAtIdleBetweenBlocks(t *testing.T) {
+	schedule := domaintest.NewSavedSchedule("Фестиваль")
+	schedule.Blocks[1].StartTime = clock(11, 0)
+	schedule.Blocks[1].LayoutItems()
+
+	status := At(schedule, clock(10, 45))
+	if status.State != StateIdle || status.CurrentBlock != nil || name(status.NextBlock) != "Косплей" {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	if status.NextChange == nil || !status.NextChange.Equal(clock(11, 0)) {
+		t.Fatalf("want next change at 11:00, got %v", status.NextChange)
+	}
+}
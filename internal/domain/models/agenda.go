@@ -0,0 +1,62 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Виды записей ленты
+const (
+	AgendaKindBlock = "block"
+	AgendaKindItem  = "item"
+)
+
+// AgendaFilter — условия выборки ленты. Пустые списки не ограничивают выборку.
+type AgendaFilter struct {
+	From time.Time
+	To   time.Time
+	// ScheduleIDs, Types и Tracks ограничивают выборку расписаниями, типами и
+	// треками блоков; элементы попадают в ленту по условиям своего блока
+	ScheduleIDs []uint
+	Types       []string
+	Tracks      []string
+	// Items добавляет в ленту элементы блоков
+	Items bool
+}
+
+// AgendaEntry — блок или элемент сохраненного расписания, который пересекается
+// с интервалом ленты. Type — тип блока или элемента, Track — трек блока.
+type AgendaEntry struct {
+	Kind         string    `json:"kind"`
+	ScheduleID   uint      `json:"schedule_id"`
+	ScheduleName string    `json:"schedule_name"`
+	BlockID      uint      `json:"block_id"`
+	BlockName    string    `json:"block_name"`
+	ItemID       uint      `json:"item_id,omitempty"`
+	ItemName     string    `json:"item_name,omitempty"`
+	Type         string    `json:"type"`
+	Track        string    `json:"track"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+}
+
+// SortAgenda упорядочивает ленту по времени начала; при равном начале блок
+// идет перед своими элементами, а расписания — по возрастанию ID
+func SortAgenda(entries []AgendaEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !a.StartTime.Equal(b.StartTime) {
+			return a.StartTime.Before(b.StartTime)
+		}
+		if a.Kind != b.Kind {
+			return a.Kind == AgendaKindBlock
+		}
+		if a.ScheduleID != b.ScheduleID {
+			return a.ScheduleID < b.ScheduleID
+		}
+		if a.BlockID != b.BlockID {
+			return a.BlockID < b.BlockID
+		}
+		return a.ItemID < b.ItemID
+	})
+}
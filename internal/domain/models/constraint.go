@@ -0,0 +1,86 @@
+package models
+
+import "strings"
+
+// Виды ограничений между блоками
+const (
+	// ConstraintAfter — блок идет позже цели
+	ConstraintAfter = "after"
+	// ConstraintMinGap — блок начинается не раньше чем через Minutes после окончания цели
+	ConstraintMinGap = "min_gap"
+	// ConstraintAdjacent — блок стоит вплотную до или после цели
+	ConstraintAdjacent = "adjacent"
+)
+
+// BlockConstraint — ограничение между блоками расписания. Блок задается по ID
+// (BlockID), а новый, еще не сохраненный блок — по имени (Block); при
+// сохранении ограничение получает ID и текущее имя блока, поэтому переименование
+// блока его не ломает. Цель — блок TargetBlockID или TargetBlock либо все блоки
+// типа TargetType.
+type BlockConstraint struct {
+	ID            uint   `json:"id" gorm:"primarykey;autoIncrement"`
+	ScheduleID    uint   `json:"schedule_id" gorm:"not null;index"`
+	Type          string `json:"type" gorm:"not null"`
+	BlockID       uint   `json:"block_id,omitempty" gorm:"not null;default:0"`
+	Block         string `json:"block" gorm:"not null"`
+	TargetBlockID uint   `json:"target_block_id,omitempty" gorm:"not null;default:0"`
+	TargetBlock   string `json:"target_block,omitempty"`
+	TargetType    string `json:"target_type,omitempty"`
+	Minutes       int    `json:"minutes,omitempty"`
+}
+
+// ConstraintBlocks возвращает индексы блоков, на которые указывает ссылка
+// ограничения: блоки с ID id, если он задан, иначе блоки с именем name
+func (s *Schedule) ConstraintBlocks(id uint, name string) []int {
+	name = strings.TrimSpace(name)
+	var matches []int
+	for i := range s.Blocks {
+		if id != 0 && s.Blocks[i].ID == id || id == 0 && name != "" && strings.TrimSpace(s.Blocks[i].Name) == name {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// ConstraintRef — индексы блока и блока-цели ограничения; -1 — блок не найден
+// однозначно или цель задана типом
+type ConstraintRef struct {
+	Block  int
+	Target int
+}
+
+// ResolveConstraints находит блоки ограничений расписания. Хранилище вызывает
+// его до того, как выдаст новым блокам ID, а BindConstraints — после.
+func (s *Schedule) ResolveConstraints() []ConstraintRef {
+	refs := make([]ConstraintRef, len(s.Constraints))
+	for i, constraint := range s.Constraints {
+		refs[i] = ConstraintRef{
+			Block:  s.uniqueConstraintBlock(constraint.BlockID, constraint.Block),
+			Target: -1,
+		}
+		if constraint.TargetBlockID != 0 || strings.TrimSpace(constraint.TargetBlock) != "" {
+			refs[i].Target = s.uniqueConstraintBlock(constraint.TargetBlockID, constraint.TargetBlock)
+		}
+	}
+	return refs
+}
+
+// BindConstraints записывает в ограничения ID и имена блоков, найденных ResolveConstraints
+func (s *Schedule) BindConstraints(refs []ConstraintRef) {
+	for i, ref := range refs {
+		constraint := &s.Constraints[i]
+		if ref.Block >= 0 {
+			constraint.BlockID, constraint.Block = s.Blocks[ref.Block].ID, s.Blocks[ref.Block].Name
+		}
+		if ref.Target >= 0 {
+			constraint.TargetBlockID, constraint.TargetBlock = s.Blocks[ref.Target].ID, s.Blocks[ref.Target].Name
+		}
+	}
+}
+
+func (s *Schedule) uniqueConstraintBlock(id uint, name string) int {
+	if matches := s.ConstraintBlocks(id, name); len(matches) == 1 {
+		return matches[0]
+	}
+	return -1
+}
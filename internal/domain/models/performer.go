@@ -0,0 +1,67 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Performer — артист или спикер, который может выступать в нескольких
+// расписаниях. Элементы ссылаются на выступающих через BlockItem.PerformerIDs.
+type Performer struct {
+	ID   uint   `json:"id" gorm:"primarykey;autoIncrement"`
+	Name string `json:"name" gorm:"not null"`
+	// Contact — телефон, почта или другой способ связи
+	Contact string `json:"contact"`
+	// BufferMinutes — время на дорогу и подготовку, которое нужно между выступлениями
+	BufferMinutes int `json:"buffer_minutes" gorm:"not null;default:0"`
+	// Availability — окна, когда выступающий свободен; пустой список означает, что ограничений нет
+	Availability []PerformerAvailability `json:"availability" gorm:"foreignKey:PerformerID;constraint:OnDelete:CASCADE"`
+	CreatedAt    time.Time               `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time               `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// PerformerAvailability — окно, в которое выступающий может выступать
+type PerformerAvailability struct {
+	ID          uint      `json:"id" gorm:"primarykey;autoIncrement"`
+	PerformerID uint      `json:"performer_id" gorm:"not null;index"`
+	StartTime   time.Time `json:"start_time" gorm:"not null"`
+	EndTime     time.Time `json:"end_time" gorm:"not null"`
+}
+
+// TableName задает имя таблицы окон доступности
+func (PerformerAvailability) TableName() string {
+	return "performer_availability"
+}
+
+// ItemPerformer — связь элемента блока с выступающим
+type ItemPerformer struct {
+	ItemID      uint `gorm:"primaryKey;autoIncrement:false"`
+	PerformerID uint `gorm:"primaryKey;autoIncrement:false"`
+}
+
+// Appearance — выступление выступающего в элементе сохраненного расписания
+type Appearance struct {
+	PerformerID  uint      `json:"performer_id"`
+	ScheduleID   uint      `json:"schedule_id"`
+	ScheduleName string    `json:"schedule_name"`
+	BlockID      uint      `json:"block_id"`
+	BlockName    string    `json:"block_name"`
+	ItemID       uint      `json:"item_id"`
+	ItemName     string    `json:"item_name"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+}
+
+// SortedIDs возвращает ID по возрастанию без повторов; результат не бывает nil
+func SortedIDs(ids []uint) []uint {
+	result := make([]uint, 0, len(ids))
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Reminder — напоминание о скором начале блока или выступления
+type Reminder struct {
+	ID         uint `json:"id" gorm:"primarykey;autoIncrement"`
+	ScheduleID uint `json:"schedule_id" gorm:"not null;index"`
+	BlockID    uint `json:"block_id" gorm:"not null"`
+	// ItemID — элемент; 0 — напоминание о начале блока
+	ItemID uint `json:"item_id" gorm:"not null;default:0"`
+	// Minutes — за сколько минут до начала напомнить
+	Minutes int `json:"minutes" gorm:"not null"`
+	// StartsAt — начало блока или элемента
+	StartsAt time.Time `json:"starts_at" gorm:"not null"`
+	FireAt   time.Time `json:"fire_at" gorm:"not null"`
+	// SentAt — когда напоминание было взято на отправку
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// ReminderKey — то, о чем напоминание: блок или элемент и срок
+type ReminderKey struct {
+	BlockID uint
+	ItemID  uint
+	Minutes int
+}
+
+func (r *Reminder) Key() ReminderKey {
+	return ReminderKey{BlockID: r.BlockID, ItemID: r.ItemID, Minutes: r.Minutes}
+}
@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+// Resource — площадка, зал, оборудование или переводчик, общие для всех
+// расписаний. Блоки и элементы бронируют ресурс через Block.Resources и
+// BlockItem.Resources.
+type Resource struct {
+	ID   uint   `json:"id" gorm:"primarykey;autoIncrement"`
+	Name string `json:"name" gorm:"not null"`
+	// Type — вид ресурса: stage, room, equipment, interpreter или любой другой
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	// Capacity — сколько единиц ресурса можно забронировать одновременно
+	Capacity  int       `json:"capacity" gorm:"not null;default:1"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// ResourceAssignment — бронь ресурса блоком или элементом
+type ResourceAssignment struct {
+	ResourceID uint `json:"resource_id"`
+	// Quantity — сколько единиц ресурса занято
+	Quantity int `json:"quantity"`
+}
+
+// ResourceBooking — строка брони ресурса. Бронь блока хранится без ItemID.
+type ResourceBooking struct {
+	ID         uint `gorm:"primarykey;autoIncrement"`
+	ResourceID uint `gorm:"not null"`
+	BlockID    uint `gorm:"not null"`
+	ItemID     *uint
+	Quantity   int `gorm:"not null"`
+}
+
+// ResourceUsage — бронь ресурса в сохраненном расписании. Бронь блока
+// занимает ресурс на время блока вместе с техперерывом после него, бронь
+// элемента — на время элемента.
+type ResourceUsage struct {
+	ResourceID   uint      `json:"resource_id"`
+	ScheduleID   uint      `json:"schedule_id"`
+	ScheduleName string    `json:"schedule_name"`
+	BlockID      uint      `json:"block_id"`
+	BlockName    string    `json:"block_name"`
+	ItemID       uint      `json:"item_id,omitempty"`
+	ItemName     string    `json:"item_name,omitempty"`
+	Quantity     int       `json:"quantity"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+}
+
+// ResourceUsages возвращает брони ресурсов блока и его элементов с рассчитанными
+// временами; времена элементов должны быть рассчитаны LayoutItems
+func (b *Block) ResourceUsages(scheduleID uint, scheduleName string) []ResourceUsage {
+	var usages []ResourceUsage
+	for _, assignment := range b.Resources {
+		usages = append(usages, ResourceUsage{
+			ResourceID:   assignment.ResourceID,
+			ScheduleID:   scheduleID,
+			ScheduleName: scheduleName,
+			BlockID:      b.ID,
+			BlockName:    b.Name,
+			Quantity:     assignment.Quantity,
+			StartTime:    b.StartTime,
+			EndTime:      b.EndTime(),
+		})
+	}
+	for _, item := range b.Items {
+		for _, assignment := range item.Resources {
+			usages = append(usages, ResourceUsage{
+				ResourceID:   assignment.ResourceID,
+				ScheduleID:   scheduleID,
+				ScheduleName: scheduleName,
+				BlockID:      b.ID,
+				BlockName:    b.Name,
+				ItemID:       item.ID,
+				ItemName:     item.Name,
+				Quantity:     assignment.Quantity,
+				StartTime:    item.StartTime,
+				EndTime:      item.EndTime,
+			})
+		}
+	}
+	return usages
+}
+
+// CopyAssignments возвращает копию броней в том же порядке; результат не бывает nil
+func CopyAssignments(assignments []ResourceAssignment) []ResourceAssignment {
+	return append([]ResourceAssignment{}, assignments...)
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// OrganizationRuleSetID — ScheduleID набора правил организации, который действует
+// для расписаний без собственного набора
+const OrganizationRuleSetID uint = 0
+
+// RuleSet — исходный текст набора правил (YAML или JSON) расписания или организации
+type RuleSet struct {
+	ScheduleID uint      `json:"schedule_id" gorm:"primaryKey;autoIncrement:false"`
+	Source     string    `json:"source" gorm:"not null"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
@@ -2,64 +2,180 @@
 package models
 
 import (
-	"errors"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// Политики размещения времени блока, не занятого элементами
+const (
+	// SlackPolicyEnd оставляет свободное время в конце блока (по умолчанию)
+	SlackPolicyEnd = "end"
+	// SlackPolicyStart оставляет свободное время в начале блока
+	SlackPolicyStart = "start"
+	// SlackPolicySpread делит свободное время поровну перед каждым элементом и после последнего
+	SlackPolicySpread = "spread"
+)
+
+// Уровни видимости блоков и элементов: каждая аудитория видит свой уровень и
+// все уровни ниже
+const (
+	// VisibilityPublic — видно всем, включая участников (по умолчанию)
+	VisibilityPublic = "public"
+	// VisibilityPerformers — видно выступающим и команде
+	VisibilityPerformers = "performers"
+	// VisibilityCrew — видно только команде
+	VisibilityCrew = "crew"
+)
+
+// Языки публичной страницы расписания
+const (
+	LanguageRussian = "ru"
+	LanguageEnglish = "en"
+)
+
 type Schedule struct {
-	ID        uint           `json:"id" gorm:"primarykey;autoIncrement"`
-	Name      string         `json:"name" gorm:"not null"`
-	StartDate time.Time      `json:"start_date" gorm:"not null"`
-	EndDate   time.Time      `json:"end_date" gorm:"not null"`
-	Blocks    []Block        `json:"blocks" gorm:"foreignKey:ScheduleID;constraint:OnDelete:CASCADE"`
-	CreatedAt time.Time      `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
-	UpdatedAt time.Time      `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID          uint              `json:"id" gorm:"primarykey;autoIncrement"`
+	Name        string            `json:"name" gorm:"not null"`
+	Slug        string            `json:"slug" gorm:"not null;default:''"`     // адрес публичной страницы /p/{slug}, пустой — страницы нет
+	Language    string            `json:"language" gorm:"not null;default:''"` // ru (по умолчанию) или en
+	Timezone    string            `json:"timezone" gorm:"not null;default:''"` // зона IANA для показа времени, по умолчанию UTC
+	StartDate   time.Time         `json:"start_date" gorm:"not null"`
+	EndDate     time.Time         `json:"end_date" gorm:"not null"`
+	Blocks      []Block           `json:"blocks" gorm:"foreignKey:ScheduleID;constraint:OnDelete:CASCADE"`
+	Constraints []BlockConstraint `json:"constraints" gorm:"foreignKey:ScheduleID;constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time         `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time         `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	DeletedAt   gorm.DeletedAt    `json:"-" gorm:"index"`
 }
 
 type Block struct {
-	ID                uint           `json:"id" gorm:"primarykey;autoIncrement"`
-	ScheduleID        uint           `json:"schedule_id" gorm:"not null"`
-	Name              string         `json:"name" gorm:"not null"`
-	Type              string         `json:"type"`
-	StartTime         time.Time      `json:"start_time"`
-	Duration          int            `json:"duration" gorm:"not null"`
-	TechBreakDuration int            `json:"tech_break_duration"`
-	Items             []BlockItem    `json:"items" gorm:"foreignKey:BlockID;constraint:OnDelete:CASCADE"`
-	Order             int            `json:"order" gorm:"not null"`
-	CreatedAt         time.Time      `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
-	UpdatedAt         time.Time      `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
-	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                uint                 `json:"id" gorm:"primarykey;autoIncrement"`
+	ScheduleID        uint                 `json:"schedule_id" gorm:"not null;index"`
+	Name              string               `json:"name" gorm:"not null"`
+	Type              string               `json:"type"`
+	Track             string               `json:"track" gorm:"not null;default:''"`
+	StartTime         time.Time            `json:"start_time"`
+	FinishTime        time.Time            `json:"-" gorm:"column:end_time"` // конец без техперерыва, рассчитывается при сохранении
+	Duration          int                  `json:"duration" gorm:"not null"`
+	TechBreakDuration int                  `json:"tech_break_duration"`
+	ItemGap           int                  `json:"item_gap" gorm:"not null;default:0"`
+	SlackPolicy       string               `json:"slack_policy" gorm:"not null;default:''"`
+	Visibility        string               `json:"visibility" gorm:"not null;default:''"` // public (по умолчанию), performers или crew
+	Notes             string               `json:"notes" gorm:"not null;default:''"`      // заметки для команды
+	Resources         []ResourceAssignment `json:"resources" gorm:"-"`
+	Items             []BlockItem          `json:"items" gorm:"foreignKey:BlockID;constraint:OnDelete:CASCADE"`
+	Order             int                  `json:"order" gorm:"not null"`
+	CreatedAt         time.Time            `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt         time.Time            `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	DeletedAt         gorm.DeletedAt       `json:"-" gorm:"index"`
 }
 
 type BlockItem struct {
-	ID          uint           `json:"id" gorm:"primarykey;autoIncrement"`
-	BlockID     uint           `json:"block_id" gorm:"not null"`
-	Name        string         `json:"name" gorm:"not null"`
-	Type        string         `json:"type"`
-	Description string         `json:"description"`
-	Duration    int            `json:"duration" gorm:"not null"`
-	Order       int            `json:"order" gorm:"not null"`
-	CreatedAt   time.Time      `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
-	UpdatedAt   time.Time      `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID           uint                 `json:"id" gorm:"primarykey;autoIncrement"`
+	BlockID      uint                 `json:"block_id" gorm:"not null;index"`
+	Name         string               `json:"name" gorm:"not null"`
+	Type         string               `json:"type"`
+	Description  string               `json:"description"`
+	Duration     int                  `json:"duration" gorm:"not null"`
+	Priority     int                  `json:"priority" gorm:"not null;default:0"`
+	MinDuration  int                  `json:"min_duration" gorm:"not null;default:0"`
+	StartTime    time.Time            `json:"start_time"`
+	EndTime      time.Time            `json:"end_time"`
+	CallOffset   int                  `json:"call_offset" gorm:"not null;default:0"` // за сколько минут до начала выступающим нужно быть на месте
+	Visibility   string               `json:"visibility" gorm:"not null;default:''"` // public (по умолчанию), performers или crew
+	Notes        string               `json:"notes" gorm:"not null;default:''"`      // заметки для команды
+	PerformerIDs []uint               `json:"performer_ids" gorm:"-"`
+	Resources    []ResourceAssignment `json:"resources" gorm:"-"`
+	Order        int                  `json:"order" gorm:"not null"`
+	CreatedAt    time.Time            `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time            `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	DeletedAt    gorm.DeletedAt       `json:"-" gorm:"index"`
 }
 
 func (b *Block) EndTime() time.Time {
 	return b.StartTime.Add(time.Duration(b.Duration+b.TechBreakDuration) * time.Minute)
 }
 
-func (b *Block) Validate() error {
-	if b.Name == "" {
-		return errors.New("block name is required")
+// ContentDuration возвращает длительность элементов блока вместе с
+// перестановками между ними
+func (b *Block) ContentDuration() int {
+	if len(b.Items) == 0 {
+		return 0
 	}
-	if b.Duration <= 0 {
-		return errors.New("block duration must be positive")
+	return b.ItemsDuration() + b.ItemGap*(len(b.Items)-1)
+}
+
+// LayoutItems рассчитывает времена начала и конца элементов внутри блока:
+// элементы идут по порядку с перестановкой ItemGap между ними, а время блока,
+// не занятое элементами, размещается по SlackPolicy
+func (b *Block) LayoutItems() {
+	slack := max(b.Duration-b.ContentDuration(), 0)
+	parts := len(b.Items) + 1
+
+	current := b.StartTime
+	if b.SlackPolicy == SlackPolicyStart {
+		current = current.Add(time.Duration(slack) * time.Minute)
 	}
-	if b.StartTime.IsZero() {
-		return errors.New("block start time must be set")
+	for i := range b.Items {
+		item := &b.Items[i]
+		if i > 0 {
+			current = current.Add(time.Duration(b.ItemGap) * time.Minute)
+		}
+		if b.SlackPolicy == SlackPolicySpread {
+			// Остаток от деления достается первым промежуткам
+			share := slack / parts
+			if i < slack%parts {
+				share++
+			}
+			current = current.Add(time.Duration(share) * time.Minute)
+		}
+		item.StartTime = current
+		item.EndTime = current.Add(time.Duration(item.Duration) * time.Minute)
+		current = item.EndTime
+	}
+}
+
+// Location возвращает часовой пояс расписания; пустая или неизвестная зона
+// считается UTC
+func (s *Schedule) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// CallTime возвращает время, к которому выступающие элемента должны быть на месте
+func (i *BlockItem) CallTime() time.Time {
+	return i.StartTime.Add(-time.Duration(i.CallOffset) * time.Minute)
+}
+
+// VisibleTo сообщает, видит ли аудитория уровня audience блок или элемент с
+// уровнем visibility; неизвестный уровень видит только команда
+func VisibleTo(visibility, audience string) bool {
+	return visibilityRank(visibility) <= visibilityRank(audience)
+}
+
+func visibilityRank(visibility string) int {
+	switch visibility {
+	case "", VisibilityPublic:
+		return 0
+	case VisibilityPerformers:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ItemsDuration возвращает суммарную длительность элементов блока в минутах
+func (b *Block) ItemsDuration() int {
+	total := 0
+	for _, item := range b.Items {
+		total += item.Duration
 	}
-	return nil
+	return total
 }
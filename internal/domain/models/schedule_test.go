@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLayoutItems(t *testing.T) {
+	start := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	// Элементы 10 и 20 минут с перестановкой 5 минут в блоке на 45 минут: 10 минут свободны
+	tests := []struct {
+		policy string
+		starts []time.Time
+	}{
+		{"", []time.Time{at(0), at(15)}},
+		{SlackPolicyEnd, []time.Time{at(0), at(15)}},
+		{SlackPolicyStart, []time.Time{at(10), at(25)}},
+		// 10 минут на три промежутка: 4 перед первым, 3 перед вторым, 3 в конце
+		{SlackPolicySpread, []time.Time{at(4), at(22)}},
+	}
+
+	for _, tt := range tests {
+		block := Block{
+			StartTime:   start,
+			Duration:    45,
+			ItemGap:     5,
+			SlackPolicy: tt.policy,
+			Items:       []BlockItem{{Name: "Первый", Duration: 10}, {Name: "Второй", Duration: 20}},
+		}
+		block.LayoutItems()
+
+		for i, want := range tt.starts {
+			item := block.Items[i]
+			if !item.StartTime.Equal(want) {
+				t.Errorf("%q: item %d starts at %s, want %s", tt.policy, i, item.StartTime.Format("15:04"), want.Format("15:04"))
+			}
+			if !item.EndTime.Equal(item.StartTime.Add(time.Duration(item.Duration) * time.Minute)) {
+				t.Errorf("%q: item %d ends at %s", tt.policy, i, item.EndTime.Format("15:04"))
+			}
+		}
+	}
+}
+
+func TestContentDurationIncludesGaps(t *testing.T) {
+	block := Block{ItemGap: 3, Items: []BlockItem{{Duration: 10}, {Duration: 5}, {Duration: 5}}}
+	if got := block.ContentDuration(); got != 26 {
+		t.Fatalf("want 26, got %d", got)
+	}
+	if got := (&Block{ItemGap: 3}).ContentDuration(); got != 0 {
+		t.Fatalf("block without items: want 0, got %d", got)
+	}
+}
@@ -0,0 +1,35 @@
+// internal/domain/models/search.go
+package models
+
+// Виды записей поискового индекса
+const (
+	SearchKindSchedule = "schedule"
+	SearchKindBlock    = "block"
+	SearchKindItem     = "item"
+)
+
+// SearchEntry — строка полнотекстового индекса. Индекс перестраивается
+// репозиторием расписаний в той же транзакции, что и запись самих данных.
+type SearchEntry struct {
+	ID         uint   `json:"id" gorm:"primarykey;autoIncrement"`
+	ScheduleID uint   `json:"schedule_id" gorm:"not null;index"`
+	BlockID    *uint  `json:"block_id"`
+	ItemID     *uint  `json:"item_id"`
+	Kind       string `json:"kind" gorm:"not null"`
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	Document   string `json:"-" gorm:"type:tsvector;index:idx_search_entries_document,type:gin"`
+}
+
+// SearchHit — результат поиска с путем до найденного элемента
+type SearchHit struct {
+	Kind         string  `json:"kind"`
+	Rank         float64 `json:"rank"`
+	ScheduleID   uint    `json:"schedule_id"`
+	ScheduleName string  `json:"schedule_name"`
+	BlockID      *uint   `json:"block_id,omitempty"`
+	BlockName    string  `json:"block_name,omitempty"`
+	ItemID       *uint   `json:"item_id,omitempty"`
+	ItemName     string  `json:"item_name,omitempty"`
+	Snippet      string  `json:"snippet"`
+}
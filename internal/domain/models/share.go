@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ShareLink — ссылка только для чтения на одно представление расписания или
+// его версии. Доступ дает подписанный токен, который строится из полей ссылки.
+type ShareLink struct {
+	ID         uint   `json:"id" gorm:"primarykey;autoIncrement"`
+	ScheduleID uint   `json:"schedule_id" gorm:"not null;index"`
+	View       string `json:"view" gorm:"not null"`
+	// Version — номер версии расписания; без него ссылка показывает текущее расписание
+	Version        *int       `json:"version,omitempty"`
+	Label          string     `json:"label" gorm:"not null;default:''"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	AccessCount    int64      `json:"access_count" gorm:"not null;default:0"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	// Token заполняется сервисом и не хранится
+	Token string `json:"token,omitempty" gorm:"-"`
+}
+
+// Active сообщает, действует ли ссылка в момент now
+func (l *ShareLink) Active(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt)
+}
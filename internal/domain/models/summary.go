@@ -0,0 +1,35 @@
+// internal/domain/models/summary.go
+package models
+
+import "time"
+
+// Статусы расписания относительно текущего времени
+const (
+	ScheduleStatusUpcoming = "upcoming"
+	ScheduleStatusOngoing  = "ongoing"
+	ScheduleStatusFinished = "finished"
+)
+
+// ScheduleSummary — облегченное представление расписания для списков
+type ScheduleSummary struct {
+	ID            uint      `json:"id"`
+	Name          string    `json:"name"`
+	StartDate     time.Time `json:"start_date"`
+	EndDate       time.Time `json:"end_date"`
+	BlockCount    int       `json:"block_count"`
+	TotalDuration int       `json:"total_duration"`
+	Status        string    `json:"status"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ScheduleStatusAt вычисляет статус расписания на момент now
+func ScheduleStatusAt(startDate, endDate, now time.Time) string {
+	switch {
+	case now.Before(startDate):
+		return ScheduleStatusUpcoming
+	case now.Before(endDate):
+		return ScheduleStatusOngoing
+	default:
+		return ScheduleStatusFinished
+	}
+}
@@ -2,25 +2,28 @@
 package models
 
 import (
-	"encoding/json"
 	"time"
 )
 
 type ScheduleVersion struct {
-	ID         uint            `json:"id" gorm:"primarykey"`
-	ScheduleID uint            `json:"schedule_id"`
-	Version    int             `json:"version"`
-	Data       json.RawMessage `json:"data" gorm:"type:jsonb"`
-	Changes    string          `json:"changes"`
-	CreatedBy  string          `json:"created_by"`
-	CreatedAt  time.Time       `json:"created_at"`
-	IsActive   bool            `json:"is_active"`
+	ID         uint      `json:"id" gorm:"primarykey"`
+	ScheduleID uint      `json:"schedule_id"`
+	Version    int       `json:"version"`
+	Data       JSON      `json:"data"`
+	Changes    string    `json:"changes"`
+	CreatedBy  string    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	IsActive   bool      `json:"is_active"`
+	// RestoredFrom — номер версии, из которой была восстановлена эта версия
+	RestoredFrom *int `json:"restored_from,omitempty"`
 }
 
 type VersionMetadata struct {
-	Version   int       `json:"version"`
-	CreatedAt time.Time `json:"created_at"`
-	Changes   string    `json:"changes"`
+	Version      int       `json:"version"`
+	CreatedAt    time.Time `json:"created_at"`
+	CreatedBy    string    `json:"created_by,omitempty"`
+	Changes      string    `json:"changes"`
+	RestoredFrom *int      `json:"restored_from,omitempty"`
 }
 
 type VersionDiff struct {
@@ -0,0 +1,241 @@
+// Package notify описывает уведомления выступающих о смещении их выступлений и
+// напоминания о скором начале: что отправляется, как накопленные за серию
+// правок смещения объединяются, когда срабатывают напоминания и через какой
+// интерфейс подключаются каналы доставки. Сами каналы — почта,
+// вебхук, Telegram — реализованы в internal/infrastructure/notifier.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"cor-events-scheduler/internal/domain/changelog"
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// ErrNoAddress — у выступающего нет адреса для канала доставки
+var ErrNoAddress = errors.New("performer has no address for this channel")
+
+// Notifier доставляет уведомление выступающему
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// Recipient — выступающий, которому адресовано уведомление
+type Recipient struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Contact string `json:"contact"`
+}
+
+// Move — смещение одного выступления
+type Move struct {
+	ItemID uint      `json:"item_id"`
+	Name   string    `json:"name"`
+	Block  string    `json:"block"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
+
+// Upcoming — скорое начало блока или выступления
+type Upcoming struct {
+	Name string `json:"name"`
+	// Block — блок выступления; пусто в напоминании о самом блоке
+	Block    string    `json:"block,omitempty"`
+	StartsAt time.Time `json:"starts_at"`
+	Minutes  int       `json:"minutes"`
+}
+
+// Notification — все смещения выступлений одного выступающего в одном
+// расписании или, если заполнено Upcoming, напоминание о скором начале
+type Notification struct {
+	Recipient    Recipient `json:"recipient"`
+	ScheduleID   uint      `json:"schedule_id"`
+	ScheduleName string    `json:"schedule_name"`
+	Language     string    `json:"language"`
+	Timezone     string    `json:"timezone"`
+	Moves        []Move    `json:"moves,omitempty"`
+	Upcoming     *Upcoming `json:"upcoming,omitempty"`
+}
+
+// messages — формулировки уведомления на одном языке
+type messages struct {
+	subject  string
+	greeting string
+	// anonymous — приветствие получателя без имени
+	anonymous string
+	intro     string
+	move      string
+	// reminderSubject и reminder принимают предмет напоминания, минуты и время начала
+	reminderSubject string
+	reminder        string
+	// inBlock — выступление вместе с его блоком
+	inBlock  string
+	block    string
+	timezone string
+	quote    func(string) string
+	date     func(time.Time) string
+}
+
+var languages = map[string]*messages{
+	models.LanguageRussian: {
+		subject:         "Изменилось время выступления — %s",
+		greeting:        "Здравствуйте, %s!",
+		anonymous:       "Здравствуйте!",
+		intro:           "В расписании %s изменилось время:",
+		move:            "— %s (блок %s): %s → %s",
+		reminderSubject: "Через %[2]d мин: %[1]s",
+		reminder:        "Через %[2]d мин, в %[3]s, начинается %[1]s.",
+		inBlock:         "%s (блок %s)",
+		block:           "блок %s",
+		timezone:        "Время указано в часовом поясе %s.",
+		quote:           func(s string) string { return "«" + s + "»" },
+		date:            func(t time.Time) string { return t.Format("02.01") },
+	},
+	models.LanguageEnglish: {
+		subject:         "Your slot has moved — %s",
+		greeting:        "Hello, %s!",
+		anonymous:       "Hello!",
+		intro:           "Times have changed in %s:",
+		move:            "- %s (block %s): %s → %s",
+		reminderSubject: "In %[2]d min: %[1]s",
+		reminder:        "%[1]s starts in %[2]d min, at %[3]s.",
+		inBlock:         "%s (block %s)",
+		block:           "Block %s",
+		timezone:        "Times are shown in %s.",
+		quote:           func(s string) string { return "'" + s + "'" },
+		date:            func(t time.Time) string { return t.Format("Jan 2") },
+	},
+}
+
+func (n *Notification) messages() *messages {
+	if m, ok := languages[n.Language]; ok {
+		return m
+	}
+	return languages[models.LanguageRussian]
+}
+
+func (n *Notification) location() *time.Location {
+	schedule := models.Schedule{Timezone: n.Timezone}
+	return schedule.Location()
+}
+
+// Subject возвращает тему уведомления на языке расписания
+func (n *Notification) Subject() string {
+	m := n.messages()
+	if n.Upcoming != nil {
+		return fmt.Sprintf(m.reminderSubject, n.Upcoming.Name, n.Upcoming.Minutes)
+	}
+	return fmt.Sprintf(m.subject, n.ScheduleName)
+}
+
+// Text возвращает текст уведомления на языке расписания; времена — в его
+// часовом поясе, дата указывается, если выступление перенесено на другой день
+func (n *Notification) Text() string {
+	m := n.messages()
+	location := n.location()
+
+	var b strings.Builder
+	if n.Recipient.Name != "" {
+		fmt.Fprintf(&b, m.greeting+"\n\n", n.Recipient.Name)
+	} else {
+		b.WriteString(m.anonymous + "\n\n")
+	}
+	if upcoming := n.Upcoming; upcoming != nil {
+		subject := fmt.Sprintf(m.block, m.quote(upcoming.Name))
+		if upcoming.Block != "" {
+			subject = fmt.Sprintf(m.inBlock, m.quote(upcoming.Name), m.quote(upcoming.Block))
+		}
+		fmt.Fprintf(&b, m.reminder+"\n", subject, upcoming.Minutes, upcoming.StartsAt.In(location).Format("15:04"))
+	}
+	if len(n.Moves) > 0 {
+		fmt.Fprintf(&b, m.intro+"\n", m.quote(n.ScheduleName))
+	}
+	for _, move := range n.Moves {
+		from, to := move.From.In(location), move.To.In(location)
+		toText := to.Format("15:04")
+		if from.YearDay() != to.YearDay() || from.Year() != to.Year() {
+			toText = m.date(to) + " " + toText
+		}
+		fmt.Fprintf(&b, m.move+"\n", m.quote(move.Name), m.quote(move.Block), m.date(from)+" "+from.Format("15:04"), toText)
+	}
+	if n.Timezone != "" {
+		fmt.Fprintf(&b, "\n"+m.timezone+"\n", location.String())
+	}
+	return b.String()
+}
+
+// Batch накапливает серию правок одного расписания. Смещения считаются между
+// состоянием до первой правки и после последней, поэтому повторные правки не
+// дают повторных уведомлений, мелкие сдвиги, вместе превысившие порог,
+// замечаются, а выступление, вернувшееся на прежнее время, выпадает.
+type Batch struct {
+	threshold time.Duration
+	before    *models.Schedule
+	after     *models.Schedule
+}
+
+// NewBatch создает пустую серию; смещения не больше threshold не сообщаются
+func NewBatch(threshold time.Duration) *Batch {
+	return &Batch{threshold: threshold}
+}
+
+// Add добавляет правку, переводящую расписание из before в after
+func (b *Batch) Add(before, after *models.Schedule) {
+	if b.before == nil {
+		b.before = before
+	}
+	b.after = after
+}
+
+// Shifts возвращает смещения выступлений за всю серию
+func (b *Batch) Shifts() []changelog.Shift {
+	if b.before == nil {
+		return nil
+	}
+	return changelog.ItemShifts(b.before, b.after, b.threshold)
+}
+
+// PerformerIDs возвращает выступающих, которым есть что сообщить
+func (b *Batch) PerformerIDs() []uint {
+	var ids []uint
+	seen := make(map[uint]bool)
+	for _, shift := range b.Shifts() {
+		for _, id := range shift.PerformerIDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// Notifications возвращает по уведомлению на выступающего из recipients со
+// всеми его смещенными выступлениями в порядке расписания
+func (b *Batch) Notifications(recipients []Recipient) []Notification {
+	shifts := b.Shifts()
+	var notifications []Notification
+	for _, recipient := range recipients {
+		n := Notification{
+			Recipient:    recipient,
+			ScheduleID:   b.after.ID,
+			ScheduleName: b.after.Name,
+			Language:     b.after.Language,
+			Timezone:     b.after.Timezone,
+		}
+		for _, shift := range shifts {
+			if slices.Contains(shift.PerformerIDs, recipient.ID) {
+				n.Moves = append(n.Moves, Move{ItemID: shift.ItemID, Name: shift.Name, Block: shift.Block, From: shift.From, To: shift.To})
+			}
+		}
+		if len(n.Moves) > 0 {
+			notifications = append(notifications, n)
+		}
+	}
+	return notifications
+}
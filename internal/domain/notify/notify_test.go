@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// newSchedule строит сохраненное расписание domaintest, где «Гимн» исполняет
+// выступающий 7, а «Участник 1» — выступающие 7 и 8
+func newSchedule() *models.Schedule {
+	schedule := domaintest.NewSavedSchedule("Фестиваль")
+	schedule.Blocks[0].Items[1].PerformerIDs = []uint{7}
+	schedule.Blocks[1].Items[0].PerformerIDs = []uint{7, 8}
+	return schedule
+}
+
+func shifted(schedule *models.Schedule, block int, minutes int) *models.Schedule {
+	next := *schedule
+	next.Blocks = append([]models.Block{}, schedule.Blocks...)
+	next.Blocks[block].StartTime = next.Blocks[block].StartTime.Add(time.Duration(minutes) * time.Minute)
+	return &next
+}
+
+func TestBatchMergesBurstOfEdits(t *testing.T) {
+	first := newSchedule()
+	second := shifted(first, 1, 10)
+	third := shifted(second, 1, 5)
+	fourth := shifted(third, 0, 30)
+
+	batch := NewBatch(5 * time.Minute)
+	batch.Add(first, second)
+	batch.Add(second, third)
+	batch.Add(third, fourth)
+
+	notifications := batch.Notifications([]Recipient{{ID: 7, Name: "Хор"}, {ID: 8, Name: "Косплеер"}, {ID: 9}})
+	if len(notifications) != 2 {
+		t.Fatalf("want notifications for performers 7 and 8, got %+v", notifications)
+	}
+	choir := notifications[0]
+	if choir.Recipient.ID != 7 || len(choir.Moves) != 2 || choir.Moves[0].Name != "Гимн" {
+		t.Fatalf("performer 7 must get both moves in one notification, got %+v", choir)
+	}
+	// Три правки второго блока сливаются в одно смещение на 15 минут
+	if move := choir.Moves[1]; move.Name != "Участник 1" || move.To.Sub(move.From) != 15*time.Minute {
+		t.Fatalf("unexpected merged move: %+v", move)
+	}
+}
+
+func TestBatchDropsRevertedMoves(t *testing.T) {
+	first := newSchedule()
+	second := shifted(first, 1, 20)
+	third := shifted(second, 1, -20)
+
+	batch := NewBatch(5 * time.Minute)
+	batch.Add(first, second)
+	batch.Add(second, third)
+	if ids := batch.PerformerIDs(); len(ids) != 0 {
+		t.Fatalf("reverted move must not be reported, got performers %v", ids)
+	}
+
+	// Два сдвига ниже порога вместе его превышают
+	batch = NewBatch(5 * time.Minute)
+	second = shifted(first, 1, 4)
+	batch.Add(first, second)
+	batch.Add(second, shifted(second, 1, 4))
+	if ids := batch.PerformerIDs(); len(ids) != 2 || ids[0] != 7 || ids[1] != 8 {
+		t.Fatalf("want performers 7 and 8, got %v", ids)
+	}
+}
+
+func TestNotificationText(t *testing.T) {
+	from := time.Date(2024, 4, 1, 7, 21, 0, 0, time.UTC)
+	n := Notification{
+		Recipient:    Recipient{ID: 7, Name: "Хор"},
+		ScheduleName: "Фестиваль",
+		Timezone:     "Europe/Moscow",
+		Moves: []Move{
+			{Name: "Гимн", Block: "Открытие", From: from, To: from.Add(15 * time.Minute)},
+			{Name: "Финал", Block: "Закрытие", From: from, To: from.Add(24 * time.Hour)},
+		},
+	}
+
+	want := "Здравствуйте, Хор!\n\n" +
+		"В расписании «Фестиваль» изменилось время:\n" +
+		"— «Гимн» (блок «Открытие»): 01.04 10:21 → 10:36\n" +
+		"— «Финал» (блок «Закрытие»): 01.04 10:21 → 02.04 10:21\n" +
+		"\nВремя указано в часовом поясе Europe/Moscow.\n"
+	if got := n.Text(); got != want {
+		t.Fatalf("unexpected text:\nwant %q\n got %q", want, got)
+	}
+
+	n.Language = models.LanguageEnglish
+	if got := n.Subject(); got != "Your slot has moved — Фестиваль" {
+		t.Fatalf("unexpected subject: %s", got)
+	}
+}
@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// PlanReminders возвращает напоминания за каждое из offsets минут до начала
+// каждого блока и каждого элемента с выступающими. Времена элементов должны
+// быть уже рассчитаны; ScheduleID заполняется из расписания.
+func PlanReminders(schedule *models.Schedule, offsets []int) []models.Reminder {
+	var reminders []models.Reminder
+	add := func(blockID, itemID uint, startsAt time.Time) {
+		for _, minutes := range offsets {
+			reminders = append(reminders, models.Reminder{
+				ScheduleID: schedule.ID,
+				BlockID:    blockID,
+				ItemID:     itemID,
+				Minutes:    minutes,
+				StartsAt:   startsAt,
+				FireAt:     startsAt.Add(-time.Duration(minutes) * time.Minute),
+			})
+		}
+	}
+
+	for _, block := range schedule.Blocks {
+		add(block.ID, 0, block.StartTime)
+		for _, item := range block.Items {
+			if len(item.PerformerIDs) > 0 {
+				add(block.ID, item.ID, item.StartTime)
+			}
+		}
+	}
+	return reminders
+}
+
+// Reminded — то, о чем напоминает напоминание, в текущем расписании
+type Reminded struct {
+	Block *models.Block
+	// Item — элемент; nil в напоминании о блоке
+	Item *models.BlockItem
+}
+
+// FindReminded находит блок и элемент напоминания в расписании. false —
+// их больше нет или они начинаются не в то время, на которое рассчитано
+// напоминание, то есть напоминание устарело.
+func FindReminded(schedule *models.Schedule, reminder *models.Reminder) (Reminded, bool) {
+	for i := range schedule.Blocks {
+		block := &schedule.Blocks[i]
+		if block.ID != reminder.BlockID {
+			continue
+		}
+		if reminder.ItemID == 0 {
+			return Reminded{Block: block}, block.StartTime.Equal(reminder.StartsAt)
+		}
+		for j := range block.Items {
+			item := &block.Items[j]
+			if item.ID == reminder.ItemID {
+				return Reminded{Block: block, Item: item}, item.StartTime.Equal(reminder.StartsAt)
+			}
+		}
+	}
+	return Reminded{}, false
+}
+
+// Remind возвращает напоминание для recipient о reminded
+func Remind(schedule *models.Schedule, reminder *models.Reminder, reminded Reminded, recipient Recipient) Notification {
+	upcoming := &Upcoming{Name: reminded.Block.Name, StartsAt: reminder.StartsAt, Minutes: reminder.Minutes}
+	if reminded.Item != nil {
+		upcoming.Name, upcoming.Block = reminded.Item.Name, reminded.Block.Name
+	}
+	return Notification{
+		Recipient:    recipient,
+		ScheduleID:   schedule.ID,
+		ScheduleName: schedule.Name,
+		Language:     schedule.Language,
+		Timezone:     schedule.Timezone,
+		Upcoming:     upcoming,
+	}
+}
@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+func TestPlanReminders(t *testing.T) {
+	schedule := newSchedule()
+	reminders := PlanReminders(schedule, []int{30, 10})
+
+	// По два напоминания на каждый из двух блоков и двух элементов с выступающими
+	if len(reminders) != 8 {
+		t.Fatalf("want 8 reminders, got %+v", reminders)
+	}
+	opening, anthem := schedule.Blocks[0], schedule.Blocks[0].Items[1]
+	if r := reminders[0]; r.ScheduleID != 1 || r.BlockID != opening.ID || r.ItemID != 0 || r.Minutes != 30 ||
+		!r.FireAt.Equal(opening.StartTime.Add(-30*time.Minute)) {
+		t.Fatalf("unexpected block reminder: %+v", r)
+	}
+	if r := reminders[3]; r.ItemID != anthem.ID || r.Minutes != 10 || !r.StartsAt.Equal(anthem.StartTime) {
+		t.Fatalf("unexpected item reminder: %+v", r)
+	}
+
+	reminded, ok := FindReminded(schedule, &reminders[3])
+	if !ok || reminded.Item.ID != anthem.ID {
+		t.Fatalf("reminder must find its item, got %+v, %v", reminded, ok)
+	}
+	// Сдвинутый блок делает напоминания о нем устаревшими
+	if _, ok := FindReminded(shifted(schedule, 0, 15), &reminders[0]); ok {
+		t.Fatal("reminder for a moved block must be stale")
+	}
+}
+
+func TestReminderText(t *testing.T) {
+	schedule := newSchedule()
+	schedule.Timezone = "Europe/Moscow"
+	reminder := PlanReminders(schedule, []int{10})[1]
+	reminded, _ := FindReminded(schedule, &reminder)
+
+	n := Remind(schedule, &reminder, reminded, Recipient{ID: 7, Name: "Хор"})
+	if got := n.Subject(); got != "Через 10 мин: Гимн" {
+		t.Fatalf("unexpected subject: %s", got)
+	}
+	want := "Здравствуйте, Хор!\n\n" +
+		"Через 10 мин, в " + reminder.StartsAt.In(schedule.Location()).Format("15:04") + ", начинается «Гимн» (блок «Открытие»).\n" +
+		"\nВремя указано в часовом поясе Europe/Moscow.\n"
+	if got := n.Text(); got != want {
+		t.Fatalf("unexpected text:\nwant %q\n got %q", want, got)
+	}
+
+	block := PlanReminders(schedule, []int{10})[0]
+	reminded, _ = FindReminded(schedule, &block)
+	n = Remind(schedule, &block, reminded, Recipient{})
+	n.Language = models.LanguageEnglish
+	want = "Hello!\n\nBlock 'Открытие' starts in 10 min, at 13:00.\n\nTimes are shown in Europe/Moscow.\n"
+	if got := n.Text(); got != want {
+		t.Fatalf("unexpected text:\nwant %q\n got %q", want, got)
+	}
+}
@@ -0,0 +1,587 @@
+// Package optimize подбирает порядок блоков и техперерывы между ними так,
+// чтобы сократить суммарное время перестановок и оценку риска, не нарушая
+// закрепленных позиций, ограничений порядка, ограничений между блоками
+// расписания, окна расписания и правил площадки.
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/risk"
+	"cor-events-scheduler/internal/domain/rules"
+	"cor-events-scheduler/internal/domain/validation"
+	"cor-events-scheduler/pkg/utils"
+)
+
+// penalty — стоимость минуты за пределами окна расписания и одного нарушения
+// блокирующего правила; заведомо больше любой выгоды от перестановок
+const penalty = 1000
+
+// maxPasses ограничивает число проходов локального поиска
+const maxPasses = 100
+
+// defaultMaxEvaluations — бюджет оценок вариантов по умолчанию
+const defaultMaxEvaluations = 20000
+
+// moveCost — стоимость переноса блока с его текущей позиции; из равноценных
+// вариантов выбирается тот, что меньше меняет расписание
+const moveCost = 0.001
+
+// Constraints — ограничения на порядок блоков, блоки задаются по ID
+type Constraints struct {
+	// Pinned — блоки, которые остаются на своих текущих позициях
+	Pinned []uint `json:"pinned,omitempty"`
+	// Precedence — пары блоков, где Before должен идти раньше After
+	Precedence []Precedence `json:"precedence,omitempty"`
+}
+
+type Precedence struct {
+	Before uint `json:"before"`
+	After  uint `json:"after"`
+}
+
+// Options задает стоимость перестановок. Нулевые значения заменяются значениями по умолчанию.
+type Options struct {
+	// ChangeoverMinutes — перестановка между блоками разных типов; рядом с тяжелым
+	// блоком используется MinHeavyTechBreak анализатора риска
+	ChangeoverMinutes int
+	// RiskWeight — сколько минут перестановок стоит единица оценки риска
+	RiskWeight float64
+	// MaxEvaluations ограничивает число оценок расписания (риск, правила и
+	// ограничения) за один вызов Optimize
+	MaxEvaluations int
+}
+
+// Summary — показатели расписания, по которым сравниваются варианты
+type Summary struct {
+	RiskScore float64 `json:"risk_score"`
+	// ChangeoverMinutes — сумма техперерывов между блоками
+	ChangeoverMinutes int       `json:"changeover_minutes"`
+	EndTime           time.Time `json:"end_time"`
+	// OverflowMinutes — на сколько последний блок выходит за конец расписания
+	OverflowMinutes int `json:"overflow_minutes"`
+	RuleViolations  int `json:"rule_violations"`
+	// ConstraintViolations — нарушенные ограничения между блоками, сохраненные в расписании
+	ConstraintViolations int `json:"constraint_violations"`
+}
+
+// Change описывает, как изменился блок в предложенном расписании
+type Change struct {
+	BlockID       uint      `json:"block_id"`
+	Name          string    `json:"name"`
+	FromIndex     int       `json:"from_index"`
+	ToIndex       int       `json:"to_index"`
+	FromStart     time.Time `json:"from_start"`
+	ToStart       time.Time `json:"to_start"`
+	FromTechBreak int       `json:"from_tech_break"`
+	ToTechBreak   int       `json:"to_tech_break"`
+}
+
+// Result — предложенное расписание и его отличия от текущего
+type Result struct {
+	Schedule *models.Schedule `json:"schedule"`
+	Current  Summary          `json:"current"`
+	Proposed Summary          `json:"proposed"`
+	Changes  []Change         `json:"changes"`
+	// Partial — поиск остановлен по бюджету оценок; предложение лучшее из найденных
+	Partial bool `json:"partial"`
+}
+
+// Optimizer подбирает порядок блоков локальным поиском: начиная с текущего
+// порядка (или ближайшего к нему допустимого), перебирает перестановки и
+// переносы незакрепленных блоков, пока это уменьшает стоимость
+type Optimizer struct {
+	analyzer *risk.Analyzer
+	options  Options
+}
+
+func NewOptimizer(analyzer *risk.Analyzer, options Options) *Optimizer {
+	if options.ChangeoverMinutes <= 0 {
+		options.ChangeoverMinutes = 5
+	}
+	if options.RiskWeight <= 0 {
+		options.RiskWeight = 60
+	}
+	if options.MaxEvaluations <= 0 {
+		options.MaxEvaluations = defaultMaxEvaluations
+	}
+	return &Optimizer{analyzer: analyzer, options: options}
+}
+
+// Optimize предлагает новый порядок блоков сохраненного расписания.
+// Исходное расписание не изменяется. Поиск детерминирован: одно и то же
+// расписание с теми же ограничениями и правилами дает то же предложение.
+func (o *Optimizer) Optimize(ctx context.Context, schedule *models.Schedule, constraints Constraints, ruleSet *rules.RuleSet) (*Result, error) {
+	plan, err := newPlan(schedule, constraints)
+	if err != nil {
+		return nil, err
+	}
+
+	best := identity(len(schedule.Blocks))
+	if !plan.valid(best) {
+		if best, err = plan.initialOrder(); err != nil {
+			return nil, err
+		}
+	}
+	s := &search{optimizer: o, schedule: schedule, ruleSet: ruleSet, budget: o.options.MaxEvaluations, seen: make(map[string]bool)}
+	bestCandidate := s.evaluate(best)
+
+	for pass := 0; pass < maxPasses && !s.exhausted; pass++ {
+		improved := false
+		for _, order := range plan.neighbours(best) {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("optimization stopped: %w", err)
+			}
+			// Уже оцененные порядки не лучше текущего, а порядки, которым не
+			// хватает одних обязательных перестановок, не оцениваются целиком
+			key := orderKey(order)
+			if s.seen[key] || s.lowerBound(order) >= bestCandidate.cost-1e-9 {
+				continue
+			}
+			// Оценка порядка — до двух вариантов перерыва после каждого блока
+			if s.budget < 2*len(order) {
+				s.exhausted = true
+				break
+			}
+			s.seen[key] = true
+			if candidate := s.evaluate(order); candidate.cost < bestCandidate.cost-1e-9 {
+				best, bestCandidate, improved = order, candidate, true
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+
+	return &Result{
+		Schedule: bestCandidate.schedule,
+		Current:  o.summarize(schedule, ruleSet),
+		Proposed: bestCandidate.summary,
+		Changes:  diff(schedule, bestCandidate.schedule, best),
+		Partial:  s.exhausted,
+	}, nil
+}
+
+type candidate struct {
+	schedule *models.Schedule
+	summary  Summary
+	cost     float64
+}
+
+// search — состояние одного вызова Optimize: оставшийся бюджет оценок и уже
+// оцененные порядки
+type search struct {
+	optimizer *Optimizer
+	schedule  *models.Schedule
+	ruleSet   *rules.RuleSet
+	budget    int
+	exhausted bool
+	seen      map[string]bool
+}
+
+// evaluate раскладывает блоки в заданном порядке, распределяет техперерывы и
+// считает стоимость. Перерывы сверх обязательных перестановок — стандартная
+// перестановка или техперерыв, заданный блоку в расписании, — добавляются там,
+// где они снижают риск и нарушения сильнее, чем стоят сами.
+func (s *search) evaluate(order []int) candidate {
+	o := s.optimizer
+	proposed := arrange(s.schedule, order)
+	for i := 0; i < len(proposed.Blocks)-1; i++ {
+		proposed.Blocks[i].TechBreakDuration = o.changeover(proposed.Blocks[i], proposed.Blocks[i+1])
+	}
+	if len(proposed.Blocks) > 0 {
+		proposed.Blocks[len(proposed.Blocks)-1].TechBreakDuration = 0
+	}
+	layout(proposed)
+	best := s.score(proposed)
+
+	for i := 0; i < len(proposed.Blocks)-1; i++ {
+		block := &proposed.Blocks[i]
+		assigned := block.TechBreakDuration
+		for _, extra := range []int{o.options.ChangeoverMinutes, s.schedule.Blocks[order[i]].TechBreakDuration} {
+			if extra <= block.TechBreakDuration || (extra == o.options.ChangeoverMinutes && assigned > 0) {
+				continue
+			}
+			previous := block.TechBreakDuration
+			block.TechBreakDuration = extra
+			layout(proposed)
+			if next := s.score(proposed); next.cost < best.cost-1e-9 {
+				best = next
+				continue
+			}
+			block.TechBreakDuration = previous
+			layout(proposed)
+		}
+	}
+
+	best.cost += s.moves(order)
+	return best
+}
+
+// lowerBound — стоимость, ниже которой оценка порядка опуститься не может:
+// обязательные перестановки и переносы блоков. Риск, нарушения и
+// дополнительные перерывы только добавляют к ней.
+func (s *search) lowerBound(order []int) float64 {
+	var minutes int
+	for i := 0; i < len(order)-1; i++ {
+		minutes += s.optimizer.changeover(s.schedule.Blocks[order[i]], s.schedule.Blocks[order[i+1]])
+	}
+	return float64(minutes) + s.moves(order)
+}
+
+// moves — стоимость переноса блоков с их текущих позиций
+func (s *search) moves(order []int) float64 {
+	var cost float64
+	for i, index := range order {
+		if i != index {
+			cost += moveCost
+		}
+	}
+	return cost
+}
+
+// score оценивает расписание и расходует единицу бюджета
+func (s *search) score(schedule *models.Schedule) candidate {
+	s.budget--
+	return s.optimizer.score(schedule, s.ruleSet)
+}
+
+// orderKey — ключ порядка блоков для учета уже оцененных вариантов
+func orderKey(order []int) string {
+	var b strings.Builder
+	for _, index := range order {
+		fmt.Fprintf(&b, "%d,", index)
+	}
+	return b.String()
+}
+
+// score считает показатели и стоимость расписания с рассчитанными временами
+func (o *Optimizer) score(schedule *models.Schedule, ruleSet *rules.RuleSet) candidate {
+	summary := o.summarize(schedule, ruleSet)
+	cost := float64(summary.ChangeoverMinutes) +
+		o.options.RiskWeight*summary.RiskScore +
+		penalty*float64(summary.OverflowMinutes+summary.RuleViolations+summary.ConstraintViolations)
+
+	return candidate{schedule: copyBlocks(schedule), summary: summary, cost: cost}
+}
+
+func (o *Optimizer) summarize(schedule *models.Schedule, ruleSet *rules.RuleSet) Summary {
+	summary := Summary{
+		RiskScore: o.analyzer.Analyze(schedule).Score,
+		EndTime:   schedule.StartDate,
+	}
+
+	for i, block := range schedule.Blocks {
+		if i < len(schedule.Blocks)-1 {
+			summary.ChangeoverMinutes += block.TechBreakDuration
+		}
+		summary.EndTime = block.StartTime.Add(time.Duration(block.Duration) * time.Minute)
+	}
+	if overflow := int(summary.EndTime.Sub(schedule.EndDate) / time.Minute); overflow > 0 {
+		summary.OverflowMinutes = overflow
+	}
+
+	for _, issue := range ruleSet.Evaluate(schedule).Issues {
+		if issue.Severity == validation.SeverityError {
+			summary.RuleViolations++
+		}
+	}
+	if len(schedule.Constraints) > 0 {
+		for _, issue := range validation.ValidateSchedule(schedule).Issues {
+			if issue.Code == validation.CodeConstraintViolated {
+				summary.ConstraintViolations++
+			}
+		}
+	}
+
+	return summary
+}
+
+// changeover возвращает время перестановки между соседними блоками
+func (o *Optimizer) changeover(block, next models.Block) int {
+	switch {
+	case o.analyzer.IsHeavy(block.Type) || o.analyzer.IsHeavy(next.Type):
+		return o.analyzer.Options().MinHeavyTechBreak
+	case !strings.EqualFold(strings.TrimSpace(block.Type), strings.TrimSpace(next.Type)):
+		return o.options.ChangeoverMinutes
+	default:
+		return 0
+	}
+}
+
+// arrange копирует расписание с блоками в заданном порядке
+func arrange(schedule *models.Schedule, order []int) *models.Schedule {
+	proposed := *schedule
+	proposed.Blocks = make([]models.Block, len(order))
+	for i, index := range order {
+		proposed.Blocks[i] = schedule.Blocks[index]
+		proposed.Blocks[i].Order = i + 1
+	}
+	return &proposed
+}
+
+func copyBlocks(schedule *models.Schedule) *models.Schedule {
+	cp := *schedule
+	cp.Blocks = append([]models.Block(nil), schedule.Blocks...)
+	return &cp
+}
+
+// layout располагает блоки подряд от начала расписания
+func layout(schedule *models.Schedule) {
+	current := schedule.StartDate
+	for i := range schedule.Blocks {
+		schedule.Blocks[i].StartTime = current
+		current = schedule.Blocks[i].EndTime()
+	}
+}
+
+// diff перечисляет блоки, у которых изменились позиция, начало или техперерыв
+func diff(current, proposed *models.Schedule, order []int) []Change {
+	changes := []Change{}
+	for to, from := range order {
+		before, after := current.Blocks[from], proposed.Blocks[to]
+		if from == to && before.StartTime.Equal(after.StartTime) && before.TechBreakDuration == after.TechBreakDuration {
+			continue
+		}
+		changes = append(changes, Change{
+			BlockID:       after.ID,
+			Name:          after.Name,
+			FromIndex:     from,
+			ToIndex:       to,
+			FromStart:     before.StartTime,
+			ToStart:       after.StartTime,
+			FromTechBreak: before.TechBreakDuration,
+			ToTechBreak:   after.TechBreakDuration,
+		})
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].ToIndex < changes[j].ToIndex })
+	return changes
+}
+
+func identity(n int) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+// plan — ограничения, переведенные с ID блоков на их текущие индексы
+type plan struct {
+	n int
+	// pinned отмечает индексы закрепленных блоков; закрепленный блок остается на своем индексе
+	pinned []bool
+	// before[b] — блоки, которые должны идти раньше b
+	before [][]int
+	// deadline[b] — позиция, раньше которой блок должен быть поставлен, чтобы
+	// не нарушить ограничения закрепленных блоков после него
+	deadline []int
+}
+
+func newPlan(schedule *models.Schedule, constraints Constraints) (*plan, error) {
+	n := len(schedule.Blocks)
+	index := make(map[uint]int, n)
+	for i, block := range schedule.Blocks {
+		index[block.ID] = i
+	}
+	lookup := func(id uint) (int, error) {
+		i, ok := index[id]
+		if !ok {
+			return 0, fmt.Errorf("%w: block %d does not belong to schedule %d", utils.ErrInvalidInput, id, schedule.ID)
+		}
+		return i, nil
+	}
+
+	p := &plan{n: n, pinned: make([]bool, n), before: make([][]int, n), deadline: make([]int, n)}
+	for _, id := range constraints.Pinned {
+		i, err := lookup(id)
+		if err != nil {
+			return nil, err
+		}
+		p.pinned[i] = true
+	}
+
+	after := make([][]int, n)
+	for _, pair := range constraints.Precedence {
+		b, err := lookup(pair.Before)
+		if err != nil {
+			return nil, err
+		}
+		a, err := lookup(pair.After)
+		if err != nil {
+			return nil, err
+		}
+		if a == b {
+			return nil, fmt.Errorf("%w: block %d cannot precede itself", utils.ErrInvalidInput, pair.Before)
+		}
+		p.before[a] = append(p.before[a], b)
+		after[b] = append(after[b], a)
+	}
+
+	if err := p.computeDeadlines(after); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// computeDeadlines для каждого блока находит ближайший закрепленный блок среди
+// тех, что должны идти после него, и заодно обнаруживает циклы
+func (p *plan) computeDeadlines(after [][]int) error {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, p.n)
+
+	var visit func(b int) error
+	visit = func(b int) error {
+		switch state[b] {
+		case visiting:
+			return fmt.Errorf("%w: precedence constraints form a cycle", utils.ErrConflict)
+		case done:
+			return nil
+		}
+		state[b] = visiting
+
+		p.deadline[b] = p.n
+		for _, a := range after[b] {
+			if err := visit(a); err != nil {
+				return err
+			}
+			limit := p.deadline[a]
+			if p.pinned[a] {
+				limit = a
+			}
+			p.deadline[b] = min(p.deadline[b], limit)
+		}
+
+		state[b] = done
+		return nil
+	}
+
+	for b := 0; b < p.n; b++ {
+		if err := visit(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// valid проверяет, что порядок сохраняет закрепленные позиции и ограничения порядка
+func (p *plan) valid(order []int) bool {
+	position := make([]int, p.n)
+	for pos, b := range order {
+		if p.pinned[b] && pos != b {
+			return false
+		}
+		position[b] = pos
+	}
+	for b := range p.before {
+		for _, prev := range p.before[b] {
+			if position[prev] >= position[b] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// initialOrder строит допустимый порядок: на свободные позиции ставятся готовые
+// блоки с самым ранним сроком, при равенстве — в текущем порядке
+func (p *plan) initialOrder() ([]int, error) {
+	order := make([]int, 0, p.n)
+	placed := make([]bool, p.n)
+	ready := func(b int) bool {
+		for _, prev := range p.before[b] {
+			if !placed[prev] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for pos := 0; pos < p.n; pos++ {
+		next := -1
+		if p.pinned[pos] {
+			if ready(pos) {
+				next = pos
+			}
+		} else {
+			for b := 0; b < p.n; b++ {
+				if placed[b] || p.pinned[b] || !ready(b) {
+					continue
+				}
+				if next < 0 || p.deadline[b] < p.deadline[next] {
+					next = b
+				}
+			}
+		}
+		if next < 0 || pos >= p.deadline[next] {
+			return nil, fmt.Errorf("%w: no block order satisfies the pinned blocks and precedence constraints", utils.ErrConflict)
+		}
+
+		placed[next] = true
+		order = append(order, next)
+	}
+
+	if !p.valid(order) {
+		return nil, fmt.Errorf("%w: no block order satisfies the pinned blocks and precedence constraints", utils.ErrConflict)
+	}
+	return order, nil
+}
+
+// neighbours возвращает допустимые порядки, получаемые из order обменом двух
+// незакрепленных блоков или переносом одного из них на другую свободную позицию
+func (p *plan) neighbours(order []int) [][]int {
+	var free []int
+	for pos := range order {
+		if !p.pinned[order[pos]] {
+			free = append(free, pos)
+		}
+	}
+
+	var result [][]int
+	add := func(candidate []int) {
+		if p.valid(candidate) {
+			result = append(result, candidate)
+		}
+	}
+
+	for i := 0; i < len(free); i++ {
+		for j := i + 1; j < len(free); j++ {
+			swapped := append([]int(nil), order...)
+			swapped[free[i]], swapped[free[j]] = swapped[free[j]], swapped[free[i]]
+			add(swapped)
+		}
+	}
+
+	// Перенос сдвигает блоки между свободными позициями, закрепленные остаются на месте
+	for i := range free {
+		for j := range free {
+			if i == j || j == i+1 || j == i-1 {
+				continue
+			}
+			sequence := make([]int, len(free))
+			for k, pos := range free {
+				sequence[k] = order[pos]
+			}
+			moved := sequence[i]
+			sequence = append(sequence[:i], sequence[i+1:]...)
+			sequence = append(sequence[:j], append([]int{moved}, sequence[j:]...)...)
+
+			candidate := append([]int(nil), order...)
+			for k, pos := range free {
+				candidate[pos] = sequence[k]
+			}
+			add(candidate)
+		}
+	}
+
+	return result
+}
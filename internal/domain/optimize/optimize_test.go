@@ -0,0 +1,202 @@
+package optimize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/risk"
+	"cor-events-scheduler/pkg/utils"
+)
+
+// noon — начало расписаний в тестах
+var noon = time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+// alternating — группы и лекции вперемешку, между ними нет перерывов
+func alternating() *models.Schedule {
+	return domaintest.Festival(noon, 8*time.Hour,
+		models.Block{Name: "Группа 1", Type: "band", Duration: 30},
+		models.Block{Name: "Лекция 1", Type: "talk", Duration: 30},
+		models.Block{Name: "Группа 2", Type: "band", Duration: 30},
+		models.Block{Name: "Лекция 2", Type: "talk", Duration: 30},
+	)
+}
+
+func names(schedule *models.Schedule) []string {
+	result := make([]string, len(schedule.Blocks))
+	for i, block := range schedule.Blocks {
+		result[i] = block.Name
+	}
+	return result
+}
+
+func newOptimizer() *Optimizer {
+	return NewOptimizer(risk.NewAnalyzer(risk.Options{}), Options{})
+}
+
+func TestOptimizeGroupsBlocksToSaveChangeovers(t *testing.T) {
+	schedule := alternating()
+
+	result, err := newOptimizer().Optimize(context.Background(), schedule, Constraints{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Обе лекции подряд: вокруг групп нужны перестановки по 15 минут, между лекциями — нет
+	if result.Proposed.ChangeoverMinutes != 30 || result.Current.ChangeoverMinutes != 0 {
+		t.Fatalf("changeover: current %d, proposed %d, order %v",
+			result.Current.ChangeoverMinutes, result.Proposed.ChangeoverMinutes, names(result.Schedule))
+	}
+	if result.Proposed.RiskScore >= result.Current.RiskScore {
+		t.Fatalf("risk should drop: current %v, proposed %v", result.Current.RiskScore, result.Proposed.RiskScore)
+	}
+
+	for i, block := range result.Schedule.Blocks {
+		if block.Order != i+1 {
+			t.Errorf("block %q has order %d at index %d", block.Name, block.Order, i)
+		}
+		if i > 0 && !block.StartTime.Equal(result.Schedule.Blocks[i-1].EndTime()) {
+			t.Errorf("block %q is not laid out after the previous one", block.Name)
+		}
+	}
+	if len(result.Changes) == 0 {
+		t.Fatal("diff should list moved blocks")
+	}
+
+	if schedule.Blocks[1].Name != "Лекция 1" || schedule.Blocks[0].TechBreakDuration != 0 {
+		t.Fatal("the current schedule must not be modified")
+	}
+}
+
+func TestOptimizeRespectsConstraints(t *testing.T) {
+	schedule := alternating()
+	constraints := Constraints{
+		Pinned:     []uint{1},
+		Precedence: []Precedence{{Before: 4, After: 2}},
+	}
+
+	result, err := newOptimizer().Optimize(context.Background(), schedule, constraints, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order := names(result.Schedule)
+	if order[0] != "Группа 1" {
+		t.Fatalf("pinned block moved: %v", order)
+	}
+	position := make(map[string]int)
+	for i, name := range order {
+		position[name] = i
+	}
+	if position["Лекция 2"] > position["Лекция 1"] {
+		t.Fatalf("precedence violated: %v", order)
+	}
+}
+
+func TestOptimizeRejectsBadConstraints(t *testing.T) {
+	cases := []struct {
+		name        string
+		constraints Constraints
+		want        error
+	}{
+		{"unknown block", Constraints{Pinned: []uint{42}}, utils.ErrInvalidInput},
+		{"self precedence", Constraints{Precedence: []Precedence{{Before: 1, After: 1}}}, utils.ErrInvalidInput},
+		{"cycle", Constraints{Precedence: []Precedence{{Before: 1, After: 2}, {Before: 2, After: 3}, {Before: 3, After: 1}}}, utils.ErrConflict},
+		// Группа 2 закреплена на третьем месте, но должна идти раньше закрепленной первой группы
+		{"pinned conflict", Constraints{Pinned: []uint{1, 3}, Precedence: []Precedence{{Before: 3, After: 1}}}, utils.ErrConflict},
+	}
+
+	for _, tc := range cases {
+		if _, err := newOptimizer().Optimize(context.Background(), alternating(), tc.constraints, nil); !errors.Is(err, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestOptimizeKeepsScheduleConstraints(t *testing.T) {
+	schedule := alternating()
+	schedule.Constraints = []models.BlockConstraint{
+		{Type: models.ConstraintAdjacent, Block: "Группа 1", TargetBlock: "Лекция 1"},
+		{Type: models.ConstraintAfter, Block: "Группа 2", TargetBlock: "Лекция 2"},
+	}
+
+	result, err := newOptimizer().Optimize(context.Background(), schedule, Constraints{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Proposed.ConstraintViolations != 0 {
+		t.Fatalf("proposal violates schedule constraints: %v", names(result.Schedule))
+	}
+	if result.Current.ConstraintViolations != 1 {
+		t.Fatalf("current order violates the after constraint, got %+v", result.Current)
+	}
+}
+
+func TestOptimizeKeepsTechBreakRequiredByMinGap(t *testing.T) {
+	schedule := domaintest.Festival(noon, 8*time.Hour,
+		models.Block{Name: "Открытие", Type: "opening", Duration: 30, TechBreakDuration: 10},
+		models.Block{Name: "Косплей", Type: "contest", Duration: 60},
+	)
+	schedule.Constraints = []models.BlockConstraint{
+		{Type: models.ConstraintAfter, Block: "Косплей", TargetBlock: "Открытие"},
+		{Type: models.ConstraintMinGap, Block: "Косплей", TargetBlock: "Открытие", Minutes: 10},
+	}
+
+	result, err := newOptimizer().Optimize(context.Background(), schedule, Constraints{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Стандартной перестановки в 5 минут мало: остается заданный перерыв
+	if result.Proposed.ConstraintViolations != 0 || result.Schedule.Blocks[0].TechBreakDuration != 10 {
+		t.Fatalf("proposal must keep the 10 min break, got %+v and %+v", result.Proposed, result.Schedule.Blocks[0])
+	}
+}
+
+func TestOptimizeStopsAtBudget(t *testing.T) {
+	blocks := make([]models.Block, 12)
+	for i := range blocks {
+		kind := []string{"band", "talk", "contest"}[i%3]
+		blocks[i] = models.Block{Name: fmt.Sprintf("%s %d", kind, i), Type: kind, Duration: 20}
+	}
+
+	full, err := newOptimizer().Optimize(context.Background(), domaintest.Festival(noon, 8*time.Hour, blocks...), Constraints{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.Partial {
+		t.Fatal("default budget must be enough for 12 blocks")
+	}
+
+	// Бюджет на пару порядков: предложение — лучшее из найденных, поиск помечен незавершенным
+	limited := NewOptimizer(risk.NewAnalyzer(risk.Options{}), Options{MaxEvaluations: 4 * len(blocks)})
+	result, err := limited.Optimize(context.Background(), domaintest.Festival(noon, 8*time.Hour, blocks...), Constraints{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Partial || len(result.Schedule.Blocks) != len(blocks) {
+		t.Fatalf("want a partial proposal with all blocks, got partial=%v", result.Partial)
+	}
+	if result.Proposed.ChangeoverMinutes < full.Proposed.ChangeoverMinutes {
+		t.Fatalf("partial search cannot beat the full one: %+v vs %+v", result.Proposed, full.Proposed)
+	}
+
+	// Повторный вызов дает то же предложение
+	again, err := newOptimizer().Optimize(context.Background(), domaintest.Festival(noon, 8*time.Hour, blocks...), Constraints{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(names(again.Schedule)) != fmt.Sprint(names(full.Schedule)) {
+		t.Fatalf("optimization must be deterministic: %v vs %v", names(again.Schedule), names(full.Schedule))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := newOptimizer().Optimize(ctx, domaintest.Festival(noon, 8*time.Hour, blocks...), Constraints{}, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context error, got %v", err)
+	}
+}
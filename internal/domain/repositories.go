@@ -0,0 +1,150 @@
+// internal/domain/repositories.go
+package domain
+
+import (
+	"context"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// ScheduleRepository хранит расписания вместе с блоками и элементами.
+// Каждая операция записи атомарна: либо применяется целиком, либо не применяется.
+// Отсутствующее расписание обозначается ошибкой, оборачивающей utils.ErrNotFound.
+type ScheduleRepository interface {
+	// Create сохраняет расписание и заполняет ID и временные метки
+	// расписания, его блоков и элементов
+	Create(ctx context.Context, schedule *models.Schedule) error
+	// Update приводит сохраненное расписание к переданному: обновляет
+	// существующие блоки и элементы, добавляет новые и удаляет отсутствующие
+	Update(ctx context.Context, schedule *models.Schedule) error
+	// UpdateWithVersion обновляет расписание как Update и в той же операции
+	// сохраняет версию, построенную version; ошибка version отменяет обновление
+	UpdateWithVersion(ctx context.Context, schedule *models.Schedule, version VersionFunc) error
+	GetByID(ctx context.Context, id uint) (*models.Schedule, error)
+	// GetBySlug возвращает расписание по адресу публичной страницы; у разных
+	// расписаний не может быть одинакового непустого адреса (utils.ErrConflict)
+	GetBySlug(ctx context.Context, slug string) (*models.Schedule, error)
+	Delete(ctx context.Context, id uint) error
+	// ListSummaries и List возвращают до limit расписаний с id больше afterID по возрастанию id
+	ListSummaries(ctx context.Context, afterID uint, limit int) ([]models.ScheduleSummary, error)
+	List(ctx context.Context, afterID uint, limit int) ([]models.Schedule, error)
+}
+
+// VersionFunc строит версию по сохраненному расписанию и последней версии
+// расписания (nil, если версий еще нет)
+type VersionFunc func(saved *models.Schedule, latest *models.ScheduleVersion) (*models.ScheduleVersion, error)
+
+// VersionRepository хранит снимки версий расписаний
+type VersionRepository interface {
+	CreateVersion(ctx context.Context, version *models.ScheduleVersion) error
+	// GetLatestVersion возвращает utils.ErrNotFound, если у расписания нет версий
+	GetLatestVersion(ctx context.Context, scheduleID uint) (*models.ScheduleVersion, error)
+	// GetVersionsByScheduleID возвращает версии от новой к старой
+	GetVersionsByScheduleID(ctx context.Context, scheduleID uint) ([]models.ScheduleVersion, error)
+}
+
+// SearchRepository выполняет полнотекстовый поиск по расписаниям
+type SearchRepository interface {
+	Search(ctx context.Context, query string, offset, limit int) ([]models.SearchHit, error)
+}
+
+// RuleSetRepository хранит наборы правил расписаний и организации
+// (models.OrganizationRuleSetID). Набор расписания удаляется вместе с расписанием.
+type RuleSetRepository interface {
+	// GetRuleSet возвращает utils.ErrNotFound, если набора нет
+	GetRuleSet(ctx context.Context, scheduleID uint) (*models.RuleSet, error)
+	// SaveRuleSet создает или заменяет набор и заполняет UpdatedAt
+	SaveRuleSet(ctx context.Context, ruleSet *models.RuleSet) error
+	// DeleteRuleSet возвращает utils.ErrNotFound, если набора нет
+	DeleteRuleSet(ctx context.Context, scheduleID uint) error
+}
+
+// PerformerRepository хранит выступающих вместе с окнами доступности.
+// Связи с элементами сохраняются вместе с расписанием (BlockItem.PerformerIDs);
+// при удалении выступающего его связи с элементами удаляются.
+type PerformerRepository interface {
+	// CreatePerformer сохраняет выступающего и заполняет ID и временные метки
+	CreatePerformer(ctx context.Context, performer *models.Performer) error
+	// UpdatePerformer заменяет поля и окна доступности выступающего
+	UpdatePerformer(ctx context.Context, performer *models.Performer) error
+	GetPerformer(ctx context.Context, id uint) (*models.Performer, error)
+	// GetPerformers возвращает найденных выступающих по возрастанию ID, отсутствующие ID пропускаются
+	GetPerformers(ctx context.Context, ids []uint) ([]models.Performer, error)
+	// ListPerformers возвращает всех выступающих по возрастанию ID
+	ListPerformers(ctx context.Context) ([]models.Performer, error)
+	DeletePerformer(ctx context.Context, id uint) error
+	// ListAppearances возвращает выступления выступающих во всех сохраненных
+	// расписаниях по времени начала
+	ListAppearances(ctx context.Context, performerIDs []uint) ([]models.Appearance, error)
+}
+
+// ResourceRepository хранит ресурсы площадки. Брони сохраняются вместе с
+// расписанием (Block.Resources и BlockItem.Resources); при удалении ресурса
+// его брони удаляются.
+type ResourceRepository interface {
+	// CreateResource сохраняет ресурс и заполняет ID и временные метки
+	CreateResource(ctx context.Context, resource *models.Resource) error
+	UpdateResource(ctx context.Context, resource *models.Resource) error
+	GetResource(ctx context.Context, id uint) (*models.Resource, error)
+	// GetResources возвращает найденные ресурсы по возрастанию ID, отсутствующие ID пропускаются
+	GetResources(ctx context.Context, ids []uint) ([]models.Resource, error)
+	// ListResources возвращает все ресурсы по возрастанию ID
+	ListResources(ctx context.Context) ([]models.Resource, error)
+	DeleteResource(ctx context.Context, id uint) error
+	// ListUsages возвращает брони ресурсов во всех сохраненных расписаниях,
+	// пересекающиеся с интервалом [from, to), по времени начала
+	ListUsages(ctx context.Context, resourceIDs []uint, from, to time.Time) ([]models.ResourceUsage, error)
+}
+
+// AgendaRepository выбирает блоки и элементы всех расписаний по интервалу
+// времени без загрузки расписаний целиком
+type AgendaRepository interface {
+	// ListAgenda возвращает блоки и, если filter.Items, элементы сохраненных
+	// расписаний, пересекающиеся с [From, To), в порядке models.SortAgenda
+	ListAgenda(ctx context.Context, filter models.AgendaFilter) ([]models.AgendaEntry, error)
+}
+
+// ShareLinkRepository хранит ссылки для просмотра расписаний. Ссылки
+// расписания удаляются вместе с расписанием.
+type ShareLinkRepository interface {
+	// CreateShareLink сохраняет ссылку и заполняет ID и CreatedAt
+	CreateShareLink(ctx context.Context, link *models.ShareLink) error
+	GetShareLink(ctx context.Context, id uint) (*models.ShareLink, error)
+	// ListShareLinks возвращает все ссылки расписания по возрастанию ID
+	ListShareLinks(ctx context.Context, scheduleID uint) ([]models.ShareLink, error)
+	// RevokeShareLink отмечает ссылку отозванной в момент at; повторный отзыв
+	// сохраняет первое время
+	RevokeShareLink(ctx context.Context, id uint, at time.Time) error
+	// RecordShareAccess увеличивает счетчик обращений по ссылке и запоминает
+	// время последнего обращения
+	RecordShareAccess(ctx context.Context, id uint, at time.Time) error
+}
+
+// ReminderRepository хранит напоминания о начале блоков и выступлений.
+// Напоминания расписания удаляются вместе с расписанием.
+type ReminderRepository interface {
+	// SyncReminders приводит напоминания расписания к planned, сопоставляя их
+	// по models.ReminderKey: отметка об отправке сохраняется, пока время
+	// срабатывания не перенесено позже now — тогда напоминание снова ждет
+	// отправки; отсутствующие в planned удаляются
+	SyncReminders(ctx context.Context, scheduleID uint, planned []models.Reminder, now time.Time) error
+	// ListReminders возвращает напоминания расписания по времени срабатывания
+	ListReminders(ctx context.Context, scheduleID uint) ([]models.Reminder, error)
+	// DueReminders возвращает не больше limit неотправленных напоминаний со
+	// временем срабатывания не позже now, начиная с самых ранних
+	DueReminders(ctx context.Context, now time.Time, limit int) ([]models.Reminder, error)
+	// ClaimReminder отмечает напоминание отправленным в момент at. false —
+	// напоминание уже взято другим вызовом или удалено
+	ClaimReminder(ctx context.Context, id uint, at time.Time) (bool, error)
+}
+
+// LeaderLock выбирает один экземпляр сервиса для фоновой работы, которую
+// нельзя выполнять параллельно
+type LeaderLock interface {
+	// Acquire возвращает true, если экземпляр ведущий; повторный вызов
+	// ведущего проверяет, что лидерство не потеряно
+	Acquire(ctx context.Context) (bool, error)
+	// Release отказывается от лидерства
+	Release(ctx context.Context) error
+}
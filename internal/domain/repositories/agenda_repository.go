@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+
+	"gorm.io/gorm"
+)
+
+var _ domain.AgendaRepository = (*AgendaRepository)(nil)
+
+type AgendaRepository struct {
+	db *gorm.DB
+}
+
+func NewAgendaRepository(db *gorm.DB) *AgendaRepository {
+	return &AgendaRepository{db: db}
+}
+
+// ListAgenda выбирает блоки и элементы по индексам времени начала и конца
+func (r *AgendaRepository) ListAgenda(ctx context.Context, filter models.AgendaFilter) ([]models.AgendaEntry, error) {
+	entries := []models.AgendaEntry{}
+
+	var blocks []models.AgendaEntry
+	err := agendaScope(r.db.WithContext(ctx).Table("blocks AS b"), filter).
+		Select(`s.id AS schedule_id, s.name AS schedule_name, b.id AS block_id, b.name AS block_name,
+			b.type, b.track, b.start_time, b.end_time`).
+		Where("b.start_time < ? AND b.end_time > ?", filter.To, filter.From).
+		Scan(&blocks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agenda blocks: %w", mapError(err))
+	}
+	for _, entry := range blocks {
+		entry.Kind = models.AgendaKindBlock
+		entries = append(entries, entry)
+	}
+
+	if filter.Items {
+		var items []models.AgendaEntry
+		err := agendaScope(r.db.WithContext(ctx).Table("block_items AS i").Joins("JOIN blocks b ON b.id = i.block_id"), filter).
+			Select(`s.id AS schedule_id, s.name AS schedule_name, b.id AS block_id, b.name AS block_name,
+				i.id AS item_id, i.name AS item_name, i.type, b.track, i.start_time, i.end_time`).
+			Where("i.deleted_at IS NULL").
+			Where("i.start_time < ? AND i.end_time > ?", filter.To, filter.From).
+			Scan(&items).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to list agenda items: %w", mapError(err))
+		}
+		for _, entry := range items {
+			entry.Kind = models.AgendaKindItem
+			entries = append(entries, entry)
+		}
+	}
+
+	models.SortAgenda(entries)
+	return entries, nil
+}
+
+// agendaScope ограничивает выборку блоками действующих расписаний, которые
+// подходят под фильтры ленты; блоки доступны под псевдонимом b
+func agendaScope(db *gorm.DB, filter models.AgendaFilter) *gorm.DB {
+	db = db.Joins("JOIN schedules s ON s.id = b.schedule_id AND s.deleted_at IS NULL").
+		Where("b.deleted_at IS NULL")
+	if len(filter.ScheduleIDs) > 0 {
+		db = db.Where("b.schedule_id IN ?", filter.ScheduleIDs)
+	}
+	if len(filter.Types) > 0 {
+		db = db.Where("b.type IN ?", filter.Types)
+	}
+	if len(filter.Tracks) > 0 {
+		db = db.Where("b.track IN ?", filter.Tracks)
+	}
+	return db
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cor-events-scheduler/internal/config"
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/infrastructure/db"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Набор проверок для PostgreSQL требует отдельной базы: перед каждой проверкой таблицы очищаются.
+//
+//	TEST_DATABASE_DSN="host=localhost user=postgres password=postgres dbname=scheduler_test sslmode=disable" \
+//	    go test ./internal/domain/repositories/
+func TestConformancePostgres(t *testing.T) {
+	database := openPostgresTestDB(t)
+
+	domaintest.Run(t, func(t *testing.T) domaintest.Repositories {
+		if err := database.Exec(`TRUNCATE schedules, blocks, block_items, schedule_versions, search_entries, rule_sets, block_constraints, performers, performer_availability, item_performers, resources, resource_bookings, share_links, reminders RESTART IDENTITY`).Error; err != nil {
+			t.Fatalf("failed to clean database: %v", err)
+		}
+		return newRepositories(database)
+	})
+}
+
+func TestConformanceSQLite(t *testing.T) {
+	domaintest.Run(t, func(t *testing.T) domaintest.Repositories {
+		return newRepositories(openSQLiteTestDB(t))
+	})
+}
+
+// openSQLiteTestDB создает базу SQLite со всеми миграциями во временном каталоге теста
+func openSQLiteTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	database, err := db.Open(&config.Config{
+		Database: config.DatabaseConfig{
+			Driver: config.DriverSQLite,
+			Path:   filepath.Join(t.TempDir(), "scheduler.db"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	database.Logger = logger.Default.LogMode(logger.Silent)
+	if err := db.Migrate(database); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := database.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return database
+}
+
+func openPostgresTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	database, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	if err := db.Migrate(database); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	return database
+}
+
+func newRepositories(database *gorm.DB) domaintest.Repositories {
+	return domaintest.Repositories{
+		Schedules:  NewScheduleRepository(database),
+		Versions:   NewVersionRepository(database),
+		Search:     NewSearchRepository(database),
+		RuleSets:   NewRuleSetRepository(database),
+		Performers: NewPerformerRepository(database),
+		Resources:  NewResourceRepository(database),
+		Agenda:     NewAgendaRepository(database),
+		ShareLinks: NewShareLinkRepository(database),
+		Reminders:  NewReminderRepository(database),
+	}
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cor-events-scheduler/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// mapError приводит ошибки GORM к ошибкам из pkg/utils, оставляя исходную ошибку в цепочке
+func mapError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return fmt.Errorf("%w: %w", utils.ErrNotFound, err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return fmt.Errorf("%w: %w", utils.ErrConflict, err)
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return err
+	default:
+		return fmt.Errorf("%w: %w", utils.ErrDatabaseOperation, err)
+	}
+}
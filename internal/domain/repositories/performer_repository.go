@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ domain.PerformerRepository = (*PerformerRepository)(nil)
+
+type PerformerRepository struct {
+	db *gorm.DB
+}
+
+func NewPerformerRepository(db *gorm.DB) *PerformerRepository {
+	return &PerformerRepository{db: db}
+}
+
+// CreatePerformer создает выступающего вместе с окнами доступности
+func (r *PerformerRepository) CreatePerformer(ctx context.Context, performer *models.Performer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		performer.ID = 0
+		performer.CreatedAt = now
+		performer.UpdatedAt = now
+
+		if err := tx.Omit(clause.Associations).Create(performer).Error; err != nil {
+			return fmt.Errorf("failed to create performer: %w", mapError(err))
+		}
+		return replaceAvailability(tx, performer)
+	})
+}
+
+// UpdatePerformer обновляет выступающего и заменяет его окна доступности
+func (r *PerformerRepository) UpdatePerformer(ctx context.Context, performer *models.Performer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.Performer
+		if err := tx.Select("id", "created_at").First(&existing, performer.ID).Error; err != nil {
+			return fmt.Errorf("failed to get existing performer: %w", mapError(err))
+		}
+
+		now := time.Now()
+		err := tx.Model(&models.Performer{}).Where("id = ?", performer.ID).Updates(map[string]interface{}{
+			"name":           performer.Name,
+			"contact":        performer.Contact,
+			"buffer_minutes": performer.BufferMinutes,
+			"updated_at":     now,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update performer: %w", mapError(err))
+		}
+		performer.CreatedAt = existing.CreatedAt
+		performer.UpdatedAt = now
+
+		return replaceAvailability(tx, performer)
+	})
+}
+
+// GetPerformer получает выступающего по ID
+func (r *PerformerRepository) GetPerformer(ctx context.Context, id uint) (*models.Performer, error) {
+	var performer models.Performer
+	if err := withAvailability(r.db.WithContext(ctx)).First(&performer, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get performer: %w", mapError(err))
+	}
+	if performer.Availability == nil {
+		performer.Availability = []models.PerformerAvailability{}
+	}
+	return &performer, nil
+}
+
+// GetPerformers получает выступающих с указанными ID
+func (r *PerformerRepository) GetPerformers(ctx context.Context, ids []uint) ([]models.Performer, error) {
+	performers := []models.Performer{}
+	if len(ids) == 0 {
+		return performers, nil
+	}
+	if err := withAvailability(r.db.WithContext(ctx)).Where("id IN ?", ids).Order("id ASC").Find(&performers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get performers: %w", mapError(err))
+	}
+	fillAvailability(performers)
+	return performers, nil
+}
+
+// ListPerformers возвращает всех выступающих
+func (r *PerformerRepository) ListPerformers(ctx context.Context) ([]models.Performer, error) {
+	performers := []models.Performer{}
+	if err := withAvailability(r.db.WithContext(ctx)).Order("id ASC").Find(&performers).Error; err != nil {
+		return nil, fmt.Errorf("failed to list performers: %w", mapError(err))
+	}
+	fillAvailability(performers)
+	return performers, nil
+}
+
+// DeletePerformer удаляет выступающего, его окна доступности и связи с элементами
+func (r *PerformerRepository) DeletePerformer(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("performer_id = ?", id).Delete(&models.ItemPerformer{}).Error; err != nil {
+			return fmt.Errorf("failed to delete performer links: %w", mapError(err))
+		}
+		if err := tx.Where("performer_id = ?", id).Delete(&models.PerformerAvailability{}).Error; err != nil {
+			return fmt.Errorf("failed to delete performer availability: %w", mapError(err))
+		}
+
+		result := tx.Delete(&models.Performer{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete performer: %w", mapError(result.Error))
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("failed to get performer for deletion: %w", utils.ErrNotFound)
+		}
+		return nil
+	})
+}
+
+// ListAppearances возвращает выступления в элементах расписаний, которые не удалены
+func (r *PerformerRepository) ListAppearances(ctx context.Context, performerIDs []uint) ([]models.Appearance, error) {
+	appearances := []models.Appearance{}
+	if len(performerIDs) == 0 {
+		return appearances, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Table("item_performers AS ip").
+		Select(`ip.performer_id, s.id AS schedule_id, s.name AS schedule_name,
+			b.id AS block_id, b.name AS block_name, i.id AS item_id, i.name AS item_name,
+			i.start_time, i.end_time`).
+		Joins("JOIN block_items i ON i.id = ip.item_id AND i.deleted_at IS NULL").
+		Joins("JOIN blocks b ON b.id = i.block_id AND b.deleted_at IS NULL").
+		Joins("JOIN schedules s ON s.id = b.schedule_id AND s.deleted_at IS NULL").
+		Where("ip.performer_id IN ?", performerIDs).
+		Order("i.start_time ASC, ip.performer_id ASC, i.id ASC").
+		Scan(&appearances).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list performer appearances: %w", mapError(err))
+	}
+	return appearances, nil
+}
+
+func withAvailability(db *gorm.DB) *gorm.DB {
+	return db.Preload("Availability", func(db *gorm.DB) *gorm.DB {
+		return db.Order("start_time ASC, id ASC")
+	})
+}
+
+// fillAvailability заменяет отсутствующие окна доступности пустым списком
+func fillAvailability(performers []models.Performer) {
+	for i := range performers {
+		if performers[i].Availability == nil {
+			performers[i].Availability = []models.PerformerAvailability{}
+		}
+	}
+}
+
+// replaceAvailability заменяет окна доступности выступающего и переносит их ID обратно
+func replaceAvailability(tx *gorm.DB, performer *models.Performer) error {
+	if err := tx.Where("performer_id = ?", performer.ID).Delete(&models.PerformerAvailability{}).Error; err != nil {
+		return fmt.Errorf("failed to delete performer availability: %w", mapError(err))
+	}
+	if performer.Availability == nil {
+		performer.Availability = []models.PerformerAvailability{}
+	}
+	if len(performer.Availability) == 0 {
+		return nil
+	}
+
+	rows := make([]models.PerformerAvailability, len(performer.Availability))
+	for i, window := range performer.Availability {
+		window.ID = 0
+		window.PerformerID = performer.ID
+		rows[i] = window
+	}
+	if err := tx.CreateInBatches(&rows, writeBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to create performer availability: %w", mapError(err))
+	}
+
+	for i := range performer.Availability {
+		performer.Availability[i].ID = rows[i].ID
+		performer.Availability[i].PerformerID = performer.ID
+	}
+	return nil
+}
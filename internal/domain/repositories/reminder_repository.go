@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+
+	"gorm.io/gorm"
+)
+
+var _ domain.ReminderRepository = (*ReminderRepository)(nil)
+
+type ReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+// SyncReminders приводит напоминания расписания к planned
+func (r *ReminderRepository) SyncReminders(ctx context.Context, scheduleID uint, planned []models.Reminder, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []models.Reminder
+		if err := tx.Where("schedule_id = ?", scheduleID).Find(&existing).Error; err != nil {
+			return fmt.Errorf("failed to get reminders: %w", mapError(err))
+		}
+		byKey := make(map[models.ReminderKey]models.Reminder, len(existing))
+		for _, reminder := range existing {
+			byKey[reminder.Key()] = reminder
+		}
+
+		for _, reminder := range planned {
+			key := reminder.Key()
+			current, ok := byKey[key]
+			delete(byKey, key)
+
+			switch {
+			case !ok:
+				reminder.ID = 0
+				reminder.ScheduleID = scheduleID
+				reminder.SentAt = nil
+				reminder.CreatedAt = time.Now()
+				if err := tx.Create(&reminder).Error; err != nil {
+					return fmt.Errorf("failed to create reminder: %w", mapError(err))
+				}
+			case !current.FireAt.Equal(reminder.FireAt):
+				updates := map[string]interface{}{
+					"starts_at": reminder.StartsAt,
+					"fire_at":   reminder.FireAt,
+				}
+				if reminder.FireAt.After(now) {
+					updates["sent_at"] = nil
+				}
+				err := tx.Model(&models.Reminder{}).Where("id = ?", current.ID).Updates(updates).Error
+				if err != nil {
+					return fmt.Errorf("failed to update reminder: %w", mapError(err))
+				}
+			}
+		}
+
+		for _, reminder := range byKey {
+			if err := tx.Delete(&models.Reminder{}, reminder.ID).Error; err != nil {
+				return fmt.Errorf("failed to delete reminder: %w", mapError(err))
+			}
+		}
+		return nil
+	})
+}
+
+// ListReminders возвращает напоминания расписания
+func (r *ReminderRepository) ListReminders(ctx context.Context, scheduleID uint) ([]models.Reminder, error) {
+	reminders := []models.Reminder{}
+	err := r.db.WithContext(ctx).Where("schedule_id = ?", scheduleID).Order("fire_at ASC, id ASC").Find(&reminders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminders: %w", mapError(err))
+	}
+	return reminders, nil
+}
+
+// DueReminders возвращает наступившие неотправленные напоминания
+func (r *ReminderRepository) DueReminders(ctx context.Context, now time.Time, limit int) ([]models.Reminder, error) {
+	reminders := []models.Reminder{}
+	err := r.db.WithContext(ctx).
+		Where("sent_at IS NULL AND fire_at <= ?", now).
+		Order("fire_at ASC, id ASC").
+		Limit(limit).
+		Find(&reminders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due reminders: %w", mapError(err))
+	}
+	return reminders, nil
+}
+
+// ClaimReminder отмечает напоминание отправленным одним условным обновлением,
+// так что из нескольких одновременных вызовов успешен только один
+func (r *ReminderRepository) ClaimReminder(ctx context.Context, id uint, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.Reminder{}).
+		Where("id = ? AND sent_at IS NULL", id).
+		Update("sent_at", at)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim reminder: %w", mapError(result.Error))
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+var _ domain.ResourceRepository = (*ResourceRepository)(nil)
+
+type ResourceRepository struct {
+	db *gorm.DB
+}
+
+func NewResourceRepository(db *gorm.DB) *ResourceRepository {
+	return &ResourceRepository{db: db}
+}
+
+// CreateResource создает ресурс
+func (r *ResourceRepository) CreateResource(ctx context.Context, resource *models.Resource) error {
+	now := time.Now()
+	resource.ID = 0
+	resource.CreatedAt = now
+	resource.UpdatedAt = now
+
+	if err := r.db.WithContext(ctx).Create(resource).Error; err != nil {
+		return fmt.Errorf("failed to create resource: %w", mapError(err))
+	}
+	return nil
+}
+
+// UpdateResource обновляет ресурс
+func (r *ResourceRepository) UpdateResource(ctx context.Context, resource *models.Resource) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.Resource
+		if err := tx.Select("id", "created_at").First(&existing, resource.ID).Error; err != nil {
+			return fmt.Errorf("failed to get existing resource: %w", mapError(err))
+		}
+
+		now := time.Now()
+		err := tx.Model(&models.Resource{}).Where("id = ?", resource.ID).Updates(map[string]interface{}{
+			"name":        resource.Name,
+			"type":        resource.Type,
+			"description": resource.Description,
+			"capacity":    resource.Capacity,
+			"updated_at":  now,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update resource: %w", mapError(err))
+		}
+		resource.CreatedAt = existing.CreatedAt
+		resource.UpdatedAt = now
+		return nil
+	})
+}
+
+// GetResource получает ресурс по ID
+func (r *ResourceRepository) GetResource(ctx context.Context, id uint) (*models.Resource, error) {
+	var resource models.Resource
+	if err := r.db.WithContext(ctx).First(&resource, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get resource: %w", mapError(err))
+	}
+	return &resource, nil
+}
+
+// GetResources получает ресурсы с указанными ID
+func (r *ResourceRepository) GetResources(ctx context.Context, ids []uint) ([]models.Resource, error) {
+	resources := []models.Resource{}
+	if len(ids) == 0 {
+		return resources, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("id ASC").Find(&resources).Error; err != nil {
+		return nil, fmt.Errorf("failed to get resources: %w", mapError(err))
+	}
+	return resources, nil
+}
+
+// ListResources возвращает все ресурсы
+func (r *ResourceRepository) ListResources(ctx context.Context) ([]models.Resource, error) {
+	resources := []models.Resource{}
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&resources).Error; err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", mapError(err))
+	}
+	return resources, nil
+}
+
+// DeleteResource удаляет ресурс и его брони
+func (r *ResourceRepository) DeleteResource(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("resource_id = ?", id).Delete(&models.ResourceBooking{}).Error; err != nil {
+			return fmt.Errorf("failed to delete resource bookings: %w", mapError(err))
+		}
+
+		result := tx.Delete(&models.Resource{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete resource: %w", mapError(result.Error))
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("failed to get resource for deletion: %w", utils.ErrNotFound)
+		}
+		return nil
+	})
+}
+
+// usageRow — бронь вместе с временами блока и элемента
+type usageRow struct {
+	ResourceID        uint
+	Quantity          int
+	ScheduleID        uint
+	ScheduleName      string
+	BlockID           uint
+	BlockName         string
+	BlockStart        time.Time
+	Duration          int
+	TechBreakDuration int
+	ItemID            *uint
+	ItemName          *string
+	ItemStart         *time.Time
+	ItemEnd           *time.Time
+}
+
+// Конец брони блока: начало, длительность и техперерыв. В SQLite время
+// хранится текстом в UTC, конец записывается в том же формате.
+const (
+	usageBlockEndPostgres = "b.start_time + (b.duration + COALESCE(b.tech_break_duration, 0)) * interval '1 minute'"
+	usageBlockEndSQLite   = "strftime('%Y-%m-%d %H:%M:%f+00:00', b.start_time, '+' || (b.duration + COALESCE(b.tech_break_duration, 0)) || ' minutes')"
+)
+
+// ListUsages возвращает брони ресурсов в расписаниях, которые не удалены.
+// Интервал отбирается в запросе; у элементов, сохраненных без рассчитанных
+// времен, бронь занимает весь блок.
+func (r *ResourceRepository) ListUsages(ctx context.Context, resourceIDs []uint, from, to time.Time) ([]models.ResourceUsage, error) {
+	usages := []models.ResourceUsage{}
+	if len(resourceIDs) == 0 || !from.Before(to) {
+		return usages, nil
+	}
+
+	blockEnd := usageBlockEndPostgres
+	if isSQLite(r.db) {
+		blockEnd = usageBlockEndSQLite
+	}
+
+	var rows []usageRow
+	err := r.db.WithContext(ctx).
+		Table("resource_bookings AS rb").
+		Select(`rb.resource_id, rb.quantity, s.id AS schedule_id, s.name AS schedule_name,
+			b.id AS block_id, b.name AS block_name, b.start_time AS block_start, b.duration,
+			COALESCE(b.tech_break_duration, 0) AS tech_break_duration,
+			i.id AS item_id, i.name AS item_name, i.start_time AS item_start, i.end_time AS item_end`).
+		Joins("JOIN blocks b ON b.id = rb.block_id AND b.deleted_at IS NULL").
+		Joins("JOIN schedules s ON s.id = b.schedule_id AND s.deleted_at IS NULL").
+		Joins("LEFT JOIN block_items i ON i.id = rb.item_id").
+		Where("rb.resource_id IN ?", resourceIDs).
+		Where("rb.item_id IS NULL OR i.deleted_at IS NULL").
+		Where("COALESCE(i.start_time, b.start_time) < ?", to).
+		Where("COALESCE(i.end_time, "+blockEnd+") > ?", from).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource usages: %w", mapError(err))
+	}
+
+	for _, row := range rows {
+		block := models.Block{StartTime: row.BlockStart, Duration: row.Duration, TechBreakDuration: row.TechBreakDuration}
+		usage := models.ResourceUsage{
+			ResourceID:   row.ResourceID,
+			ScheduleID:   row.ScheduleID,
+			ScheduleName: row.ScheduleName,
+			BlockID:      row.BlockID,
+			BlockName:    row.BlockName,
+			Quantity:     row.Quantity,
+			StartTime:    block.StartTime,
+			EndTime:      block.EndTime(),
+		}
+		if row.ItemID != nil {
+			usage.ItemID = *row.ItemID
+			if row.ItemName != nil {
+				usage.ItemName = *row.ItemName
+			}
+			if row.ItemStart != nil && row.ItemEnd != nil {
+				usage.StartTime = *row.ItemStart
+				usage.EndTime = *row.ItemEnd
+			}
+		}
+		// Запрос отбирает с запасом (конец блока в SQLite округлен до
+		// миллисекунд), точное пересечение проверяется здесь
+		if !usage.StartTime.Before(to) || !usage.EndTime.After(from) {
+			continue
+		}
+		usages = append(usages, usage)
+	}
+
+	// Конец брони блока считается по его длительности, поэтому сортировка
+	// выполняется после расчета
+	sort.Slice(usages, func(i, j int) bool {
+		a, b := usages[i], usages[j]
+		if !a.StartTime.Equal(b.StartTime) {
+			return a.StartTime.Before(b.StartTime)
+		}
+		if a.ResourceID != b.ResourceID {
+			return a.ResourceID < b.ResourceID
+		}
+		if a.BlockID != b.BlockID {
+			return a.BlockID < b.BlockID
+		}
+		return a.ItemID < b.ItemID
+	})
+	return usages, nil
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// Элементы, сохраненные до расчета их времен, хранят NULL в start_time и
+// end_time; их бронь занимает весь блок
+func TestListUsagesOfItemsWithoutTimes(t *testing.T) {
+	ctx := context.Background()
+	database := openSQLiteTestDB(t)
+	schedules, resources := NewScheduleRepository(database), NewResourceRepository(database)
+
+	projectors := domaintest.NewResource("Проекторы", 3)
+	if err := resources.CreateResource(ctx, projectors); err != nil {
+		t.Fatalf("create resource: %v", err)
+	}
+	schedule := domaintest.NewSchedule("Legacy")
+	schedule.Blocks[0].Items[1].Resources = []models.ResourceAssignment{{ResourceID: projectors.ID, Quantity: 2}}
+	if err := schedules.Create(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	item := schedule.Blocks[0].Items[1]
+	if err := database.Exec("UPDATE block_items SET start_time = NULL, end_time = NULL WHERE id = ?", item.ID).Error; err != nil {
+		t.Fatalf("clear item times: %v", err)
+	}
+
+	block := schedule.Blocks[0]
+	usages, err := resources.ListUsages(ctx, []uint{projectors.ID}, block.StartTime.Add(35*time.Minute), block.StartTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("list usages: %v", err)
+	}
+	if len(usages) != 1 || usages[0].ItemID != item.ID || usages[0].ItemName != item.Name ||
+		!usages[0].StartTime.Equal(block.StartTime) || !usages[0].EndTime.Equal(block.EndTime()) {
+		t.Fatalf("want the item usage over the whole block, got %+v", usages)
+	}
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ domain.RuleSetRepository = (*RuleSetRepository)(nil)
+
+type RuleSetRepository struct {
+	db *gorm.DB
+}
+
+func NewRuleSetRepository(db *gorm.DB) *RuleSetRepository {
+	return &RuleSetRepository{db: db}
+}
+
+// GetRuleSet получает набор правил расписания или организации
+func (r *RuleSetRepository) GetRuleSet(ctx context.Context, scheduleID uint) (*models.RuleSet, error) {
+	var ruleSet models.RuleSet
+	if err := r.db.WithContext(ctx).Where("schedule_id = ?", scheduleID).First(&ruleSet).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rule set: %w", mapError(err))
+	}
+	return &ruleSet, nil
+}
+
+// SaveRuleSet создает или заменяет набор правил одним upsert
+func (r *RuleSetRepository) SaveRuleSet(ctx context.Context, ruleSet *models.RuleSet) error {
+	ruleSet.UpdatedAt = time.Now()
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "schedule_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"source", "updated_at"}),
+		}).
+		Create(ruleSet).Error
+	if err != nil {
+		return fmt.Errorf("failed to save rule set: %w", mapError(err))
+	}
+	return nil
+}
+
+// DeleteRuleSet удаляет набор правил
+func (r *RuleSetRepository) DeleteRuleSet(ctx context.Context, scheduleID uint) error {
+	result := r.db.WithContext(ctx).Where("schedule_id = ?", scheduleID).Delete(&models.RuleSet{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete rule set: %w", mapError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to get rule set for deletion: %w", utils.ErrNotFound)
+	}
+	return nil
+}
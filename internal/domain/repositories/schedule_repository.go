@@ -2,13 +2,19 @@ package repositories
 
 import (
 	"context"
+	"cor-events-scheduler/internal/domain"
 	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+	"errors"
 	"fmt"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var _ domain.ScheduleRepository = (*ScheduleRepository)(nil)
+
 type ScheduleRepository struct {
 	db *gorm.DB
 }
@@ -17,14 +23,27 @@ func NewScheduleRepository(db *gorm.DB) *ScheduleRepository {
 	return &ScheduleRepository{db: db}
 }
 
+// writeBatchSize — размер пачки для пакетной вставки блоков и элементов
+const writeBatchSize = 500
+
+// Колонки, которые обновляются при upsert существующих блоков и элементов
+var (
+	blockUpsertColumns = []string{"name", "type", "track", "start_time", "end_time", "duration", "tech_break_duration", "item_gap", "slack_policy", "visibility", "notes", "order", "updated_at"}
+	itemUpsertColumns  = []string{"block_id", "name", "type", "description", "duration", "priority", "min_duration", "start_time", "end_time", "call_offset", "visibility", "notes", "order", "updated_at"}
+)
+
 // Create создает новое расписание
 func (r *ScheduleRepository) Create(ctx context.Context, schedule *models.Schedule) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		now := time.Now()
+		refs := schedule.ResolveConstraints()
 
 		// 1. Создаем чистое расписание без связей
 		scheduleToCreate := &models.Schedule{
 			Name:      schedule.Name,
+			Slug:      schedule.Slug,
+			Language:  schedule.Language,
+			Timezone:  schedule.Timezone,
 			StartDate: schedule.StartDate,
 			EndDate:   schedule.EndDate,
 			CreatedAt: now,
@@ -32,188 +51,293 @@ func (r *ScheduleRepository) Create(ctx context.Context, schedule *models.Schedu
 		}
 
 		if err := tx.Create(scheduleToCreate).Error; err != nil {
-			return fmt.Errorf("failed to create schedule: %w", err)
+			return fmt.Errorf("failed to create schedule: %w", mapError(err))
 		}
 
-		// Сохраняем блоки временно
-		originalBlocks := schedule.Blocks
 		schedule.ID = scheduleToCreate.ID
 		schedule.CreatedAt = now
 		schedule.UpdatedAt = now
 
-		// 2. Создаем каждый блок отдельно
-		for i := range originalBlocks {
-			// Создаем новый блок без связей
-			blockToCreate := &models.Block{
-				ScheduleID:        schedule.ID,
-				Name:              originalBlocks[i].Name,
-				Type:              originalBlocks[i].Type,
-				StartTime:         originalBlocks[i].StartTime,
-				Duration:          originalBlocks[i].Duration,
-				TechBreakDuration: originalBlocks[i].TechBreakDuration,
-				Order:             i + 1,
-				CreatedAt:         now,
-				UpdatedAt:         now,
-			}
+		// 2. Вставляем все блоки пачками
+		blocks := make([]models.Block, len(schedule.Blocks))
+		for i := range schedule.Blocks {
+			blocks[i] = newBlockRow(&schedule.Blocks[i], schedule.ID, i+1, now)
+			blocks[i].CreatedAt = now
+		}
+		if err := insertBlocks(tx, blocks); err != nil {
+			return err
+		}
 
-			if err := tx.Create(blockToCreate).Error; err != nil {
-				return fmt.Errorf("failed to create block: %w", err)
+		// 3. Вставляем все элементы всех блоков пачками
+		var items []models.BlockItem
+		for i := range schedule.Blocks {
+			for j := range schedule.Blocks[i].Items {
+				item := newItemRow(&schedule.Blocks[i].Items[j], blocks[i].ID, j+1, now)
+				item.CreatedAt = now
+				items = append(items, item)
 			}
+		}
+		if err := insertItems(tx, items); err != nil {
+			return err
+		}
 
-			// Сохраняем элементы блока временно
-			originalItems := originalBlocks[i].Items
-
-			// 3. Создаем элементы для этого блока
-			for j := range originalItems {
-				itemToCreate := &models.BlockItem{
-					BlockID:     blockToCreate.ID,
-					Name:        originalItems[j].Name,
-					Type:        originalItems[j].Type,
-					Description: originalItems[j].Description,
-					Duration:    originalItems[j].Duration,
-					Order:       j + 1,
-					CreatedAt:   now,
-					UpdatedAt:   now,
-				}
-
-				if err := tx.Create(itemToCreate).Error; err != nil {
-					return fmt.Errorf("failed to create block item: %w", err)
-				}
-
-				// Обновляем ID и временные метки в оригинальном элементе
-				originalItems[j].ID = itemToCreate.ID
-				originalItems[j].BlockID = blockToCreate.ID
-				originalItems[j].CreatedAt = now
-				originalItems[j].UpdatedAt = now
-			}
+		// Переносим ID и временные метки обратно в расписание
+		k := 0
+		for i := range schedule.Blocks {
+			block := &schedule.Blocks[i]
+			block.ID = blocks[i].ID
+			block.ScheduleID = schedule.ID
+			block.Order = blocks[i].Order
+			block.CreatedAt = now
+			block.UpdatedAt = now
 
-			// Обновляем ID, временные метки и элементы в оригинальном блоке
-			originalBlocks[i].ID = blockToCreate.ID
-			originalBlocks[i].ScheduleID = schedule.ID
-			originalBlocks[i].CreatedAt = now
-			originalBlocks[i].UpdatedAt = now
-			originalBlocks[i].Items = originalItems
+			for j := range block.Items {
+				block.Items[j].ID = items[k].ID
+				block.Items[j].BlockID = block.ID
+				block.Items[j].Order = items[k].Order
+				block.Items[j].CreatedAt = now
+				block.Items[j].UpdatedAt = now
+				k++
+			}
 		}
 
-		// Возвращаем блоки обратно в расписание
-		schedule.Blocks = originalBlocks
+		schedule.BindConstraints(refs)
+		if err := replaceConstraints(tx, schedule); err != nil {
+			return err
+		}
+		if err := replaceItemPerformers(tx, schedule, nil); err != nil {
+			return err
+		}
+		if err := replaceResourceBookings(tx, schedule, nil); err != nil {
+			return err
+		}
 
-		return nil
+		return reindexSchedule(tx, schedule.ID)
 	})
 }
 
 // Update обновляет существующее расписание
 func (r *ScheduleRepository) Update(ctx context.Context, schedule *models.Schedule) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Получаем текущее расписание для сравнения
-		var existingSchedule models.Schedule
-		if err := tx.Preload("Blocks.Items").First(&existingSchedule, schedule.ID).Error; err != nil {
-			return fmt.Errorf("failed to get existing schedule: %w", err)
-		}
+		return updateSchedule(tx, schedule)
+	})
+}
 
-		// Обновляем основные поля расписания
-		if err := tx.Model(schedule).Updates(map[string]interface{}{
-			"start_date": schedule.StartDate,
-			"end_date":   schedule.EndDate,
-			"updated_at": time.Now(),
-		}).Error; err != nil {
-			return fmt.Errorf("failed to update schedule: %w", err)
+// UpdateWithVersion обновляет расписание и сохраняет его версию в одной транзакции
+func (r *ScheduleRepository) UpdateWithVersion(ctx context.Context, schedule *models.Schedule, version domain.VersionFunc) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updateSchedule(tx, schedule); err != nil {
+			return err
 		}
-
-		// Создаем мапы существующих блоков и элементов
-		existingBlocks := make(map[uint]*models.Block)
-		existingItems := make(map[uint]*models.BlockItem)
-
-		for i := range existingSchedule.Blocks {
-			block := &existingSchedule.Blocks[i]
-			existingBlocks[block.ID] = block
-			for j := range block.Items {
-				item := &block.Items[j]
-				existingItems[item.ID] = item
-			}
+		saved, err := loadSchedule(tx, "id = ?", schedule.ID)
+		if err != nil {
+			return err
+		}
+		latest, err := latestVersion(tx, schedule.ID)
+		if errors.Is(err, utils.ErrNotFound) {
+			latest, err = nil, nil
+		}
+		if err != nil {
+			return err
 		}
 
-		// Обрабатываем блоки
-		for i := range schedule.Blocks {
-			block := &schedule.Blocks[i]
-			block.ScheduleID = schedule.ID
-
-			if block.ID == 0 {
-				// Новый блок
-				if err := tx.Create(block).Error; err != nil {
-					return fmt.Errorf("failed to create new block: %w", err)
-				}
-			} else {
-				// Обновляем существующий блок
-				if err := tx.Model(block).Updates(map[string]interface{}{
-					"name":       block.Name,
-					"start_time": block.StartTime,
-					"duration":   block.Duration,
-					"order":      block.Order,
-					"updated_at": time.Now(),
-				}).Error; err != nil {
-					return fmt.Errorf("failed to update block: %w", err)
-				}
-				delete(existingBlocks, block.ID)
-			}
+		next, err := version(saved, latest)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(next).Error; err != nil {
+			return fmt.Errorf("failed to create version: %w", mapError(err))
+		}
+		return nil
+	})
+}
 
-			// Обрабатываем элементы блока
-			for j := range block.Items {
-				item := &block.Items[j]
-				item.BlockID = block.ID
-
-				if item.ID == 0 {
-					// Новый элемент
-					if err := tx.Create(item).Error; err != nil {
-						return fmt.Errorf("failed to create new block item: %w", err)
-					}
-				} else {
-					// Обновляем существующий элемент
-					if err := tx.Model(item).Updates(map[string]interface{}{
-						"name":       item.Name,
-						"duration":   item.Duration,
-						"order":      item.Order,
-						"updated_at": time.Now(),
-					}).Error; err != nil {
-						return fmt.Errorf("failed to update block item: %w", err)
-					}
-					delete(existingItems, item.ID)
-				}
-			}
+// updateSchedule приводит сохраненное расписание к переданному в транзакции tx
+func updateSchedule(tx *gorm.DB, schedule *models.Schedule) error {
+	now := time.Now()
+	// Новые блоки получат ID ниже, поэтому ссылки ограничений находим заранее
+	refs := schedule.ResolveConstraints()
+
+	// Обновляем основные поля расписания
+	result := tx.Model(&models.Schedule{}).Where("id = ?", schedule.ID).Updates(map[string]interface{}{
+		"name":       schedule.Name,
+		"slug":       schedule.Slug,
+		"language":   schedule.Language,
+		"timezone":   schedule.Timezone,
+		"start_date": schedule.StartDate,
+		"end_date":   schedule.EndDate,
+		"updated_at": now,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update schedule: %w", mapError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to get existing schedule: %w", utils.ErrNotFound)
+	}
+	schedule.UpdatedAt = now
+
+	// ID блоков и элементов, которые сейчас принадлежат расписанию.
+	// ID из запроса, не найденные здесь, считаются новыми записями.
+	var existingBlockIDs, existingItemIDs []uint
+	if err := tx.Model(&models.Block{}).
+		Where("schedule_id = ?", schedule.ID).
+		Pluck("id", &existingBlockIDs).Error; err != nil {
+		return fmt.Errorf("failed to get existing blocks: %w", mapError(err))
+	}
+	if len(existingBlockIDs) > 0 {
+		if err := tx.Model(&models.BlockItem{}).
+			Where("block_id IN ?", existingBlockIDs).
+			Pluck("id", &existingItemIDs).Error; err != nil {
+			return fmt.Errorf("failed to get existing block items: %w", mapError(err))
+		}
+	}
+	knownBlocks := idSet(existingBlockIDs)
+	knownItems := idSet(existingItemIDs)
+
+	// Блоки: новые вставляем пачкой, существующие обновляем одним upsert
+	var newBlocks, changedBlocks []models.Block
+	var newBlockIdx []int
+	for i := range schedule.Blocks {
+		block := &schedule.Blocks[i]
+		block.ScheduleID = schedule.ID
+		row := newBlockRow(block, schedule.ID, block.Order, now)
+
+		if knownBlocks[block.ID] {
+			row.ID = block.ID
+			changedBlocks = append(changedBlocks, row)
+			continue
 		}
+		row.CreatedAt = now
+		newBlocks = append(newBlocks, row)
+		newBlockIdx = append(newBlockIdx, i)
+	}
+	if err := insertBlocks(tx, newBlocks); err != nil {
+		return err
+	}
+	for k, i := range newBlockIdx {
+		schedule.Blocks[i].ID = newBlocks[k].ID
+		schedule.Blocks[i].CreatedAt = now
+	}
+	if err := upsertRows(tx, &changedBlocks, len(changedBlocks), blockUpsertColumns); err != nil {
+		return fmt.Errorf("failed to update blocks: %w", mapError(err))
+	}
 
-		// Удаляем оставшиеся элементы
-		for _, item := range existingItems {
-			if err := tx.Delete(item).Error; err != nil {
-				return fmt.Errorf("failed to delete block item: %w", err)
+	// Элементы: аналогично, после того как у всех блоков появились ID
+	var newItems, changedItems []models.BlockItem
+	var newItemIdx [][2]int
+	keepBlockIDs := make([]uint, 0, len(schedule.Blocks))
+	keepItemIDs := make([]uint, 0)
+	for i := range schedule.Blocks {
+		block := &schedule.Blocks[i]
+		block.UpdatedAt = now
+		keepBlockIDs = append(keepBlockIDs, block.ID)
+
+		for j := range block.Items {
+			item := &block.Items[j]
+			item.BlockID = block.ID
+			item.UpdatedAt = now
+			row := newItemRow(item, block.ID, item.Order, now)
+
+			if knownItems[item.ID] {
+				row.ID = item.ID
+				changedItems = append(changedItems, row)
+				keepItemIDs = append(keepItemIDs, item.ID)
+				continue
 			}
+			row.CreatedAt = now
+			newItems = append(newItems, row)
+			newItemIdx = append(newItemIdx, [2]int{i, j})
 		}
+	}
+	if err := insertItems(tx, newItems); err != nil {
+		return err
+	}
+	for k, idx := range newItemIdx {
+		item := &schedule.Blocks[idx[0]].Items[idx[1]]
+		item.ID = newItems[k].ID
+		item.CreatedAt = now
+		keepItemIDs = append(keepItemIDs, item.ID)
+	}
+	if err := upsertRows(tx, &changedItems, len(changedItems), itemUpsertColumns); err != nil {
+		return fmt.Errorf("failed to update block items: %w", mapError(err))
+	}
 
-		// Удаляем оставшиеся блоки
-		for _, block := range existingBlocks {
-			if err := tx.Delete(block).Error; err != nil {
-				return fmt.Errorf("failed to delete block: %w", err)
-			}
+	// Удаляем одним запросом все элементы и блоки, которых нет в запросе
+	if len(existingItemIDs) > 0 {
+		stale := tx.Where("id IN ?", existingItemIDs)
+		if len(keepItemIDs) > 0 {
+			stale = stale.Where("id NOT IN ?", keepItemIDs)
 		}
+		if err := stale.Delete(&models.BlockItem{}).Error; err != nil {
+			return fmt.Errorf("failed to delete block items: %w", mapError(err))
+		}
+	}
 
-		return nil
-	})
+	stale := tx.Where("schedule_id = ?", schedule.ID)
+	if len(keepBlockIDs) > 0 {
+		stale = stale.Where("id NOT IN ?", keepBlockIDs)
+	}
+	if err := stale.Delete(&models.Block{}).Error; err != nil {
+		return fmt.Errorf("failed to delete blocks: %w", mapError(err))
+	}
+
+	schedule.BindConstraints(refs)
+	if err := replaceConstraints(tx, schedule); err != nil {
+		return err
+	}
+	if err := replaceItemPerformers(tx, schedule, existingItemIDs); err != nil {
+		return err
+	}
+	if err := replaceResourceBookings(tx, schedule, existingBlockIDs); err != nil {
+		return err
+	}
+
+	return reindexSchedule(tx, schedule.ID)
 }
 
 // GetByID получает расписание по ID
 func (r *ScheduleRepository) GetByID(ctx context.Context, id uint) (*models.Schedule, error) {
+	return r.get(ctx, "id = ?", id)
+}
+
+// GetBySlug получает расписание по адресу публичной страницы
+func (r *ScheduleRepository) GetBySlug(ctx context.Context, slug string) (*models.Schedule, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("failed to get schedule: %w", utils.ErrNotFound)
+	}
+	return r.get(ctx, "slug = ?", slug)
+}
+
+// get загружает первое расписание по условию вместе с блоками, элементами,
+// ограничениями и связями
+func (r *ScheduleRepository) get(ctx context.Context, query string, args ...interface{}) (*models.Schedule, error) {
+	return loadSchedule(r.db.WithContext(ctx), query, args...)
+}
+
+// loadSchedule загружает расписание через db, в том числе внутри транзакции
+func loadSchedule(db *gorm.DB, query string, args ...interface{}) (*models.Schedule, error) {
 	var schedule models.Schedule
-	err := r.db.WithContext(ctx).
+	err := db.
 		Preload("Blocks", func(db *gorm.DB) *gorm.DB {
-			return db.Order("blocks.order ASC")
+			return db.Order(orderColumn("blocks"))
 		}).
 		Preload("Blocks.Items", func(db *gorm.DB) *gorm.DB {
-			return db.Order("block_items.order ASC")
+			return db.Order(orderColumn("block_items"))
 		}).
-		First(&schedule, id).Error
+		Preload("Constraints", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Where(query, args...).
+		First(&schedule).Error
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get schedule: %w", err)
+		return nil, fmt.Errorf("failed to get schedule: %w", mapError(err))
+	}
+	if err := loadItemPerformers(db, []*models.Schedule{&schedule}); err != nil {
+		return nil, err
+	}
+	if err := loadResourceBookings(db, []*models.Schedule{&schedule}); err != nil {
+		return nil, err
 	}
 	return &schedule, nil
 }
@@ -221,95 +345,372 @@ func (r *ScheduleRepository) GetByID(ctx context.Context, id uint) (*models.Sche
 // Delete удаляет расписание
 func (r *ScheduleRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Получаем расписание со всеми связями
 		var schedule models.Schedule
-		if err := tx.Preload("Blocks.Items").First(&schedule, id).Error; err != nil {
-			return fmt.Errorf("failed to get schedule for deletion: %w", err)
+		if err := tx.Select("id").First(&schedule, id).Error; err != nil {
+			return fmt.Errorf("failed to get schedule for deletion: %w", mapError(err))
 		}
 
-		// Удаляем все элементы блоков
-		for _, block := range schedule.Blocks {
-			if err := tx.Where("block_id = ?", block.ID).Delete(&models.BlockItem{}).Error; err != nil {
-				return fmt.Errorf("failed to delete block items: %w", err)
-			}
+		// Удаляем все элементы блоков одним запросом
+		blockIDs := tx.Model(&models.Block{}).Select("id").Where("schedule_id = ?", id)
+
+		// Элементы удаляются мягко, поэтому их связи с выступающими удаляются явно
+		itemIDs := tx.Model(&models.BlockItem{}).Select("id").Where("block_id IN (?)", blockIDs)
+		if err := tx.Where("item_id IN (?)", itemIDs).Delete(&models.ItemPerformer{}).Error; err != nil {
+			return fmt.Errorf("failed to delete performer links: %w", mapError(err))
+		}
+		if err := tx.Where("block_id IN (?)", blockIDs).Delete(&models.ResourceBooking{}).Error; err != nil {
+			return fmt.Errorf("failed to delete resource bookings: %w", mapError(err))
+		}
+		if err := tx.Where("block_id IN (?)", blockIDs).Delete(&models.BlockItem{}).Error; err != nil {
+			return fmt.Errorf("failed to delete block items: %w", mapError(err))
 		}
 
 		// Удаляем блоки
 		if err := tx.Where("schedule_id = ?", id).Delete(&models.Block{}).Error; err != nil {
-			return fmt.Errorf("failed to delete blocks: %w", err)
+			return fmt.Errorf("failed to delete blocks: %w", mapError(err))
 		}
 
 		// Удаляем само расписание
 		if err := tx.Delete(&models.Schedule{}, id).Error; err != nil {
-			return fmt.Errorf("failed to delete schedule: %w", err)
+			return fmt.Errorf("failed to delete schedule: %w", mapError(err))
 		}
 
-		return nil
+		// Расписания удаляются мягко, поэтому каскад внешних ключей не срабатывает
+		if err := tx.Where("schedule_id = ?", id).Delete(&models.RuleSet{}).Error; err != nil {
+			return fmt.Errorf("failed to delete rule set: %w", mapError(err))
+		}
+		if err := tx.Where("schedule_id = ?", id).Delete(&models.BlockConstraint{}).Error; err != nil {
+			return fmt.Errorf("failed to delete block constraints: %w", mapError(err))
+		}
+		if err := tx.Where("schedule_id = ?", id).Delete(&models.ShareLink{}).Error; err != nil {
+			return fmt.Errorf("failed to delete share links: %w", mapError(err))
+		}
+		if err := tx.Where("schedule_id = ?", id).Delete(&models.Reminder{}).Error; err != nil {
+			return fmt.Errorf("failed to delete reminders: %w", mapError(err))
+		}
+
+		return removeFromIndex(tx, id)
 	})
 }
 
-// List возвращает список расписаний с пагинацией
-func (r *ScheduleRepository) List(ctx context.Context, offset, limit int) ([]models.Schedule, int64, error) {
-	var schedules []models.Schedule
-	var total int64
+// ListSummaries возвращает облегченные представления расписаний с id больше afterID
+func (r *ScheduleRepository) ListSummaries(ctx context.Context, afterID uint, limit int) ([]models.ScheduleSummary, error) {
+	summaries := make([]models.ScheduleSummary, 0, limit)
 
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Получаем общее количество
-		if err := tx.Model(&models.Schedule{}).Count(&total).Error; err != nil {
-			return fmt.Errorf("failed to count schedules: %w", err)
-		}
+	query := r.db.WithContext(ctx).
+		Table("schedules AS s").
+		Select(`s.id, s.name, s.start_date, s.end_date, s.updated_at,
+			COUNT(b.id) AS block_count,
+			COALESCE(SUM(b.duration + b.tech_break_duration), 0) AS total_duration`).
+		Joins("LEFT JOIN blocks b ON b.schedule_id = s.id AND b.deleted_at IS NULL").
+		Where("s.deleted_at IS NULL")
+
+	if afterID > 0 {
+		query = query.Where("s.id > ?", afterID)
+	}
+
+	if err := query.
+		Group("s.id").
+		Order("s.id ASC").
+		Limit(limit).
+		Scan(&summaries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list schedule summaries: %w", mapError(err))
+	}
 
-		// Получаем расписания с блоками и элементами
-		if err := tx.Preload("Blocks", func(db *gorm.DB) *gorm.DB {
-			return db.Order("blocks.order ASC")
+	now := time.Now()
+	for i := range summaries {
+		summaries[i].Status = models.ScheduleStatusAt(summaries[i].StartDate, summaries[i].EndDate, now)
+	}
+
+	return summaries, nil
+}
+
+// List возвращает расписания с блоками и элементами с id больше afterID
+func (r *ScheduleRepository) List(ctx context.Context, afterID uint, limit int) ([]models.Schedule, error) {
+	schedules := make([]models.Schedule, 0, limit)
+
+	query := r.db.WithContext(ctx).
+		Preload("Blocks", func(db *gorm.DB) *gorm.DB {
+			return db.Order(orderColumn("blocks"))
 		}).
-			Preload("Blocks.Items", func(db *gorm.DB) *gorm.DB {
-				return db.Order("block_items.order ASC")
-			}).
-			Offset(offset).
-			Limit(limit).
-			Find(&schedules).Error; err != nil {
-			return fmt.Errorf("failed to list schedules: %w", err)
-		}
+		Preload("Blocks.Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order(orderColumn("block_items"))
+		}).
+		Preload("Constraints", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		})
+
+	if afterID > 0 {
+		query = query.Where("id > ?", afterID)
+	}
+
+	if err := query.Order("id ASC").Limit(limit).Find(&schedules).Error; err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", mapError(err))
+	}
+
+	page := make([]*models.Schedule, len(schedules))
+	for i := range schedules {
+		page[i] = &schedules[i]
+	}
+	if err := loadItemPerformers(r.db.WithContext(ctx), page); err != nil {
+		return nil, err
+	}
+	if err := loadResourceBookings(r.db.WithContext(ctx), page); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// newBlockRow копирует поля блока без связей для записи в БД
+func newBlockRow(block *models.Block, scheduleID uint, order int, now time.Time) models.Block {
+	return models.Block{
+		ScheduleID:        scheduleID,
+		Name:              block.Name,
+		Type:              block.Type,
+		Track:             block.Track,
+		StartTime:         block.StartTime,
+		FinishTime:        block.StartTime.Add(time.Duration(block.Duration) * time.Minute),
+		Duration:          block.Duration,
+		TechBreakDuration: block.TechBreakDuration,
+		ItemGap:           block.ItemGap,
+		SlackPolicy:       block.SlackPolicy,
+		Visibility:        block.Visibility,
+		Notes:             block.Notes,
+		Order:             order,
+		UpdatedAt:         now,
+	}
+}
+
+// newItemRow копирует поля элемента для записи в БД
+func newItemRow(item *models.BlockItem, blockID uint, order int, now time.Time) models.BlockItem {
+	return models.BlockItem{
+		BlockID:     blockID,
+		Name:        item.Name,
+		Type:        item.Type,
+		Description: item.Description,
+		Duration:    item.Duration,
+		Priority:    item.Priority,
+		MinDuration: item.MinDuration,
+		StartTime:   item.StartTime,
+		EndTime:     item.EndTime,
+		CallOffset:  item.CallOffset,
+		Visibility:  item.Visibility,
+		Notes:       item.Notes,
+		Order:       order,
+		UpdatedAt:   now,
+	}
+}
 
+// insertBlocks вставляет блоки пачками, заполняя их ID
+func insertBlocks(tx *gorm.DB, blocks []models.Block) error {
+	if len(blocks) == 0 {
 		return nil
-	})
+	}
+	if err := tx.CreateInBatches(&blocks, writeBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to create blocks: %w", mapError(err))
+	}
+	return nil
+}
 
-	if err != nil {
-		return nil, 0, err
+// insertItems вставляет элементы пачками, заполняя их ID
+func insertItems(tx *gorm.DB, items []models.BlockItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if err := tx.CreateInBatches(&items, writeBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to create block items: %w", mapError(err))
+	}
+	return nil
+}
+
+// replaceConstraints заменяет ограничения расписания переданными и заполняет их ID.
+// Ссылки на блоки к этому моменту уже связаны через Schedule.BindConstraints.
+func replaceConstraints(tx *gorm.DB, schedule *models.Schedule) error {
+	if err := tx.Where("schedule_id = ?", schedule.ID).Delete(&models.BlockConstraint{}).Error; err != nil {
+		return fmt.Errorf("failed to delete block constraints: %w", mapError(err))
+	}
+	if len(schedule.Constraints) == 0 {
+		return nil
+	}
+
+	rows := make([]models.BlockConstraint, len(schedule.Constraints))
+	for i, constraint := range schedule.Constraints {
+		constraint.ID = 0
+		constraint.ScheduleID = schedule.ID
+		rows[i] = constraint
+	}
+	if err := tx.CreateInBatches(&rows, writeBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to create block constraints: %w", mapError(err))
 	}
 
-	return schedules, total, nil
+	for i := range schedule.Constraints {
+		schedule.Constraints[i].ID = rows[i].ID
+		schedule.Constraints[i].ScheduleID = schedule.ID
+	}
+	return nil
 }
 
-// ValidateScheduleTimes проверяет корректность временных интервалов
-func (r *ScheduleRepository) ValidateScheduleTimes(schedule *models.Schedule) error {
-	if schedule.StartDate.After(schedule.EndDate) {
-		return fmt.Errorf("start time must be before end time")
+// replaceItemPerformers заменяет связи элементов расписания с выступающими.
+// staleItemIDs — элементы, принадлежавшие расписанию до записи; их связи
+// удаляются. PerformerIDs элементов приводятся к виду, в котором они читаются.
+func replaceItemPerformers(tx *gorm.DB, schedule *models.Schedule, staleItemIDs []uint) error {
+	if len(staleItemIDs) > 0 {
+		if err := tx.Where("item_id IN ?", staleItemIDs).Delete(&models.ItemPerformer{}).Error; err != nil {
+			return fmt.Errorf("failed to delete performer links: %w", mapError(err))
+		}
+	}
+
+	var rows []models.ItemPerformer
+	for i := range schedule.Blocks {
+		for j := range schedule.Blocks[i].Items {
+			item := &schedule.Blocks[i].Items[j]
+			item.PerformerIDs = models.SortedIDs(item.PerformerIDs)
+			for _, performerID := range item.PerformerIDs {
+				rows = append(rows, models.ItemPerformer{ItemID: item.ID, PerformerID: performerID})
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return nil
 	}
+	if err := tx.CreateInBatches(&rows, writeBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to link performers: %w", mapError(err))
+	}
+	return nil
+}
 
-	currentTime := schedule.StartDate
-	for i, block := range schedule.Blocks {
-		if block.StartTime.Before(currentTime) {
-			return fmt.Errorf("block %d starts before previous block ends", i+1)
+// loadItemPerformers заполняет PerformerIDs элементов загруженных расписаний
+func loadItemPerformers(db *gorm.DB, schedules []*models.Schedule) error {
+	items := make(map[uint]*models.BlockItem)
+	for _, schedule := range schedules {
+		for i := range schedule.Blocks {
+			for j := range schedule.Blocks[i].Items {
+				item := &schedule.Blocks[i].Items[j]
+				item.PerformerIDs = []uint{}
+				items[item.ID] = item
+			}
 		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	itemIDs := make([]uint, 0, len(items))
+	for id := range items {
+		itemIDs = append(itemIDs, id)
+	}
+
+	var links []models.ItemPerformer
+	if err := db.Where("item_id IN ?", itemIDs).Order("item_id ASC, performer_id ASC").Find(&links).Error; err != nil {
+		return fmt.Errorf("failed to get performer links: %w", mapError(err))
+	}
+	for _, link := range links {
+		item := items[link.ItemID]
+		item.PerformerIDs = append(item.PerformerIDs, link.PerformerID)
+	}
+	return nil
+}
 
-		blockEndTime := block.StartTime.Add(time.Duration(block.Duration) * time.Minute)
-		if blockEndTime.After(schedule.EndDate) {
-			return fmt.Errorf("block %d ends after schedule end time", i+1)
+// replaceResourceBookings заменяет брони ресурсов блоками и элементами
+// расписания. staleBlockIDs — блоки, принадлежавшие расписанию до записи; их
+// брони удаляются.
+func replaceResourceBookings(tx *gorm.DB, schedule *models.Schedule, staleBlockIDs []uint) error {
+	if len(staleBlockIDs) > 0 {
+		if err := tx.Where("block_id IN ?", staleBlockIDs).Delete(&models.ResourceBooking{}).Error; err != nil {
+			return fmt.Errorf("failed to delete resource bookings: %w", mapError(err))
 		}
+	}
 
-		totalItemsDuration := 0
-		for _, item := range block.Items {
-			totalItemsDuration += item.Duration
+	var rows []models.ResourceBooking
+	for i := range schedule.Blocks {
+		block := &schedule.Blocks[i]
+		block.Resources = models.CopyAssignments(block.Resources)
+		for _, assignment := range block.Resources {
+			rows = append(rows, models.ResourceBooking{ResourceID: assignment.ResourceID, BlockID: block.ID, Quantity: assignment.Quantity})
+		}
+		for j := range block.Items {
+			item := &block.Items[j]
+			item.Resources = models.CopyAssignments(item.Resources)
+			for _, assignment := range item.Resources {
+				rows = append(rows, models.ResourceBooking{
+					ResourceID: assignment.ResourceID, BlockID: block.ID, ItemID: &item.ID, Quantity: assignment.Quantity,
+				})
+			}
 		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	if err := tx.CreateInBatches(&rows, writeBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to book resources: %w", mapError(err))
+	}
+	return nil
+}
 
-		if totalItemsDuration > block.Duration {
-			return fmt.Errorf("total duration of items in block %d exceeds block duration", i+1)
+// loadResourceBookings заполняет брони ресурсов блоков и элементов загруженных расписаний
+func loadResourceBookings(db *gorm.DB, schedules []*models.Schedule) error {
+	blocks := make(map[uint]*models.Block)
+	items := make(map[uint]*models.BlockItem)
+	for _, schedule := range schedules {
+		for i := range schedule.Blocks {
+			block := &schedule.Blocks[i]
+			block.Resources = []models.ResourceAssignment{}
+			blocks[block.ID] = block
+			for j := range block.Items {
+				item := &block.Items[j]
+				item.Resources = []models.ResourceAssignment{}
+				items[item.ID] = item
+			}
 		}
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
 
-		currentTime = blockEndTime
+	blockIDs := make([]uint, 0, len(blocks))
+	for id := range blocks {
+		blockIDs = append(blockIDs, id)
 	}
 
+	var bookings []models.ResourceBooking
+	if err := db.Where("block_id IN ?", blockIDs).Order("id ASC").Find(&bookings).Error; err != nil {
+		return fmt.Errorf("failed to get resource bookings: %w", mapError(err))
+	}
+	for _, booking := range bookings {
+		assignment := models.ResourceAssignment{ResourceID: booking.ResourceID, Quantity: booking.Quantity}
+		if booking.ItemID == nil {
+			blocks[booking.BlockID].Resources = append(blocks[booking.BlockID].Resources, assignment)
+			continue
+		}
+		if item, ok := items[*booking.ItemID]; ok {
+			item.Resources = append(item.Resources, assignment)
+		}
+	}
 	return nil
 }
+
+// upsertRows обновляет существующие строки одним INSERT ... ON CONFLICT (id) DO UPDATE
+func upsertRows(tx *gorm.DB, rows interface{}, count int, columns []string) error {
+	if count == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).CreateInBatches(rows, writeBatchSize).Error
+}
+
+// orderColumn возвращает сортировку по колонке order; имя колонки — ключевое слово SQL,
+// поэтому оно экранируется средствами диалекта
+func orderColumn(table string) clause.OrderByColumn {
+	return clause.OrderByColumn{Column: clause.Column{Table: table, Name: "order"}}
+}
+
+func idSet(ids []uint) map[uint]bool {
+	set := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
@@ -0,0 +1,235 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/infrastructure/db"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Бенчмарки записи требуют PostgreSQL:
+//
+//	TEST_DATABASE_DSN="host=localhost user=postgres password=postgres dbname=scheduler_test sslmode=disable" \
+//	    go test -run '^$' -bench ScheduleRepository ./internal/domain/repositories/
+//
+// Варианты RowByRow воспроизводят прежнюю построчную запись и служат точкой сравнения.
+
+var benchItemCounts = []int{10, 100, 1000}
+
+const benchItemsPerBlock = 10
+
+func openBenchDB(b *testing.B) *gorm.DB {
+	b.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		b.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	database, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		b.Fatalf("failed to connect to database: %v", err)
+	}
+
+	if err := db.Migrate(database); err != nil {
+		b.Fatalf("failed to migrate: %v", err)
+	}
+
+	return database
+}
+
+// benchSchedule строит расписание с заданным числом элементов, по 10 элементов на блок
+func benchSchedule(itemCount int) *models.Schedule {
+	start := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+	schedule := &models.Schedule{
+		Name:      fmt.Sprintf("Bench %d", itemCount),
+		StartDate: start,
+		EndDate:   start.Add(time.Duration(itemCount*10) * time.Minute),
+	}
+
+	current := start
+	for b := 0; b*benchItemsPerBlock < itemCount; b++ {
+		block := models.Block{
+			Name:              fmt.Sprintf("Block %d", b+1),
+			Type:              "performance",
+			StartTime:         current,
+			Duration:          benchItemsPerBlock * 5,
+			TechBreakDuration: 5,
+			Order:             b + 1,
+		}
+		for i := 0; i < benchItemsPerBlock && b*benchItemsPerBlock+i < itemCount; i++ {
+			block.Items = append(block.Items, models.BlockItem{
+				Name:        fmt.Sprintf("Item %d.%d", b+1, i+1),
+				Type:        "performance",
+				Description: "Bench item",
+				Duration:    5,
+				Order:       i + 1,
+			})
+		}
+		current = block.EndTime()
+		schedule.Blocks = append(schedule.Blocks, block)
+	}
+
+	return schedule
+}
+
+// touchSchedule меняет каждый блок и элемент, чтобы Update писал все строки
+func touchSchedule(schedule *models.Schedule, n int) {
+	for i := range schedule.Blocks {
+		schedule.Blocks[i].Name = fmt.Sprintf("Block %d rev %d", i+1, n)
+		for j := range schedule.Blocks[i].Items {
+			schedule.Blocks[i].Items[j].Duration = 4 + n%2
+		}
+	}
+}
+
+func BenchmarkScheduleRepositoryCreate(b *testing.B) {
+	db := openBenchDB(b)
+	repo := NewScheduleRepository(db)
+	ctx := context.Background()
+
+	for _, n := range benchItemCounts {
+		b.Run(fmt.Sprintf("items=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := repo.Create(ctx, benchSchedule(n)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkScheduleRepositoryCreateRowByRow(b *testing.B) {
+	db := openBenchDB(b)
+	ctx := context.Background()
+
+	for _, n := range benchItemCounts {
+		b.Run(fmt.Sprintf("items=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := createRowByRow(db.WithContext(ctx), benchSchedule(n)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkScheduleRepositoryUpdate(b *testing.B) {
+	db := openBenchDB(b)
+	repo := NewScheduleRepository(db)
+	ctx := context.Background()
+
+	for _, n := range benchItemCounts {
+		b.Run(fmt.Sprintf("items=%d", n), func(b *testing.B) {
+			schedule := benchSchedule(n)
+			if err := repo.Create(ctx, schedule); err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				touchSchedule(schedule, i)
+				if err := repo.Update(ctx, schedule); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkScheduleRepositoryUpdateRowByRow(b *testing.B) {
+	db := openBenchDB(b)
+	repo := NewScheduleRepository(db)
+	ctx := context.Background()
+
+	for _, n := range benchItemCounts {
+		b.Run(fmt.Sprintf("items=%d", n), func(b *testing.B) {
+			schedule := benchSchedule(n)
+			if err := repo.Create(ctx, schedule); err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				touchSchedule(schedule, i)
+				if err := updateRowByRow(db.WithContext(ctx), schedule); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// createRowByRow — прежняя реализация Create: один INSERT на каждый блок и элемент
+func createRowByRow(db *gorm.DB, schedule *models.Schedule) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		row := &models.Schedule{Name: schedule.Name, StartDate: schedule.StartDate, EndDate: schedule.EndDate}
+		if err := tx.Create(row).Error; err != nil {
+			return err
+		}
+
+		for i := range schedule.Blocks {
+			block := newBlockRow(&schedule.Blocks[i], row.ID, i+1, time.Now())
+			if err := tx.Create(&block).Error; err != nil {
+				return err
+			}
+			for j := range schedule.Blocks[i].Items {
+				item := newItemRow(&schedule.Blocks[i].Items[j], block.ID, j+1, time.Now())
+				if err := tx.Create(&item).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return reindexSchedule(tx, row.ID)
+	})
+}
+
+// updateRowByRow — прежняя реализация Update: один UPDATE на каждый блок и элемент
+func updateRowByRow(db *gorm.DB, schedule *models.Schedule) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Schedule{}).Where("id = ?", schedule.ID).Updates(map[string]interface{}{
+			"start_date": schedule.StartDate,
+			"end_date":   schedule.EndDate,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+
+		for i := range schedule.Blocks {
+			block := &schedule.Blocks[i]
+			if err := tx.Model(&models.Block{}).Where("id = ?", block.ID).Updates(map[string]interface{}{
+				"name":       block.Name,
+				"start_time": block.StartTime,
+				"duration":   block.Duration,
+				"order":      block.Order,
+				"updated_at": time.Now(),
+			}).Error; err != nil {
+				return err
+			}
+			for j := range block.Items {
+				item := &block.Items[j]
+				if err := tx.Model(&models.BlockItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+					"name":       item.Name,
+					"duration":   item.Duration,
+					"order":      item.Order,
+					"updated_at": time.Now(),
+				}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return reindexSchedule(tx, schedule.ID)
+	})
+}
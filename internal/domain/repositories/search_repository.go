@@ -0,0 +1,282 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+
+	"gorm.io/gorm"
+)
+
+// Документ индекса собирается из русской и английской конфигураций,
+// чтобы стемминг работал для обоих языков независимо от языка запроса.
+const (
+	searchDocumentA = "setweight(to_tsvector('russian', coalesce(%[1]s, '')), 'A') || setweight(to_tsvector('english', coalesce(%[1]s, '')), 'A')"
+	searchDocumentB = "setweight(to_tsvector('russian', coalesce(%[1]s, '')), 'B') || setweight(to_tsvector('english', coalesce(%[1]s, '')), 'B')"
+)
+
+// Совпадения во фрагменте база отмечает управляющими символами, а не тегами:
+// текст расписаний пользовательский, поэтому фрагмент сначала экранируется и
+// только потом границы заменяются на <mark> (см. markSnippet)
+const (
+	snippetStart = "\x02"
+	snippetStop  = "\x03"
+)
+
+var _ domain.SearchRepository = (*SearchRepository)(nil)
+
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// Search выполняет полнотекстовый поиск по расписаниям, блокам и элементам
+func (r *SearchRepository) Search(ctx context.Context, query string, offset, limit int) ([]models.SearchHit, error) {
+	if isSQLite(r.db) {
+		return r.searchSQLite(ctx, query, offset, limit)
+	}
+
+	hits := make([]models.SearchHit, 0)
+
+	err := r.db.WithContext(ctx).Raw(`
+		WITH q AS (
+			SELECT websearch_to_tsquery('russian', @query) || websearch_to_tsquery('english', @query) AS query
+		)
+		SELECT
+			e.kind,
+			ts_rank(e.document, q.query) AS rank,
+			e.schedule_id,
+			s.name AS schedule_name,
+			e.block_id,
+			coalesce(b.name, '') AS block_name,
+			e.item_id,
+			coalesce(i.name, '') AS item_name,
+			ts_headline('russian', e.title || ' ' || e.body, q.query, @options) AS snippet
+		FROM search_entries e
+		CROSS JOIN q
+		JOIN schedules s ON s.id = e.schedule_id AND s.deleted_at IS NULL
+		LEFT JOIN blocks b ON b.id = e.block_id
+		LEFT JOIN block_items i ON i.id = e.item_id
+		WHERE e.document @@ q.query
+		ORDER BY rank DESC, e.id ASC
+		OFFSET @offset
+		LIMIT @limit`,
+		map[string]interface{}{
+			"query":   query,
+			"options": "StartSel=" + snippetStart + ", StopSel=" + snippetStop + ", MaxFragments=2, MaxWords=20, MinWords=5",
+			"offset":  offset,
+			"limit":   limit,
+		},
+	).Scan(&hits).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", mapError(err))
+	}
+
+	return markSnippets(hits), nil
+}
+
+// searchSQLite выполняет поиск по виртуальной таблице FTS5
+func (r *SearchRepository) searchSQLite(ctx context.Context, query string, offset, limit int) ([]models.SearchHit, error) {
+	hits := make([]models.SearchHit, 0)
+
+	match := ftsQuery(query)
+	if match == "" {
+		return hits, nil
+	}
+
+	err := r.db.WithContext(ctx).Raw(`
+		WITH matches AS (
+			SELECT
+				rowid AS id,
+				kind,
+				CAST(schedule_id AS INTEGER) AS schedule_id,
+				CAST(block_id AS INTEGER) AS block_id,
+				CAST(item_id AS INTEGER) AS item_id,
+				-bm25(search_entries, 10.0, 5.0) AS rank,
+				snippet(search_entries, -1, @start, @stop, '…', 20) AS snippet
+			FROM search_entries
+			WHERE search_entries MATCH @match
+		)
+		SELECT
+			m.kind,
+			m.rank,
+			m.schedule_id,
+			s.name AS schedule_name,
+			m.block_id,
+			coalesce(b.name, '') AS block_name,
+			m.item_id,
+			coalesce(i.name, '') AS item_name,
+			m.snippet
+		FROM matches m
+		JOIN schedules s ON s.id = m.schedule_id AND s.deleted_at IS NULL
+		LEFT JOIN blocks b ON b.id = m.block_id
+		LEFT JOIN block_items i ON i.id = m.item_id
+		ORDER BY m.rank DESC, m.id ASC
+		LIMIT @limit
+		OFFSET @offset`,
+		map[string]interface{}{
+			"match":  match,
+			"start":  snippetStart,
+			"stop":   snippetStop,
+			"offset": offset,
+			"limit":  limit,
+		},
+	).Scan(&hits).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", mapError(err))
+	}
+
+	return markSnippets(hits), nil
+}
+
+// markSnippets переводит фрагменты найденного в безопасный HTML
+func markSnippets(hits []models.SearchHit) []models.SearchHit {
+	for i := range hits {
+		hits[i].Snippet = markSnippet(hits[i].Snippet)
+	}
+	return hits
+}
+
+// markSnippet экранирует фрагмент и заменяет границы совпадений на <mark>.
+// Теги всегда парные, даже если управляющие символы встретились в самом тексте.
+func markSnippet(snippet string) string {
+	var b strings.Builder
+	open := false
+	for len(snippet) > 0 {
+		i := strings.IndexAny(snippet, snippetStart+snippetStop)
+		if i < 0 {
+			b.WriteString(html.EscapeString(snippet))
+			break
+		}
+		b.WriteString(html.EscapeString(snippet[:i]))
+		switch start := snippet[i:i+1] == snippetStart; {
+		case start && !open:
+			b.WriteString("<mark>")
+			open = true
+		case !start && open:
+			b.WriteString("</mark>")
+			open = false
+		}
+		snippet = snippet[i+1:]
+	}
+	if open {
+		b.WriteString("</mark>")
+	}
+	return b.String()
+}
+
+// ftsQuery превращает пользовательский запрос в запрос FTS5: каждое слово
+// берется в кавычки, чтобы операторы FTS5 в тексте не ломали разбор
+func ftsQuery(query string) string {
+	terms := strings.Fields(query)
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+	return strings.Join(terms, " ")
+}
+
+// RebuildIfEmpty заполняет индекс для уже существующих расписаний,
+// если таблица индекса пуста (например, сразу после миграции)
+func (r *SearchRepository) RebuildIfEmpty(ctx context.Context) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.SearchEntry{}).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count search entries: %w", mapError(err))
+		}
+		if count > 0 {
+			return nil
+		}
+
+		var ids []uint
+		if err := tx.Model(&models.Schedule{}).Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("failed to list schedules for indexing: %w", mapError(err))
+		}
+
+		for _, id := range ids {
+			if err := reindexSchedule(tx, id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// reindexSchedule перестраивает записи индекса для одного расписания.
+// Вызывается внутри транзакций записи ScheduleRepository.
+func reindexSchedule(tx *gorm.DB, scheduleID uint) error {
+	if err := removeFromIndex(tx, scheduleID); err != nil {
+		return err
+	}
+
+	statements := postgresIndexStatements
+	if isSQLite(tx) {
+		statements = sqliteIndexStatements
+	}
+
+	for _, stmt := range statements {
+		if err := tx.Exec(stmt, scheduleID).Error; err != nil {
+			return fmt.Errorf("failed to index schedule: %w", mapError(err))
+		}
+	}
+
+	return nil
+}
+
+var postgresIndexStatements = []string{
+	`INSERT INTO search_entries (schedule_id, kind, title, body, document)
+	SELECT s.id, 'schedule', s.name, '', ` + fmt.Sprintf(searchDocumentA, "s.name") + `
+	FROM schedules s
+	WHERE s.id = ? AND s.deleted_at IS NULL`,
+
+	`INSERT INTO search_entries (schedule_id, block_id, kind, title, body, document)
+	SELECT b.schedule_id, b.id, 'block', b.name, coalesce(b.type, ''),
+		` + fmt.Sprintf(searchDocumentA, "b.name") + ` || ` + fmt.Sprintf(searchDocumentB, "b.type") + `
+	FROM blocks b
+	WHERE b.schedule_id = ? AND b.deleted_at IS NULL`,
+
+	`INSERT INTO search_entries (schedule_id, block_id, item_id, kind, title, body, document)
+	SELECT b.schedule_id, b.id, i.id, 'item', i.name, coalesce(i.description, ''),
+		` + fmt.Sprintf(searchDocumentA, "i.name") + ` || ` + fmt.Sprintf(searchDocumentB, "i.description") + `
+	FROM block_items i
+	JOIN blocks b ON b.id = i.block_id AND b.deleted_at IS NULL
+	WHERE b.schedule_id = ? AND i.deleted_at IS NULL`,
+}
+
+// В SQLite документ FTS5 строится из колонок title и body самой виртуальной таблицей
+var sqliteIndexStatements = []string{
+	`INSERT INTO search_entries (schedule_id, kind, title, body)
+	SELECT s.id, 'schedule', s.name, ''
+	FROM schedules s
+	WHERE s.id = ? AND s.deleted_at IS NULL`,
+
+	`INSERT INTO search_entries (schedule_id, block_id, kind, title, body)
+	SELECT b.schedule_id, b.id, 'block', b.name, coalesce(b.type, '')
+	FROM blocks b
+	WHERE b.schedule_id = ? AND b.deleted_at IS NULL`,
+
+	`INSERT INTO search_entries (schedule_id, block_id, item_id, kind, title, body)
+	SELECT b.schedule_id, b.id, i.id, 'item', i.name, coalesce(i.description, '')
+	FROM block_items i
+	JOIN blocks b ON b.id = i.block_id AND b.deleted_at IS NULL
+	WHERE b.schedule_id = ? AND i.deleted_at IS NULL`,
+}
+
+func isSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+// removeFromIndex удаляет записи индекса расписания
+func removeFromIndex(tx *gorm.DB, scheduleID uint) error {
+	if err := tx.Where("schedule_id = ?", scheduleID).Delete(&models.SearchEntry{}).Error; err != nil {
+		return fmt.Errorf("failed to remove schedule from search index: %w", mapError(err))
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import "testing"
+
+func TestMarkSnippet(t *testing.T) {
+	cases := []struct {
+		snippet, want string
+	}{
+		{"Косплей \x02Дефиле\x03", "Косплей <mark>Дефиле</mark>"},
+		{"<img src=x onerror=\"alert(1)\"> \x02Дефиле\x03 & co", "&lt;img src=x onerror=&#34;alert(1)&#34;&gt; <mark>Дефиле</mark> &amp; co"},
+		{"<mark>\x02a\x03</mark>", "&lt;mark&gt;<mark>a</mark>&lt;/mark&gt;"},
+		// Лишние и незакрытые границы не дают непарных тегов
+		{"\x03a\x02\x02b", "a<mark>b</mark>"},
+		{"", ""},
+	}
+	for _, c := range cases {
+		if got := markSnippet(c.snippet); got != c.want {
+			t.Errorf("markSnippet(%q) = %q, want %q", c.snippet, got, c.want)
+		}
+	}
+}
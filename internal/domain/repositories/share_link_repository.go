@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+var _ domain.ShareLinkRepository = (*ShareLinkRepository)(nil)
+
+type ShareLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewShareLinkRepository(db *gorm.DB) *ShareLinkRepository {
+	return &ShareLinkRepository{db: db}
+}
+
+// CreateShareLink создает ссылку
+func (r *ShareLinkRepository) CreateShareLink(ctx context.Context, link *models.ShareLink) error {
+	link.ID = 0
+	link.CreatedAt = time.Now()
+
+	if err := r.db.WithContext(ctx).Create(link).Error; err != nil {
+		return fmt.Errorf("failed to create share link: %w", mapError(err))
+	}
+	return nil
+}
+
+// GetShareLink получает ссылку по ID
+func (r *ShareLinkRepository) GetShareLink(ctx context.Context, id uint) (*models.ShareLink, error) {
+	var link models.ShareLink
+	if err := r.db.WithContext(ctx).First(&link, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get share link: %w", mapError(err))
+	}
+	return &link, nil
+}
+
+// ListShareLinks возвращает ссылки расписания
+func (r *ShareLinkRepository) ListShareLinks(ctx context.Context, scheduleID uint) ([]models.ShareLink, error) {
+	links := []models.ShareLink{}
+	if err := r.db.WithContext(ctx).Where("schedule_id = ?", scheduleID).Order("id ASC").Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", mapError(err))
+	}
+	return links, nil
+}
+
+// RevokeShareLink отзывает ссылку
+func (r *ShareLinkRepository) RevokeShareLink(ctx context.Context, id uint, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var link models.ShareLink
+		if err := tx.Select("id").First(&link, id).Error; err != nil {
+			return fmt.Errorf("failed to get share link for revocation: %w", mapError(err))
+		}
+
+		err := tx.Model(&models.ShareLink{}).
+			Where("id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", at).Error
+		if err != nil {
+			return fmt.Errorf("failed to revoke share link: %w", mapError(err))
+		}
+		return nil
+	})
+}
+
+// RecordShareAccess учитывает обращение по ссылке одним обновлением, так что
+// одновременные обращения не теряются
+func (r *ShareLinkRepository) RecordShareAccess(ctx context.Context, id uint, at time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.ShareLink{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"access_count":     gorm.Expr("access_count + 1"),
+		"last_accessed_at": at,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to record share link access: %w", mapError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to get share link: %w", utils.ErrNotFound)
+	}
+	return nil
+}
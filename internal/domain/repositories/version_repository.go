@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"cor-events-scheduler/internal/domain"
 	"cor-events-scheduler/internal/domain/models"
 
 	"gorm.io/gorm"
 )
 
+var _ domain.VersionRepository = (*VersionRepository)(nil)
+
 type VersionRepository struct {
 	db *gorm.DB
 }
@@ -24,13 +27,18 @@ func (r *VersionRepository) CreateVersion(ctx context.Context, version *models.S
 
 // GetLatestVersion получает последнюю версию расписания
 func (r *VersionRepository) GetLatestVersion(ctx context.Context, scheduleID uint) (*models.ScheduleVersion, error) {
+	return latestVersion(r.db.WithContext(ctx), scheduleID)
+}
+
+// latestVersion получает последнюю версию расписания через db
+func latestVersion(db *gorm.DB, scheduleID uint) (*models.ScheduleVersion, error) {
 	var version models.ScheduleVersion
-	err := r.db.WithContext(ctx).
+	err := db.
 		Where("schedule_id = ?", scheduleID).
 		Order("version DESC").
 		First(&version).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest version: %w", err)
+		return nil, fmt.Errorf("failed to get latest version: %w", mapError(err))
 	}
 	return &version, nil
 }
@@ -43,7 +51,7 @@ func (r *VersionRepository) GetVersionsByScheduleID(ctx context.Context, schedul
 		Order("version DESC").
 		Find(&versions).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to get versions: %w", err)
+		return nil, fmt.Errorf("failed to get versions: %w", mapError(err))
 	}
 	return versions, nil
 }
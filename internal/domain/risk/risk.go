@@ -0,0 +1,294 @@
+// Package risk оценивает, насколько расписание уязвимо к задержкам: короткие
+// техперерывы вокруг тяжелых блоков, блоки без запаса времени, долгие отрезки
+// без перерыва и конец программы вплотную к окончанию расписания. Оценки лежат
+// в диапазоне от 0 (риска нет) до 1.
+package risk
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/validation"
+)
+
+// Факторы риска
+const (
+	FactorTechBreak    = "tech_break"
+	FactorNoSlack      = "no_slack"
+	FactorLongStretch  = "long_stretch"
+	FactorEndProximity = "end_proximity"
+)
+
+// factorWeights — вклад факторов в оценку расписания; порядок задает порядок
+// факторов в ответе
+var factorWeights = []struct {
+	code   string
+	weight float64
+}{
+	{FactorTechBreak, 0.3},
+	{FactorNoSlack, 0.2},
+	{FactorLongStretch, 0.3},
+	{FactorEndProximity, 0.2},
+}
+
+// Options задает пороги анализа. Нулевые значения заменяются значениями
+// из DefaultOptions.
+type Options struct {
+	// HeavyBlockTypes — типы блоков, вокруг которых нужна перестановка сцены
+	HeavyBlockTypes []string
+	// MinHeavyTechBreak — техперерыв в минутах рядом с тяжелым блоком, при котором риска нет
+	MinHeavyTechBreak int
+	// SlackShare — доля длительности блока, которую должен оставлять запас
+	// сверх суммы элементов
+	SlackShare float64
+	// MaxStretch — длительность в минутах отрезка без техперерыва, при которой риск максимален;
+	// риск начинает расти с половины этого значения
+	MaxStretch int
+	// EndMargin — запас в минутах между концом последнего блока и концом расписания, при котором риска нет
+	EndMargin int
+}
+
+// DefaultOptions возвращает пороги по умолчанию
+func DefaultOptions() Options {
+	return Options{
+		HeavyBlockTypes:   []string{"concert", "band", "cosplay_performance", "show"},
+		MinHeavyTechBreak: 15,
+		SlackShare:        0.1,
+		MaxStretch:        180,
+		EndMargin:         30,
+	}
+}
+
+// Factor объясняет вклад одного фактора в оценку
+type Factor struct {
+	Code  string  `json:"code"`
+	Score float64 `json:"score"`
+	// Weight — вес фактора в оценке расписания; у факторов блока не заполняется
+	Weight float64 `json:"weight,omitempty"`
+	// Path — JSON Pointer на поле расписания, к которому относится фактор
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// BlockRisk — оценка блока и сработавшие для него факторы
+type BlockRisk struct {
+	BlockID uint     `json:"block_id"`
+	Index   int      `json:"index"`
+	Name    string   `json:"name"`
+	Score   float64  `json:"score"`
+	Factors []Factor `json:"factors"`
+}
+
+// Assessment — оценка расписания. Score расписания — взвешенное среднее
+// факторов, где значение фактора равно худшему значению среди блоков.
+type Assessment struct {
+	ScheduleID uint        `json:"schedule_id"`
+	Score      float64     `json:"score"`
+	Factors    []Factor    `json:"factors"`
+	Blocks     []BlockRisk `json:"blocks"`
+}
+
+// Analyzer оценивает риск расписаний с рассчитанными временами блоков
+type Analyzer struct {
+	options Options
+	heavy   map[string]bool
+}
+
+func NewAnalyzer(options Options) *Analyzer {
+	defaults := DefaultOptions()
+	if options.HeavyBlockTypes == nil {
+		options.HeavyBlockTypes = defaults.HeavyBlockTypes
+	}
+	if options.MinHeavyTechBreak <= 0 {
+		options.MinHeavyTechBreak = defaults.MinHeavyTechBreak
+	}
+	if options.SlackShare <= 0 {
+		options.SlackShare = defaults.SlackShare
+	}
+	if options.MaxStretch <= 0 {
+		options.MaxStretch = defaults.MaxStretch
+	}
+	if options.EndMargin <= 0 {
+		options.EndMargin = defaults.EndMargin
+	}
+
+	heavy := make(map[string]bool, len(options.HeavyBlockTypes))
+	for _, t := range options.HeavyBlockTypes {
+		heavy[strings.ToLower(strings.TrimSpace(t))] = true
+	}
+
+	return &Analyzer{options: options, heavy: heavy}
+}
+
+// Analyze оценивает расписание и каждый его блок
+func (a *Analyzer) Analyze(schedule *models.Schedule) *Assessment {
+	blocks := make([]BlockRisk, len(schedule.Blocks))
+	for i, block := range schedule.Blocks {
+		blocks[i] = BlockRisk{BlockID: block.ID, Index: i, Name: block.Name, Factors: []Factor{}}
+	}
+
+	a.techBreaks(schedule, blocks)
+	a.slack(schedule, blocks)
+	a.stretches(schedule, blocks)
+	a.endProximity(schedule, blocks)
+
+	for i := range blocks {
+		// Факторы блока независимы: блок срывается, если сработал хотя бы один
+		safe := 1.0
+		for _, factor := range blocks[i].Factors {
+			safe *= 1 - factor.Score
+		}
+		blocks[i].Score = round(1 - safe)
+	}
+
+	assessment := &Assessment{ScheduleID: schedule.ID, Factors: make([]Factor, 0, len(factorWeights)), Blocks: blocks}
+	var total, weights float64
+	for _, fw := range factorWeights {
+		factor := worstFactor(fw.code, blocks)
+		factor.Weight = fw.weight
+		assessment.Factors = append(assessment.Factors, factor)
+
+		total += factor.Score * fw.weight
+		weights += fw.weight
+	}
+	assessment.Score = round(total / weights)
+
+	return assessment
+}
+
+// techBreaks оценивает техперерывы, соседствующие с тяжелыми блоками;
+// фактор приписывается блоку, после которого идет перерыв
+func (a *Analyzer) techBreaks(schedule *models.Schedule, blocks []BlockRisk) {
+	minBreak := a.options.MinHeavyTechBreak
+	for i := 0; i < len(schedule.Blocks)-1; i++ {
+		block, next := schedule.Blocks[i], schedule.Blocks[i+1]
+		if !a.IsHeavy(block.Type) && !a.IsHeavy(next.Type) {
+			continue
+		}
+		if block.TechBreakDuration >= minBreak {
+			continue
+		}
+
+		blocks[i].add(Factor{
+			Code:  FactorTechBreak,
+			Score: 1 - ratio(block.TechBreakDuration, minBreak),
+			Path:  validation.Pointer("blocks", i, "tech_break_duration"),
+			Message: fmt.Sprintf("%d minute tech break between %q and %q, %d recommended around heavy blocks",
+				block.TechBreakDuration, block.Name, next.Name, minBreak),
+		})
+	}
+}
+
+// slack оценивает запас блока сверх его элементов и перестановок между ними
+func (a *Analyzer) slack(schedule *models.Schedule, blocks []BlockRisk) {
+	for i, block := range schedule.Blocks {
+		if len(block.Items) == 0 || block.Duration <= 0 {
+			continue
+		}
+		slack := block.Duration - block.ContentDuration()
+		wanted := int(math.Ceil(float64(block.Duration) * a.options.SlackShare))
+		if slack >= wanted {
+			continue
+		}
+
+		blocks[i].add(Factor{
+			Code:  FactorNoSlack,
+			Score: 1 - ratio(slack, wanted),
+			Path:  validation.Pointer("blocks", i, "duration"),
+			Message: fmt.Sprintf("block %q leaves %d minutes of slack over its items, %d recommended",
+				block.Name, max(slack, 0), wanted),
+		})
+	}
+}
+
+// stretches ищет отрезки блоков, идущих подряд без техперерыва; фактор
+// приписывается каждому блоку по мере того, как отрезок становится длиннее
+func (a *Analyzer) stretches(schedule *models.Schedule, blocks []BlockRisk) {
+	half := a.options.MaxStretch / 2
+	stretch := 0
+	for i, block := range schedule.Blocks {
+		if i > 0 && schedule.Blocks[i-1].TechBreakDuration > 0 {
+			stretch = 0
+		}
+		stretch += block.Duration
+		if stretch <= half {
+			continue
+		}
+
+		blocks[i].add(Factor{
+			Code:  FactorLongStretch,
+			Score: ratio(stretch-half, a.options.MaxStretch-half),
+			Path:  validation.Pointer("blocks", i),
+			Message: fmt.Sprintf("%d minutes without a tech break up to the end of %q, at most %d recommended",
+				stretch, block.Name, half),
+		})
+	}
+}
+
+// endProximity оценивает запас между концом последнего блока и концом расписания
+func (a *Analyzer) endProximity(schedule *models.Schedule, blocks []BlockRisk) {
+	if len(schedule.Blocks) == 0 {
+		return
+	}
+	last := len(schedule.Blocks) - 1
+	block := schedule.Blocks[last]
+
+	end := block.StartTime.Add(time.Duration(block.Duration) * time.Minute)
+	margin := int(schedule.EndDate.Sub(end) / time.Minute)
+	if margin >= a.options.EndMargin {
+		return
+	}
+
+	blocks[last].add(Factor{
+		Code:  FactorEndProximity,
+		Score: 1 - ratio(margin, a.options.EndMargin),
+		Path:  validation.Pointer("blocks", last),
+		Message: fmt.Sprintf("last block %q ends %d minutes before the schedule end, %d recommended",
+			block.Name, margin, a.options.EndMargin),
+	})
+}
+
+// Options возвращает пороги анализатора с подставленными значениями по умолчанию
+func (a *Analyzer) Options() Options {
+	return a.options
+}
+
+// IsHeavy сообщает, требует ли блок этого типа перестановки сцены
+func (a *Analyzer) IsHeavy(blockType string) bool {
+	return a.heavy[strings.ToLower(strings.TrimSpace(blockType))]
+}
+
+func (b *BlockRisk) add(factor Factor) {
+	factor.Score = round(factor.Score)
+	if factor.Score > 0 {
+		b.Factors = append(b.Factors, factor)
+	}
+}
+
+// worstFactor возвращает фактор с наибольшей оценкой среди блоков
+func worstFactor(code string, blocks []BlockRisk) Factor {
+	worst := Factor{Code: code, Message: "no risk found"}
+	for _, block := range blocks {
+		for _, factor := range block.Factors {
+			if factor.Code == code && factor.Score > worst.Score {
+				worst = factor
+			}
+		}
+	}
+	return worst
+}
+
+// ratio возвращает value/limit, ограниченное отрезком [0, 1]
+func ratio(value, limit int) float64 {
+	if limit <= 0 {
+		return 1
+	}
+	return math.Min(math.Max(float64(value)/float64(limit), 0), 1)
+}
+
+func round(score float64) float64 {
+	return math.Round(score*100) / 100
+}
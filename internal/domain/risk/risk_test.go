@@ -0,0 +1,116 @@
+package risk
+
+import (
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// noon — начало расписаний в тестах
+var noon = time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+func items(durations ...int) []models.BlockItem {
+	result := make([]models.BlockItem, len(durations))
+	for i, d := range durations {
+		result[i] = models.BlockItem{Name: "Выступление", Duration: d}
+	}
+	return result
+}
+
+func factor(factors []Factor, code string) (Factor, bool) {
+	for _, f := range factors {
+		if f.Code == code {
+			return f, true
+		}
+	}
+	return Factor{}, false
+}
+
+func TestAnalyzeRelaxedSchedule(t *testing.T) {
+	schedule := domaintest.Festival(noon, 6*time.Hour,
+		models.Block{Name: "Открытие", Type: "opening", Duration: 30, TechBreakDuration: 15, Items: items(20)},
+		models.Block{Name: "Концерт", Type: "concert", Duration: 60, TechBreakDuration: 20, Items: items(25, 25)},
+		models.Block{Name: "Закрытие", Type: "closing", Duration: 30, Items: items(20)},
+	)
+
+	assessment := NewAnalyzer(Options{}).Analyze(schedule)
+
+	if assessment.Score != 0 {
+		t.Fatalf("relaxed schedule should have no risk, got %+v", assessment)
+	}
+	if len(assessment.Factors) != len(factorWeights) || len(assessment.Blocks) != 3 {
+		t.Fatalf("every factor and block must be reported, got %+v", assessment)
+	}
+	for _, block := range assessment.Blocks {
+		if len(block.Factors) != 0 {
+			t.Errorf("block %q should have no factors, got %+v", block.Name, block.Factors)
+		}
+	}
+}
+
+func TestAnalyzeFactors(t *testing.T) {
+	schedule := domaintest.Festival(noon, 4*time.Hour+5*time.Minute,
+		models.Block{Name: "Группа", Type: "Band", Duration: 60, Items: items(30, 30)},
+		models.Block{Name: "Лекция", Type: "talk", Duration: 90, Items: items(45)},
+		models.Block{Name: "Финал", Type: "closing", Duration: 90, Items: items(60)},
+	)
+
+	assessment := NewAnalyzer(Options{}).Analyze(schedule)
+	blocks := assessment.Blocks
+
+	techBreak, ok := factor(blocks[0].Factors, FactorTechBreak)
+	if !ok || techBreak.Score != 1 || techBreak.Path != "/blocks/0/tech_break_duration" {
+		t.Errorf("zero tech break after a heavy block: %+v", blocks[0].Factors)
+	}
+	if noSlack, ok := factor(blocks[0].Factors, FactorNoSlack); !ok || noSlack.Score != 1 {
+		t.Errorf("items fill the whole block: %+v", blocks[0].Factors)
+	}
+	if _, ok := factor(blocks[1].Factors, FactorTechBreak); ok {
+		t.Errorf("no heavy blocks around the second break: %+v", blocks[1].Factors)
+	}
+
+	// 60+90 минут без перерыва: риск растет с 90 минут и достигает 1 на 180
+	stretch, ok := factor(blocks[1].Factors, FactorLongStretch)
+	if !ok || stretch.Score != 0.67 {
+		t.Errorf("150 minute stretch: %+v", blocks[1].Factors)
+	}
+	if stretch, _ := factor(blocks[2].Factors, FactorLongStretch); stretch.Score != 1 {
+		t.Errorf("240 minute stretch: %+v", blocks[2].Factors)
+	}
+
+	end, ok := factor(blocks[2].Factors, FactorEndProximity)
+	if !ok || end.Score != 0.83 {
+		t.Errorf("5 minutes before the end: %+v", blocks[2].Factors)
+	}
+
+	scheduleFactor, _ := factor(assessment.Factors, FactorLongStretch)
+	if scheduleFactor.Score != 1 || scheduleFactor.Path != "/blocks/2" || scheduleFactor.Weight == 0 {
+		t.Errorf("schedule factor should be the worst block factor: %+v", scheduleFactor)
+	}
+
+	// 0.3·1 + 0.2·1 + 0.3·1 + 0.2·0.83
+	if assessment.Score != 0.97 {
+		t.Errorf("schedule score = %v", assessment.Score)
+	}
+	if blocks[0].Score != 1 || blocks[2].Score != 1 {
+		t.Errorf("block scores: %+v", blocks)
+	}
+}
+
+func TestAnalyzeOptions(t *testing.T) {
+	schedule := domaintest.Festival(noon, 10*time.Hour,
+		models.Block{Name: "Лекция", Type: "talk", Duration: 60, TechBreakDuration: 5},
+		models.Block{Name: "Лекция 2", Type: "talk", Duration: 60},
+	)
+
+	if assessment := NewAnalyzer(Options{}).Analyze(schedule); assessment.Score != 0 {
+		t.Fatalf("talks are not heavy by default, got %+v", assessment)
+	}
+
+	assessment := NewAnalyzer(Options{HeavyBlockTypes: []string{"talk"}, MinHeavyTechBreak: 10}).Analyze(schedule)
+	if techBreak, ok := factor(assessment.Blocks[0].Factors, FactorTechBreak); !ok || techBreak.Score != 0.5 {
+		t.Fatalf("5 of 10 minutes: %+v", assessment.Blocks[0].Factors)
+	}
+}
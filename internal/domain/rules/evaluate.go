@@ -0,0 +1,127 @@
+package rules
+
+import (
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/validation"
+)
+
+// Evaluate проверяет расписание по всем правилам набора. Времена блоков
+// должны быть уже рассчитаны.
+func (rs *RuleSet) Evaluate(schedule *models.Schedule) validation.Report {
+	var report validation.Report
+	if rs == nil {
+		return report.Finish()
+	}
+
+	for i := range rs.Rules {
+		rule := &rs.Rules[i]
+		switch rule.Type {
+		case TypeMaxBlockDuration:
+			evaluateMaxBlockDuration(&report, rule, schedule)
+		case TypeMinTechBreakAfter:
+			evaluateMinTechBreakAfter(&report, rule, schedule)
+		case TypeMaxConsecutive:
+			evaluateMaxConsecutive(&report, rule, schedule)
+		case TypeCurfew:
+			evaluateCurfew(&report, rule, schedule)
+		}
+	}
+
+	return report.Finish()
+}
+
+func (r *Rule) report(report *validation.Report, path, format string, args ...interface{}) {
+	args = append([]interface{}{r.label()}, args...)
+	if r.Severity == validation.SeverityWarning {
+		report.Warnf(path, r.Type, "%s: "+format, args...)
+	} else {
+		report.Errorf(path, r.Type, "%s: "+format, args...)
+	}
+}
+
+func evaluateMaxBlockDuration(report *validation.Report, rule *Rule, schedule *models.Schedule) {
+	for i, block := range schedule.Blocks {
+		if rule.matches(block.Type) && block.Duration > rule.Minutes {
+			rule.report(report, validation.Pointer("blocks", i, "duration"),
+				"block %q lasts %d minutes, the limit is %d", block.Name, block.Duration, rule.Minutes)
+		}
+	}
+}
+
+// evaluateMinTechBreakAfter проверяет техперерыв после подходящих блоков; после
+// последнего блока перерыв не нужен
+func evaluateMinTechBreakAfter(report *validation.Report, rule *Rule, schedule *models.Schedule) {
+	for i, block := range schedule.Blocks {
+		if i == len(schedule.Blocks)-1 {
+			break
+		}
+		if rule.matches(block.Type) && block.TechBreakDuration < rule.Minutes {
+			rule.report(report, validation.Pointer("blocks", i, "tech_break_duration"),
+				"block %q is followed by a %d minute tech break, at least %d is required",
+				block.Name, block.TechBreakDuration, rule.Minutes)
+		}
+	}
+}
+
+// evaluateMaxConsecutive ищет серии подходящих блоков, идущих друг за другом
+// без техперерыва, и сообщает о первом блоке сверх допустимого числа
+func evaluateMaxConsecutive(report *validation.Report, rule *Rule, schedule *models.Schedule) {
+	run := 0
+	for i, block := range schedule.Blocks {
+		if !rule.matches(block.Type) {
+			run = 0
+			continue
+		}
+		if i > 0 && schedule.Blocks[i-1].TechBreakDuration > 0 {
+			run = 0
+		}
+		run++
+
+		if run == rule.Count+1 {
+			rule.report(report, validation.Pointer("blocks", i),
+				"block %q is number %d in a row without a break, at most %d allowed", block.Name, run, rule.Count)
+		}
+	}
+}
+
+// evaluateCurfew проверяет, что выступления не заходят в тихие часы [Time, Until)
+// ни в один из дней, которые они затрагивают
+func evaluateCurfew(report *validation.Report, rule *Rule, schedule *models.Schedule) {
+	location, err := time.LoadLocation(rule.Timezone)
+	if err != nil {
+		return
+	}
+	from, _ := parseClock(rule.Time)
+	until := 6 * time.Hour
+	if rule.Until != "" {
+		until, _ = parseClock(rule.Until)
+	}
+
+	for i, block := range schedule.Blocks {
+		if !rule.matches(block.Type) {
+			continue
+		}
+		start := block.StartTime.In(location)
+		end := start.Add(time.Duration(block.Duration) * time.Minute)
+
+		// Тихие часы, начавшиеся накануне, тоже могут задеть блок
+		day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, location).AddDate(0, 0, -1)
+		for !day.After(end) {
+			quietStart := day.Add(from)
+			quietEnd := day.Add(until)
+			if until <= from {
+				quietEnd = day.AddDate(0, 0, 1).Add(until)
+			}
+
+			if start.Before(quietEnd) && end.After(quietStart) {
+				rule.report(report, validation.Pointer("blocks", i),
+					"block %q runs %s–%s, inside curfew %s–%s",
+					block.Name, start.Format("15:04"), end.Format("15:04"), quietStart.Format("15:04"), quietEnd.Format("15:04"))
+				break
+			}
+			day = day.AddDate(0, 0, 1)
+		}
+	}
+}
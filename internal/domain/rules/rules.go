@@ -0,0 +1,142 @@
+// Package rules описывает декларативные правила площадки (максимальная длина
+// блока, техперерыв после блоков определенного типа, число выступлений подряд,
+// комендантский час) и проверяет по ним расписание с рассчитанными временами блоков.
+package rules
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	// База часовых поясов нужна для комендантского часа в образах без tzdata
+	_ "time/tzdata"
+
+	"cor-events-scheduler/internal/domain/validation"
+	"cor-events-scheduler/pkg/utils"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Типы правил
+const (
+	TypeMaxBlockDuration  = "max_block_duration"
+	TypeMinTechBreakAfter = "min_tech_break_after"
+	TypeMaxConsecutive    = "max_consecutive"
+	TypeCurfew            = "curfew"
+)
+
+// RuleSet — набор правил расписания или организации
+type RuleSet struct {
+	Rules []Rule `json:"rules" yaml:"rules"`
+}
+
+// Rule — одно правило. Какие параметры обязательны, зависит от Type.
+// Severity error блокирует сохранение расписания, warning только предупреждает.
+type Rule struct {
+	Type string `json:"type" yaml:"type"`
+	// Name попадает в сообщения о нарушениях
+	Name     string              `json:"name,omitempty" yaml:"name,omitempty"`
+	Severity validation.Severity `json:"severity" yaml:"severity"`
+	// BlockTypes ограничивает правило блоками этих типов; пусто — все блоки
+	BlockTypes []string `json:"block_types,omitempty" yaml:"block_types,omitempty"`
+	// Minutes — предел длительности для max_block_duration и min_tech_break_after
+	Minutes int `json:"minutes,omitempty" yaml:"minutes,omitempty"`
+	// Count — допустимое число блоков подряд без техперерыва для max_consecutive
+	Count int `json:"count,omitempty" yaml:"count,omitempty"`
+	// Time и Until задают тихие часы для curfew в формате 15:04; Until по умолчанию 06:00
+	Time     string `json:"time,omitempty" yaml:"time,omitempty"`
+	Until    string `json:"until,omitempty" yaml:"until,omitempty"`
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+}
+
+// Parse разбирает набор правил в YAML или JSON и проверяет параметры правил
+func Parse(source []byte) (*RuleSet, error) {
+	var ruleSet RuleSet
+
+	decoder := yaml.NewDecoder(bytes.NewReader(source))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&ruleSet); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: failed to parse rule set: %v", utils.ErrInvalidInput, err)
+	}
+
+	var problems []string
+	for i := range ruleSet.Rules {
+		rule := &ruleSet.Rules[i]
+		if rule.Severity == "" {
+			rule.Severity = validation.SeverityError
+		}
+		if err := rule.check(); err != nil {
+			problems = append(problems, fmt.Sprintf("rule %d: %v", i, err))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s", utils.ErrInvalidInput, strings.Join(problems, "; "))
+	}
+
+	return &ruleSet, nil
+}
+
+// check проверяет, что у правила есть все параметры его типа
+func (r *Rule) check() error {
+	if r.Severity != validation.SeverityError && r.Severity != validation.SeverityWarning {
+		return fmt.Errorf("severity must be %q or %q", validation.SeverityError, validation.SeverityWarning)
+	}
+
+	switch r.Type {
+	case TypeMaxBlockDuration, TypeMinTechBreakAfter:
+		if r.Minutes <= 0 {
+			return fmt.Errorf("%s requires positive minutes", r.Type)
+		}
+	case TypeMaxConsecutive:
+		if r.Count <= 0 {
+			return fmt.Errorf("%s requires positive count", r.Type)
+		}
+	case TypeCurfew:
+		if _, err := parseClock(r.Time); err != nil {
+			return fmt.Errorf("%s time: %v", r.Type, err)
+		}
+		if r.Until != "" {
+			if _, err := parseClock(r.Until); err != nil {
+				return fmt.Errorf("%s until: %v", r.Type, err)
+			}
+		}
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			return fmt.Errorf("%s timezone: %v", r.Type, err)
+		}
+	default:
+		return fmt.Errorf("unknown rule type %q", r.Type)
+	}
+	return nil
+}
+
+// matches сообщает, относится ли правило к блоку данного типа
+func (r *Rule) matches(blockType string) bool {
+	if len(r.BlockTypes) == 0 {
+		return true
+	}
+	for _, t := range r.BlockTypes {
+		if strings.EqualFold(t, blockType) {
+			return true
+		}
+	}
+	return false
+}
+
+// label возвращает имя правила для сообщений
+func (r *Rule) label() string {
+	if r.Name != "" {
+		return fmt.Sprintf("rule %q", r.Name)
+	}
+	return "rule " + r.Type
+}
+
+// parseClock разбирает время суток 15:04 в смещение от полуночи
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
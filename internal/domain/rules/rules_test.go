@@ -0,0 +1,138 @@
+package rules
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/validation"
+	"cor-events-scheduler/pkg/utils"
+)
+
+// evening — начало расписаний в тестах
+var evening = time.Date(2024, 4, 1, 18, 0, 0, 0, time.UTC)
+
+func TestParseYAMLAndJSON(t *testing.T) {
+	yamlSource := `
+rules:
+  - type: max_block_duration
+    name: Длина сета
+    minutes: 90
+    severity: warning
+  - type: curfew
+    time: "23:00"
+    timezone: Europe/Moscow
+`
+	jsonSource := `{"rules": [
+		{"type": "max_block_duration", "name": "Длина сета", "minutes": 90, "severity": "warning"},
+		{"type": "curfew", "time": "23:00", "timezone": "Europe/Moscow"}
+	]}`
+
+	for name, source := range map[string]string{"yaml": yamlSource, "json": jsonSource} {
+		ruleSet, err := Parse([]byte(source))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(ruleSet.Rules) != 2 {
+			t.Fatalf("%s: got %d rules", name, len(ruleSet.Rules))
+		}
+		if ruleSet.Rules[1].Severity != validation.SeverityError {
+			t.Fatalf("%s: severity should default to error, got %q", name, ruleSet.Rules[1].Severity)
+		}
+	}
+
+	empty, err := Parse(nil)
+	if err != nil || len(empty.Rules) != 0 {
+		t.Fatalf("empty source should give an empty rule set, got %+v, %v", empty, err)
+	}
+}
+
+func TestParseRejectsInvalidRules(t *testing.T) {
+	sources := []string{
+		"rules:\n  - type: teleport\n",
+		"rules:\n  - type: max_block_duration\n",
+		"rules:\n  - type: max_consecutive\n    count: 2\n    severity: fatal\n",
+		"rules:\n  - type: curfew\n    time: 25:00\n",
+		"rules:\n  - type: curfew\n    time: \"23:00\"\n    timezone: Mars/Olympus\n",
+		"rules:\n  - type: max_block_duration\n    minutes: 10\n    minuets: 5\n",
+	}
+
+	for _, source := range sources {
+		if _, err := Parse([]byte(source)); !errors.Is(err, utils.ErrInvalidInput) {
+			t.Errorf("Parse(%q) = %v, want ErrInvalidInput", source, err)
+		}
+	}
+}
+
+func issuesByCode(report validation.Report) map[string][]validation.Issue {
+	byCode := make(map[string][]validation.Issue)
+	for _, issue := range report.Issues {
+		byCode[issue.Code] = append(byCode[issue.Code], issue)
+	}
+	return byCode
+}
+
+func TestEvaluate(t *testing.T) {
+	schedule := domaintest.Festival(evening, 24*time.Hour,
+		models.Block{Name: "Группа 1", Type: "band", Duration: 120, TechBreakDuration: 5},
+		models.Block{Name: "Доклад 1", Type: "talk", Duration: 30},
+		models.Block{Name: "Доклад 2", Type: "talk", Duration: 30},
+		models.Block{Name: "Доклад 3", Type: "talk", Duration: 30},
+		models.Block{Name: "Доклад 4", Type: "talk", Duration: 30, TechBreakDuration: 10},
+		models.Block{Name: "Доклад 5", Type: "talk", Duration: 30},
+		models.Block{Name: "Группа 2", Type: "band", Duration: 60},
+	)
+	// Расписание начинается в 21:00 по Москве, Группа 2 выходит в 01:45
+
+	ruleSet, err := Parse([]byte(`
+rules:
+  - type: max_block_duration
+    block_types: [band]
+    minutes: 90
+  - type: min_tech_break_after
+    block_types: [band]
+    minutes: 15
+    severity: warning
+  - type: max_consecutive
+    block_types: [talk]
+    count: 3
+  - type: curfew
+    block_types: [band]
+    time: "23:00"
+    until: "07:00"
+    timezone: Europe/Moscow
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := ruleSet.Evaluate(schedule)
+	byCode := issuesByCode(report)
+
+	if got := byCode[TypeMaxBlockDuration]; len(got) != 1 || got[0].Path != "/blocks/0/duration" {
+		t.Errorf("max_block_duration: %+v", got)
+	}
+	// Последний блок не требует перерыва после себя
+	if got := byCode[TypeMinTechBreakAfter]; len(got) != 1 || got[0].Path != "/blocks/0/tech_break_duration" || got[0].Severity != validation.SeverityWarning {
+		t.Errorf("min_tech_break_after: %+v", got)
+	}
+	// Доклады 1–4 идут подряд без перерыва, после четвертого перерыв сбрасывает серию
+	if got := byCode[TypeMaxConsecutive]; len(got) != 1 || got[0].Path != "/blocks/4" {
+		t.Errorf("max_consecutive: %+v", got)
+	}
+	if got := byCode[TypeCurfew]; len(got) != 1 || got[0].Path != "/blocks/6" {
+		t.Errorf("curfew: %+v", got)
+	}
+	if report.Valid {
+		t.Error("report with blocking rule violations must not be valid")
+	}
+}
+
+func TestEvaluateEmptyRuleSet(t *testing.T) {
+	var ruleSet *RuleSet
+	if report := ruleSet.Evaluate(domaintest.Festival(evening, 24*time.Hour, models.Block{Name: "Блок", Duration: 600})); !report.Valid || len(report.Issues) != 0 {
+		t.Fatalf("nil rule set must not report anything, got %+v", report)
+	}
+}
@@ -0,0 +1,78 @@
+// Package share подписывает и проверяет токены ссылок для просмотра
+// расписания без учетной записи. Токен содержит область действия ссылки и
+// подпись HMAC-SHA256, поэтому его нельзя подделать или расширить, не зная
+// секрета; отзыв ссылки проверяется по хранилищу.
+package share
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"cor-events-scheduler/pkg/utils"
+)
+
+// Claims — область действия ссылки
+type Claims struct {
+	LinkID     uint   `json:"l"`
+	ScheduleID uint   `json:"s"`
+	View       string `json:"v"`
+	// Version — номер версии расписания; 0 означает текущее расписание
+	Version   int   `json:"n,omitempty"`
+	ExpiresAt int64 `json:"e"`
+}
+
+// Signer подписывает и проверяет токены одним секретом
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(secret []byte) *Signer {
+	return &Signer{key: append([]byte{}, secret...)}
+}
+
+var encoding = base64.RawURLEncoding
+
+// Sign возвращает токен вида <данные>.<подпись> в base64url
+func (s *Signer) Sign(claims Claims) string {
+	payload, _ := json.Marshal(claims)
+	encoded := encoding.EncodeToString(payload)
+	return encoded + "." + encoding.EncodeToString(s.mac(encoded))
+}
+
+// Verify проверяет подпись и срок действия токена. Поддельный, испорченный и
+// просроченный токены дают ошибку, оборачивающую utils.ErrNotFound, чтобы не
+// раскрывать, существовала ли ссылка.
+func (s *Signer) Verify(token string, now time.Time) (Claims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Claims{}, fmt.Errorf("%w: malformed share token", utils.ErrNotFound)
+	}
+	mac, err := encoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(encoded)) {
+		return Claims{}, fmt.Errorf("%w: invalid share token signature", utils.ErrNotFound)
+	}
+
+	payload, err := encoding.DecodeString(encoded)
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: malformed share token", utils.ErrNotFound)
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Claims{}, fmt.Errorf("%w: malformed share token", utils.ErrNotFound)
+	}
+	if !now.Before(time.Unix(claims.ExpiresAt, 0)) {
+		return Claims{}, fmt.Errorf("%w: share link expired", utils.ErrNotFound)
+	}
+	return claims, nil
+}
+
+func (s *Signer) mac(data string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,55 @@
+package share
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/pkg/utils"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+	signer := NewSigner([]byte("secret"))
+	claims := Claims{LinkID: 3, ScheduleID: 7, View: "public", Version: 2, ExpiresAt: now.Add(time.Hour).Unix()}
+
+	token := signer.Sign(claims)
+	if token != signer.Sign(claims) {
+		t.Fatal("the same claims must give the same token")
+	}
+
+	got, err := signer.Verify(token, now)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if got != claims {
+		t.Fatalf("want %+v, got %+v", claims, got)
+	}
+
+	if _, err := signer.Verify(token, now.Add(time.Hour)); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("expired token must fail with ErrNotFound, got %v", err)
+	}
+}
+
+func TestVerifyRejectsForgedTokens(t *testing.T) {
+	now := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+	signer := NewSigner([]byte("secret"))
+	token := signer.Sign(Claims{LinkID: 3, ScheduleID: 7, View: "public", ExpiresAt: now.Add(time.Hour).Unix()})
+
+	// Данные другой ссылки с подписью исходной
+	payload, signature, _ := strings.Cut(token, ".")
+	other, _, _ := strings.Cut(signer.Sign(Claims{LinkID: 3, ScheduleID: 7, View: "crew", ExpiresAt: now.Add(time.Hour).Unix()}), ".")
+
+	for name, forged := range map[string]string{
+		"other secret":   NewSigner([]byte("other")).Sign(Claims{LinkID: 3, ScheduleID: 7, View: "public", ExpiresAt: now.Add(time.Hour).Unix()}),
+		"swapped claims": other + "." + signature,
+		"no signature":   payload,
+		"bad encoding":   payload + ".!!!",
+		"empty":          "",
+	} {
+		if _, err := signer.Verify(forged, now); !errors.Is(err, utils.ErrNotFound) {
+			t.Errorf("%s: want ErrNotFound, got %v", name, err)
+		}
+	}
+}
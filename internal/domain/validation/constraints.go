@@ -0,0 +1,266 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// placement — время начала и длительность блока, с которыми он будет сохранен
+type placement struct {
+	start    time.Time
+	duration int
+}
+
+func (p placement) end() time.Time {
+	return p.start.Add(time.Duration(p.duration) * time.Minute)
+}
+
+// resolvedConstraint — ограничение, блоки которого найдены в расписании
+type resolvedConstraint struct {
+	index   int
+	kind    string
+	block   int
+	targets []int
+	minutes int
+}
+
+// validateConstraints проверяет ограничения между блоками: ссылки на блоки,
+// отсутствие циклов и выполнение ограничений при текущем порядке блоков
+func validateConstraints(r *Report, schedule *models.Schedule, placements []placement) {
+	var resolved []resolvedConstraint
+	for i, constraint := range schedule.Constraints {
+		if rc, ok := resolveConstraint(r, i, constraint, schedule); ok {
+			resolved = append(resolved, rc)
+		}
+	}
+
+	if !checkCycles(r, schedule, resolved) {
+		return
+	}
+	if !checkAdjacency(r, schedule, resolved) {
+		return
+	}
+	for _, rc := range resolved {
+		checkSatisfied(r, schedule, placements, rc)
+	}
+}
+
+func resolveConstraint(r *Report, i int, constraint models.BlockConstraint, schedule *models.Schedule) (resolvedConstraint, bool) {
+	rc := resolvedConstraint{index: i, kind: constraint.Type, minutes: constraint.Minutes}
+	ok := true
+
+	switch constraint.Type {
+	case models.ConstraintAfter, models.ConstraintAdjacent:
+	case models.ConstraintMinGap:
+		if constraint.Minutes <= 0 {
+			r.Errorf(Pointer("constraints", i, "minutes"), CodePositive, "min_gap constraint requires positive minutes")
+			ok = false
+		}
+	default:
+		r.Errorf(Pointer("constraints", i, "type"), CodeUnknownType,
+			"unknown constraint type %q, expected %s, %s or %s",
+			constraint.Type, models.ConstraintAfter, models.ConstraintMinGap, models.ConstraintAdjacent)
+		return rc, false
+	}
+
+	block, found := lookupBlock(r, schedule, i, "block", constraint.BlockID, constraint.Block)
+	ok = ok && found
+	rc.block = block
+
+	hasTargetBlock := constraint.TargetBlockID != 0 || strings.TrimSpace(constraint.TargetBlock) != ""
+	targetType := strings.TrimSpace(constraint.TargetType)
+	switch {
+	case !hasTargetBlock && targetType == "":
+		r.Errorf(Pointer("constraints", i, "target_block"), CodeRequired, "constraint must have a target_block or a target_type")
+		ok = false
+	case hasTargetBlock && targetType != "":
+		r.Errorf(Pointer("constraints", i), CodeInvalidTarget, "constraint must have either a target_block or a target_type, not both")
+		ok = false
+	case targetType != "" && constraint.Type == models.ConstraintAdjacent:
+		r.Errorf(Pointer("constraints", i, "target_type"), CodeInvalidTarget, "adjacent constraint must target a single block")
+		ok = false
+	case hasTargetBlock:
+		target, found := lookupBlock(r, schedule, i, "target_block", constraint.TargetBlockID, constraint.TargetBlock)
+		ok = ok && found
+		if ok && target == block && constraint.Type == models.ConstraintAdjacent {
+			r.Errorf(Pointer("constraints", i, "target_block"), CodeInvalidTarget, "block %q cannot be adjacent to itself", schedule.Blocks[block].Name)
+			ok = false
+		}
+		rc.targets = []int{target}
+	default:
+		// Цель — все остальные блоки этого типа
+		for j, candidate := range schedule.Blocks {
+			if j != block && strings.EqualFold(strings.TrimSpace(candidate.Type), targetType) {
+				rc.targets = append(rc.targets, j)
+			}
+		}
+		if len(rc.targets) == 0 {
+			r.Warnf(Pointer("constraints", i, "target_type"), CodeUnknownBlock,
+				"no blocks of type %q, the constraint has no effect", constraint.TargetType)
+		}
+	}
+
+	return rc, ok
+}
+
+// lookupBlock находит единственный блок по ID из поля field+"_id" или, если
+// ID не задан, по имени из поля field
+func lookupBlock(r *Report, schedule *models.Schedule, i int, field string, id uint, name string) (int, bool) {
+	matches := schedule.ConstraintBlocks(id, name)
+
+	if id != 0 {
+		path := Pointer("constraints", i, field+"_id")
+		switch len(matches) {
+		case 0:
+			r.Errorf(path, CodeUnknownBlock, "schedule has no block with id %d", id)
+			return 0, false
+		case 1:
+			return matches[0], true
+		default:
+			r.Errorf(path, CodeAmbiguousBlock, "%d blocks have id %d", len(matches), id)
+			return 0, false
+		}
+	}
+
+	path := Pointer("constraints", i, field)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		r.Errorf(path, CodeRequired, "constraint must name a block")
+		return 0, false
+	}
+	switch len(matches) {
+	case 0:
+		r.Errorf(path, CodeUnknownBlock, "schedule has no block named %q", name)
+		return 0, false
+	case 1:
+		return matches[0], true
+	default:
+		r.Errorf(path, CodeAmbiguousBlock, "%d blocks are named %q", len(matches), name)
+		return 0, false
+	}
+}
+
+// checkCycles ищет цикл в ограничениях порядка (after и min_gap) и называет
+// его блоки. Возвращает false, если цикл найден.
+func checkCycles(r *Report, schedule *models.Schedule, resolved []resolvedConstraint) bool {
+	type edge struct{ to, constraint int }
+	edges := make([][]edge, len(schedule.Blocks))
+	for _, rc := range resolved {
+		if rc.kind == models.ConstraintAdjacent {
+			continue
+		}
+		for _, target := range rc.targets {
+			edges[target] = append(edges[target], edge{to: rc.block, constraint: rc.index})
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(schedule.Blocks))
+	var path []int
+
+	var visit func(b int) bool
+	visit = func(b int) bool {
+		state[b] = visiting
+		path = append(path, b)
+
+		for _, e := range edges[b] {
+			switch state[e.to] {
+			case visiting:
+				reportCycle(r, schedule, path, e.to, e.constraint)
+				return false
+			case unvisited:
+				if !visit(e.to) {
+					return false
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[b] = done
+		return true
+	}
+
+	for b := range schedule.Blocks {
+		if state[b] == unvisited && !visit(b) {
+			return false
+		}
+	}
+	return true
+}
+
+// reportCycle сообщает о цикле, который замыкает ребро из последнего блока пути в start
+func reportCycle(r *Report, schedule *models.Schedule, path []int, start, constraint int) {
+	var names []string
+	for i := len(path) - 1; i >= 0; i-- {
+		names = append([]string{fmt.Sprintf("%q", schedule.Blocks[path[i]].Name)}, names...)
+		if path[i] == start {
+			break
+		}
+	}
+	names = append(names, fmt.Sprintf("%q", schedule.Blocks[start].Name))
+
+	r.Errorf(Pointer("constraints", constraint), CodeConstraintCycle,
+		"constraints form a cycle: %s", strings.Join(names, " → "))
+}
+
+// checkAdjacency проверяет, что ни один блок не должен стоять вплотную к
+// более чем двум блокам. Возвращает false при противоречии.
+func checkAdjacency(r *Report, schedule *models.Schedule, resolved []resolvedConstraint) bool {
+	neighbours := make([]map[int]bool, len(schedule.Blocks))
+	for _, rc := range resolved {
+		if rc.kind != models.ConstraintAdjacent {
+			continue
+		}
+		for _, pair := range [][2]int{{rc.block, rc.targets[0]}, {rc.targets[0], rc.block}} {
+			if neighbours[pair[0]] == nil {
+				neighbours[pair[0]] = make(map[int]bool)
+			}
+			neighbours[pair[0]][pair[1]] = true
+
+			if len(neighbours[pair[0]]) > 2 {
+				r.Errorf(Pointer("constraints", rc.index), CodeConstraintConflict,
+					"block %q cannot be adjacent to more than two blocks", schedule.Blocks[pair[0]].Name)
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkSatisfied проверяет ограничение при текущем порядке и временах блоков
+func checkSatisfied(r *Report, schedule *models.Schedule, placements []placement, rc resolvedConstraint) {
+	block := schedule.Blocks[rc.block]
+	path := Pointer("constraints", rc.index)
+
+	for _, t := range rc.targets {
+		target := schedule.Blocks[t]
+
+		switch rc.kind {
+		case models.ConstraintAfter:
+			if rc.block < t {
+				r.Errorf(path, CodeConstraintViolated, "block %q must come after %q", block.Name, target.Name)
+				return
+			}
+		case models.ConstraintMinGap:
+			gap := int(placements[rc.block].start.Sub(placements[t].end()) / time.Minute)
+			if gap < rc.minutes {
+				r.Errorf(path, CodeConstraintViolated,
+					"block %q must start at least %d minutes after %q ends, the gap is %d",
+					block.Name, rc.minutes, target.Name, gap)
+				return
+			}
+		case models.ConstraintAdjacent:
+			if rc.block-t != 1 && t-rc.block != 1 {
+				r.Errorf(path, CodeConstraintViolated, "block %q must be adjacent to %q", block.Name, target.Name)
+				return
+			}
+		}
+	}
+}
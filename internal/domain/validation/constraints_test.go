@@ -0,0 +1,154 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// contest — конкурсные блоки, за которыми идет награждение
+func contest(constraints ...models.BlockConstraint) *models.Schedule {
+	start := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+	item := []models.BlockItem{{Name: "Выступление", Duration: 20}}
+	return &models.Schedule{
+		Name:      "Конкурс",
+		StartDate: start,
+		EndDate:   start.Add(8 * time.Hour),
+		Blocks: []models.Block{
+			{Name: "Отбор", Type: "competition", Duration: 30, TechBreakDuration: 10, Items: item},
+			{Name: "Финал", Type: "competition", Duration: 30, Items: item},
+			{Name: "Фуршет", Type: "break", Duration: 30, TechBreakDuration: 20, Items: item},
+			{Name: "Награждение", Type: "ceremony", Duration: 30, Items: item},
+		},
+		Constraints: constraints,
+	}
+}
+
+func issuesWithCode(report Report, code string) []Issue {
+	var issues []Issue
+	for _, issue := range report.Issues {
+		if issue.Code == code {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func TestConstraintsSatisfied(t *testing.T) {
+	report := ValidateSchedule(contest(
+		models.BlockConstraint{Type: models.ConstraintAfter, Block: "Награждение", TargetType: "competition"},
+		models.BlockConstraint{Type: models.ConstraintMinGap, Block: "Награждение", TargetBlock: "Финал", Minutes: 50},
+		models.BlockConstraint{Type: models.ConstraintAdjacent, Block: "Фуршет", TargetBlock: "Финал"},
+	))
+
+	if !report.Valid || len(report.Issues) != 0 {
+		t.Fatalf("constraints should hold, got %+v", report.Issues)
+	}
+}
+
+func TestConstraintsViolated(t *testing.T) {
+	report := ValidateSchedule(contest(
+		models.BlockConstraint{Type: models.ConstraintAfter, Block: "Фуршет", TargetType: "ceremony"},
+		// Между концом финала и награждением 30 минут фуршета и 20 минут перерыва
+		models.BlockConstraint{Type: models.ConstraintMinGap, Block: "Награждение", TargetBlock: "Финал", Minutes: 60},
+		models.BlockConstraint{Type: models.ConstraintAdjacent, Block: "Отбор", TargetBlock: "Награждение"},
+	))
+
+	violated := issuesWithCode(report, CodeConstraintViolated)
+	if len(violated) != 3 {
+		t.Fatalf("want 3 violations, got %+v", report.Issues)
+	}
+	for i, issue := range violated {
+		if want := Pointer("constraints", i); issue.Path != want {
+			t.Errorf("violation %d at %s, want %s", i, issue.Path, want)
+		}
+	}
+	if !strings.Contains(violated[1].Message, "the gap is 50") {
+		t.Errorf("min_gap message should name the gap: %q", violated[1].Message)
+	}
+}
+
+func TestConstraintsCycleIsNamed(t *testing.T) {
+	report := ValidateSchedule(contest(
+		models.BlockConstraint{Type: models.ConstraintAfter, Block: "Финал", TargetBlock: "Отбор"},
+		models.BlockConstraint{Type: models.ConstraintAfter, Block: "Награждение", TargetBlock: "Финал"},
+		models.BlockConstraint{Type: models.ConstraintMinGap, Block: "Отбор", TargetBlock: "Награждение", Minutes: 10},
+	))
+
+	cycles := issuesWithCode(report, CodeConstraintCycle)
+	if len(cycles) != 1 {
+		t.Fatalf("want one cycle, got %+v", report.Issues)
+	}
+	if want := `"Отбор" → "Финал" → "Награждение" → "Отбор"`; !strings.HasSuffix(cycles[0].Message, want) {
+		t.Fatalf("cycle message %q should end with %s", cycles[0].Message, want)
+	}
+	// Пока есть цикл, выполнение ограничений не проверяется
+	if len(issuesWithCode(report, CodeConstraintViolated)) != 0 {
+		t.Fatalf("violations must not be reported for a cyclic set: %+v", report.Issues)
+	}
+
+	selfLoop := ValidateSchedule(contest(models.BlockConstraint{Type: models.ConstraintAfter, Block: "Финал", TargetBlock: "Финал"}))
+	if cycles := issuesWithCode(selfLoop, CodeConstraintCycle); len(cycles) != 1 {
+		t.Fatalf("a block after itself is a cycle, got %+v", selfLoop.Issues)
+	}
+}
+
+func TestConstraintsInvalid(t *testing.T) {
+	schedule := contest(
+		models.BlockConstraint{Type: "before", Block: "Финал", TargetBlock: "Отбор"},
+		models.BlockConstraint{Type: models.ConstraintAfter, Block: "Гала", TargetBlock: "Отбор"},
+		models.BlockConstraint{Type: models.ConstraintMinGap, Block: "Финал", TargetBlock: "Отбор"},
+		models.BlockConstraint{Type: models.ConstraintAfter, Block: "Финал"},
+		models.BlockConstraint{Type: models.ConstraintAdjacent, Block: "Финал", TargetType: "competition"},
+		models.BlockConstraint{Type: models.ConstraintAfter, Block: "Финал", TargetType: "workshop"},
+		models.BlockConstraint{Type: models.ConstraintAdjacent, Block: "Отбор", TargetBlock: "Финал"},
+		models.BlockConstraint{Type: models.ConstraintAdjacent, Block: "Отбор", TargetBlock: "Фуршет"},
+		models.BlockConstraint{Type: models.ConstraintAdjacent, Block: "Отбор", TargetBlock: "Награждение"},
+	)
+
+	report := ValidateSchedule(schedule)
+
+	want := map[string]string{
+		"/constraints/0/type":         CodeUnknownType,
+		"/constraints/1/block":        CodeUnknownBlock,
+		"/constraints/2/minutes":      CodePositive,
+		"/constraints/3/target_block": CodeRequired,
+		"/constraints/4/target_type":  CodeInvalidTarget,
+		"/constraints/5/target_type":  CodeUnknownBlock,
+		"/constraints/8":              CodeConstraintConflict,
+	}
+	got := make(map[string]Issue)
+	for _, issue := range report.Issues {
+		got[issue.Path] = issue
+	}
+	for path, code := range want {
+		if got[path].Code != code {
+			t.Errorf("%s: got %+v, want code %q", path, got[path], code)
+		}
+	}
+	if got["/constraints/5/target_type"].Severity != SeverityWarning {
+		t.Error("a type without blocks should only be a warning")
+	}
+}
+
+func TestConstraintsReferenceBlocksByID(t *testing.T) {
+	schedule := contest(
+		// Имя устарело после переименования, но ID указывает на блок
+		models.BlockConstraint{Type: models.ConstraintAfter, BlockID: 4, Block: "Вручение", TargetBlockID: 2, TargetBlock: "Финал"},
+		models.BlockConstraint{Type: models.ConstraintAdjacent, Block: "Фуршет", TargetBlockID: 9},
+	)
+	for i := range schedule.Blocks {
+		schedule.Blocks[i].ID = uint(i + 1)
+	}
+
+	report := ValidateSchedule(schedule)
+
+	if len(report.Issues) != 1 {
+		t.Fatalf("want only the unknown block ID, got %+v", report.Issues)
+	}
+	if issue := report.Issues[0]; issue.Path != "/constraints/1/target_block_id" || issue.Code != CodeUnknownBlock {
+		t.Fatalf("unexpected issue %+v", issue)
+	}
+}
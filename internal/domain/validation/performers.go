@@ -0,0 +1,143 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// appearanceTimeFormat — формат времени выступлений в сообщениях
+const appearanceTimeFormat = "2006-01-02 15:04"
+
+// ValidatePerformer проверяет выступающего перед сохранением
+func ValidatePerformer(performer *models.Performer) Report {
+	var r Report
+
+	if strings.TrimSpace(performer.Name) == "" {
+		r.Errorf(Pointer("name"), CodeRequired, "performer must have a name")
+	}
+	if performer.BufferMinutes < 0 {
+		r.Errorf(Pointer("buffer_minutes"), CodeNonNegative, "buffer minutes must not be negative")
+	}
+
+	for i, window := range performer.Availability {
+		switch {
+		case window.StartTime.IsZero():
+			r.Errorf(Pointer("availability", i, "start_time"), CodeRequired, "availability window must have a start time")
+		case window.EndTime.IsZero():
+			r.Errorf(Pointer("availability", i, "end_time"), CodeRequired, "availability window must have an end time")
+		case !window.EndTime.After(window.StartTime):
+			r.Errorf(Pointer("availability", i, "end_time"), CodeDateOrder, "availability window must end after it starts")
+		}
+	}
+
+	return r.Finish()
+}
+
+// ownAppearance — выступление в проверяемом расписании и путь к ссылке на выступающего
+type ownAppearance struct {
+	models.Appearance
+	path string
+}
+
+// ValidatePerformers проверяет выступающих в элементах расписания с
+// рассчитанными временами: ссылки на выступающих, окна доступности, а также
+// пересечения и слишком близкие выступления в этом и других расписаниях.
+// performers — выступающие, на которых ссылаются элементы; others — их
+// выступления в сохраненных расписаниях, выступления самого расписания
+// среди них пропускаются.
+func ValidatePerformers(schedule *models.Schedule, performers []models.Performer, others []models.Appearance) Report {
+	var r Report
+
+	byID := make(map[uint]*models.Performer, len(performers))
+	for i := range performers {
+		byID[performers[i].ID] = &performers[i]
+	}
+
+	var own []ownAppearance
+	for i, block := range schedule.Blocks {
+		for j, item := range block.Items {
+			for k, performerID := range item.PerformerIDs {
+				path := Pointer("blocks", i, "items", j, "performer_ids", k)
+				if byID[performerID] == nil {
+					r.Errorf(path, CodeUnknownPerformer, "performer %d does not exist", performerID)
+					continue
+				}
+				own = append(own, ownAppearance{
+					Appearance: models.Appearance{
+						PerformerID:  performerID,
+						ScheduleID:   schedule.ID,
+						ScheduleName: schedule.Name,
+						BlockID:      block.ID,
+						BlockName:    block.Name,
+						ItemID:       item.ID,
+						ItemName:     item.Name,
+						StartTime:    item.StartTime,
+						EndTime:      item.EndTime,
+					},
+					path: path,
+				})
+			}
+		}
+	}
+	sort.SliceStable(own, func(a, b int) bool { return own[a].StartTime.Before(own[b].StartTime) })
+
+	for a, appearance := range own {
+		performer := byID[appearance.PerformerID]
+		checkAvailability(&r, performer, appearance)
+
+		// Пара выступлений в этом расписании сообщается один раз, у более позднего
+		for _, earlier := range own[:a] {
+			if earlier.PerformerID == appearance.PerformerID {
+				checkGap(&r, performer, appearance, earlier.Appearance,
+					fmt.Sprintf("%q in block %q", earlier.ItemName, earlier.BlockName))
+			}
+		}
+		for _, other := range others {
+			if other.PerformerID == appearance.PerformerID && (schedule.ID == 0 || other.ScheduleID != schedule.ID) {
+				checkGap(&r, performer, appearance, other,
+					fmt.Sprintf("%q in schedule %q", other.ItemName, other.ScheduleName))
+			}
+		}
+	}
+
+	return r.Finish()
+}
+
+// checkAvailability предупреждает, если выступление не помещается ни в одно
+// окно доступности выступающего
+func checkAvailability(r *Report, performer *models.Performer, appearance ownAppearance) {
+	if len(performer.Availability) == 0 {
+		return
+	}
+	for _, window := range performer.Availability {
+		if !appearance.StartTime.Before(window.StartTime) && !appearance.EndTime.After(window.EndTime) {
+			return
+		}
+	}
+	r.Warnf(appearance.path, CodePerformerUnavailable, "performer %q is not available from %s to %s",
+		performer.Name, appearance.StartTime.Format(appearanceTimeFormat), appearance.EndTime.Format(appearanceTimeFormat))
+}
+
+// checkGap предупреждает, если выступления пересекаются или между ними
+// меньше времени, чем нужно выступающему на дорогу
+func checkGap(r *Report, performer *models.Performer, appearance ownAppearance, other models.Appearance, where string) {
+	if appearance.StartTime.Before(other.EndTime) && other.StartTime.Before(appearance.EndTime) {
+		r.Warnf(appearance.path, CodePerformerConflict, "performer %q also appears in %s from %s to %s",
+			performer.Name, where, other.StartTime.Format(appearanceTimeFormat), other.EndTime.Format(appearanceTimeFormat))
+		return
+	}
+
+	gap := appearance.StartTime.Sub(other.EndTime)
+	if other.StartTime.After(appearance.StartTime) {
+		gap = other.StartTime.Sub(appearance.EndTime)
+	}
+	if minutes := int(gap / time.Minute); minutes < performer.BufferMinutes {
+		r.Warnf(appearance.path, CodePerformerConflict,
+			"performer %q appears in %s only %d minutes apart, %d needed",
+			performer.Name, where, minutes, performer.BufferMinutes)
+	}
+}
@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+)
+
+func TestValidatePerformer(t *testing.T) {
+	performer := domaintest.NewPerformer(" ")
+	performer.BufferMinutes = -1
+	performer.Availability[1].EndTime = performer.Availability[1].StartTime
+
+	report := ValidatePerformer(performer)
+
+	want := map[string]string{
+		"/name":                    CodeRequired,
+		"/buffer_minutes":          CodeNonNegative,
+		"/availability/1/end_time": CodeDateOrder,
+	}
+	assertIssues(t, report, want)
+	if report.Valid {
+		t.Fatal("report with errors must not be valid")
+	}
+}
+
+func TestValidatePerformers(t *testing.T) {
+	// Элементы: 10:05–10:15 и 10:21–10:26 в первом блоке, 10:40–11:00 во втором
+	schedule := domaintest.NewSchedule("Фестиваль")
+	schedule.ID = 1
+
+	host := *domaintest.NewPerformer("Ведущий")
+	host.ID = 1
+	choir := *domaintest.NewPerformer("Хор")
+	choir.ID = 2
+	choir.BufferMinutes = 0
+	choir.Availability = nil
+	late := *domaintest.NewPerformer("Хедлайнер")
+	late.ID = 3
+	late.Availability = late.Availability[1:]
+
+	schedule.Blocks[0].Items[0].PerformerIDs = []uint{host.ID}
+	schedule.Blocks[0].Items[1].PerformerIDs = []uint{choir.ID, 999}
+	schedule.Blocks[1].Items[0].PerformerIDs = []uint{host.ID, late.ID}
+
+	day := schedule.StartDate
+	others := []models.Appearance{
+		// Хор в то же время выступает на другой площадке
+		{PerformerID: choir.ID, ScheduleID: 7, ScheduleName: "Другая площадка", ItemName: "Гимн",
+			StartTime: day.Add(20 * time.Minute), EndTime: day.Add(30 * time.Minute)},
+		// Выступления самого расписания пропускаются
+		{PerformerID: choir.ID, ScheduleID: schedule.ID, ItemName: "Гимн",
+			StartTime: day.Add(21 * time.Minute), EndTime: day.Add(26 * time.Minute)},
+		// Хедлайнер выступает через три часа после конца второго блока
+		{PerformerID: late.ID, ScheduleID: 8, ScheduleName: "Вечер", ItemName: "Сет",
+			StartTime: day.Add(4 * time.Hour), EndTime: day.Add(5 * time.Hour)},
+	}
+
+	report := ValidatePerformers(schedule, []models.Performer{host, choir, late}, others)
+
+	want := map[string]string{
+		"/blocks/0/items/1/performer_ids/1": CodeUnknownPerformer,
+		"/blocks/0/items/1/performer_ids/0": CodePerformerConflict,
+		// Между выступлениями ведущего 25 минут при нужных 30
+		"/blocks/1/items/0/performer_ids/0": CodePerformerConflict,
+		"/blocks/1/items/0/performer_ids/1": CodePerformerUnavailable,
+	}
+	assertIssues(t, report, want)
+	if len(report.Issues) != len(want) {
+		t.Fatalf("want %d issues, got %+v", len(want), report.Issues)
+	}
+	if report.Valid {
+		t.Fatal("unknown performer must be an error")
+	}
+	for _, issue := range report.Issues {
+		if issue.Code != CodeUnknownPerformer && issue.Severity != SeverityWarning {
+			t.Errorf("%s: conflicts must be warnings", issue.Path)
+		}
+	}
+}
+
+func assertIssues(t *testing.T, report Report, want map[string]string) {
+	t.Helper()
+
+	got := make(map[string]Issue)
+	for _, issue := range report.Issues {
+		got[issue.Path] = issue
+	}
+	for path, code := range want {
+		issue, ok := got[path]
+		if !ok {
+			t.Errorf("missing issue at %s, got %+v", path, report.Issues)
+			continue
+		}
+		if issue.Code != code {
+			t.Errorf("%s: code = %q, want %q", path, issue.Code, code)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"cor-events-scheduler/internal/domain/booking"
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// ValidateResource проверяет ресурс перед сохранением
+func ValidateResource(resource *models.Resource) Report {
+	var r Report
+
+	if strings.TrimSpace(resource.Name) == "" {
+		r.Errorf(Pointer("name"), CodeRequired, "resource must have a name")
+	}
+	if resource.Capacity <= 0 {
+		r.Errorf(Pointer("capacity"), CodePositive, "resource capacity must be positive")
+	}
+
+	return r.Finish()
+}
+
+// ownUsage — бронь в проверяемом расписании и путь к ней
+type ownUsage struct {
+	models.ResourceUsage
+	path string
+}
+
+// ValidateResources проверяет брони ресурсов блоками и элементами расписания с
+// рассчитанными временами: ссылки на ресурсы, повторные брони и превышение
+// вместимости ресурса вместе с бронями других расписаний. resources — ресурсы,
+// на которые ссылается расписание; others — их брони в сохраненных
+// расписаниях, брони самого расписания среди них пропускаются.
+func ValidateResources(schedule *models.Schedule, resources []models.Resource, others []models.ResourceUsage) Report {
+	var r Report
+
+	byID := make(map[uint]*models.Resource, len(resources))
+	for i := range resources {
+		byID[resources[i].ID] = &resources[i]
+	}
+
+	// Брони собираются в том же порядке, что и в Block.ResourceUsages
+	var own []ownUsage
+	for i := range schedule.Blocks {
+		block := &schedule.Blocks[i]
+		usages := block.ResourceUsages(schedule.ID, schedule.Name)
+		next := 0
+
+		blockBooked := make(map[uint]bool)
+		for k, assignment := range block.Resources {
+			path := Pointer("blocks", i, "resources", k)
+			usage := usages[next]
+			next++
+			if !checkAssignment(&r, byID, assignment, path) {
+				continue
+			}
+			if blockBooked[assignment.ResourceID] {
+				r.Errorf(path, CodeDuplicateBooking, "resource %q is booked twice by block %q",
+					byID[assignment.ResourceID].Name, block.Name)
+				continue
+			}
+			blockBooked[assignment.ResourceID] = true
+			own = append(own, ownUsage{ResourceUsage: usage, path: path})
+		}
+
+		for j, item := range block.Items {
+			itemBooked := make(map[uint]bool)
+			for k, assignment := range item.Resources {
+				path := Pointer("blocks", i, "items", j, "resources", k)
+				usage := usages[next]
+				next++
+				if !checkAssignment(&r, byID, assignment, path) {
+					continue
+				}
+				switch {
+				case blockBooked[assignment.ResourceID]:
+					r.Errorf(path, CodeDuplicateBooking, "resource %q is already booked by block %q for its whole time",
+						byID[assignment.ResourceID].Name, block.Name)
+					continue
+				case itemBooked[assignment.ResourceID]:
+					r.Errorf(path, CodeDuplicateBooking, "resource %q is booked twice by item %q",
+						byID[assignment.ResourceID].Name, item.Name)
+					continue
+				}
+				itemBooked[assignment.ResourceID] = true
+				own = append(own, ownUsage{ResourceUsage: usage, path: path})
+			}
+		}
+	}
+
+	for _, usage := range own {
+		resource := byID[usage.ResourceID]
+
+		var competing []models.ResourceUsage
+		for _, other := range own {
+			if other.ResourceID == usage.ResourceID {
+				competing = append(competing, other.ResourceUsage)
+			}
+		}
+		for _, other := range others {
+			if other.ResourceID == usage.ResourceID && (schedule.ID == 0 || other.ScheduleID != schedule.ID) {
+				competing = append(competing, other)
+			}
+		}
+
+		peak, ok := booking.Peak(booking.Timeline(competing, usage.StartTime, usage.EndTime))
+		if ok && peak.Used > resource.Capacity {
+			r.Errorf(usage.path, CodeResourceOverbooked, "resource %q is overbooked from %s to %s: %d of %d units booked by %s",
+				resource.Name, peak.Start.Format(appearanceTimeFormat), peak.End.Format(appearanceTimeFormat),
+				peak.Used, resource.Capacity, describeUsages(peak.Usages))
+		}
+	}
+
+	return r.Finish()
+}
+
+// checkAssignment проверяет ссылку на ресурс и количество; возвращает false,
+// если бронь нельзя учитывать при проверке загрузки
+func checkAssignment(r *Report, resources map[uint]*models.Resource, assignment models.ResourceAssignment, path string) bool {
+	valid := true
+	if resources[assignment.ResourceID] == nil {
+		r.Errorf(path+"/resource_id", CodeUnknownResource, "resource %d does not exist", assignment.ResourceID)
+		valid = false
+	}
+	if assignment.Quantity <= 0 {
+		r.Errorf(path+"/quantity", CodePositive, "booked quantity must be positive")
+		valid = false
+	}
+	return valid
+}
+
+// describeUsages перечисляет брони для сообщения
+func describeUsages(usages []models.ResourceUsage) string {
+	parts := make([]string, len(usages))
+	for i, usage := range usages {
+		name := fmt.Sprintf("block %q", usage.BlockName)
+		if usage.ItemName != "" {
+			name = fmt.Sprintf("item %q", usage.ItemName)
+		}
+		parts[i] = fmt.Sprintf("%s in schedule %q (%d)", name, usage.ScheduleName, usage.Quantity)
+	}
+	return strings.Join(parts, ", ")
+}
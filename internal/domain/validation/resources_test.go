@@ -0,0 +1,71 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+)
+
+func TestValidateResource(t *testing.T) {
+	report := ValidateResource(&models.Resource{Name: " ", Capacity: 0})
+
+	assertIssues(t, report, map[string]string{
+		"/name":     CodeRequired,
+		"/capacity": CodePositive,
+	})
+	if report.Valid {
+		t.Fatal("report with errors must not be valid")
+	}
+}
+
+func TestValidateResources(t *testing.T) {
+	// Блоки: 10:00–10:40 с техперерывом и 10:40–11:40; элементы первого
+	// блока 10:05–10:15 и 10:21–10:26, второго — 10:40–11:00
+	schedule := domaintest.NewSchedule("Фестиваль")
+	schedule.ID = 1
+
+	stage := models.Resource{ID: 1, Name: "Сцена", Capacity: 1}
+	projector := models.Resource{ID: 2, Name: "Проектор", Capacity: 2}
+
+	// Блоки идут подряд, поэтому одна сцена на оба не конфликтует
+	schedule.Blocks[0].Resources = []models.ResourceAssignment{{ResourceID: stage.ID, Quantity: 1}}
+	schedule.Blocks[0].Items[0].Resources = []models.ResourceAssignment{{ResourceID: stage.ID, Quantity: 1}}
+	schedule.Blocks[0].Items[1].Resources = []models.ResourceAssignment{{ResourceID: projector.ID, Quantity: 2}}
+	schedule.Blocks[1].Resources = []models.ResourceAssignment{
+		{ResourceID: stage.ID, Quantity: 1},
+		{ResourceID: 999, Quantity: 1},
+	}
+	schedule.Blocks[1].Items[0].Resources = []models.ResourceAssignment{{ResourceID: projector.ID}}
+
+	day := schedule.StartDate
+	others := []models.ResourceUsage{
+		// Проектор одновременно нужен на другой площадке
+		{ResourceID: projector.ID, ScheduleID: 7, ScheduleName: "Другая площадка", BlockName: "Лекция", Quantity: 1,
+			StartTime: day.Add(20 * time.Minute), EndTime: day.Add(30 * time.Minute)},
+		// Брони самого расписания пропускаются
+		{ResourceID: stage.ID, ScheduleID: schedule.ID, BlockName: "Открытие", Quantity: 1,
+			StartTime: day, EndTime: day.Add(40 * time.Minute)},
+		// Сцена занята вечерней программой до конца второго блока
+		{ResourceID: stage.ID, ScheduleID: 8, ScheduleName: "Вечер", BlockName: "Саундчек", Quantity: 1,
+			StartTime: day.Add(90 * time.Minute), EndTime: day.Add(2 * time.Hour)},
+	}
+
+	report := ValidateResources(schedule, []models.Resource{stage, projector}, others)
+
+	want := map[string]string{
+		"/blocks/0/items/0/resources/0":          CodeDuplicateBooking,
+		"/blocks/0/items/1/resources/0":          CodeResourceOverbooked,
+		"/blocks/1/resources/0":                  CodeResourceOverbooked,
+		"/blocks/1/resources/1/resource_id":      CodeUnknownResource,
+		"/blocks/1/items/0/resources/0/quantity": CodePositive,
+	}
+	assertIssues(t, report, want)
+	if len(report.Issues) != len(want) {
+		t.Fatalf("want %d issues, got %+v", len(want), report.Issues)
+	}
+	if report.Valid {
+		t.Fatal("booking problems must be errors")
+	}
+}
@@ -0,0 +1,165 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// slugPattern — адрес публичной страницы: строчные латинские буквы и цифры,
+// разделенные одиночными дефисами
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// maxSlugLength ограничивает длину адреса публичной страницы
+const maxSlugLength = 64
+
+// ValidateSchedule проверяет расписание в том виде, в котором оно пришло от клиента.
+// Времена блоков рассчитываются так же, как при сохранении: блоки идут подряд
+// от начала расписания, а блок без длительности получает сумму длительностей элементов.
+func ValidateSchedule(schedule *models.Schedule) Report {
+	var r Report
+
+	if strings.TrimSpace(schedule.Name) == "" {
+		r.Errorf(Pointer("name"), CodeRequired, "schedule must have a name")
+	}
+	validatePage(&r, schedule)
+
+	datesSet := true
+	if schedule.StartDate.IsZero() {
+		r.Errorf(Pointer("start_date"), CodeRequired, "schedule must have a start date")
+		datesSet = false
+	}
+	if schedule.EndDate.IsZero() {
+		r.Errorf(Pointer("end_date"), CodeRequired, "schedule must have an end date")
+		datesSet = false
+	}
+	if datesSet && schedule.StartDate.After(schedule.EndDate) {
+		r.Errorf(Pointer("end_date"), CodeDateOrder, "schedule end date must not be before start date")
+	}
+
+	if len(schedule.Blocks) == 0 {
+		r.Errorf(Pointer("blocks"), CodeRequired, "schedule must have at least one block")
+	}
+
+	blockNames := make(map[string]int)
+	placements := make([]placement, len(schedule.Blocks))
+	current := schedule.StartDate
+	for i := range schedule.Blocks {
+		block := &schedule.Blocks[i]
+		duration := validateBlock(&r, i, block)
+		placements[i] = placement{start: current, duration: duration}
+
+		name := strings.TrimSpace(block.Name)
+		if first, ok := blockNames[name]; ok && name != "" {
+			r.Warnf(Pointer("blocks", i, "name"), CodeDuplicateName, "block %q has the same name as block %d", block.Name, first)
+		} else {
+			blockNames[name] = i
+		}
+
+		current = current.Add(time.Duration(duration+block.TechBreakDuration) * time.Minute)
+		if datesSet && current.After(schedule.EndDate) {
+			r.Errorf(Pointer("blocks", i), CodeExceedsScheduleEnd,
+				"block %q ends at %s, after the schedule end %s",
+				block.Name, current.Format(time.RFC3339), schedule.EndDate.Format(time.RFC3339))
+		}
+	}
+
+	validateConstraints(&r, schedule, placements)
+
+	return r.Finish()
+}
+
+// validatePage проверяет адрес, язык и часовой пояс публичной страницы
+func validatePage(r *Report, schedule *models.Schedule) {
+	if schedule.Slug != "" && (len(schedule.Slug) > maxSlugLength || !slugPattern.MatchString(schedule.Slug)) {
+		r.Errorf(Pointer("slug"), CodeInvalidFormat,
+			"slug %q must be up to %d lowercase latin letters and digits separated by single hyphens",
+			schedule.Slug, maxSlugLength)
+	}
+	switch schedule.Language {
+	case "", models.LanguageRussian, models.LanguageEnglish:
+	default:
+		r.Errorf(Pointer("language"), CodeUnknownType, "unknown language %q, expected %s or %s",
+			schedule.Language, models.LanguageRussian, models.LanguageEnglish)
+	}
+	if schedule.Timezone != "" {
+		if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+			r.Errorf(Pointer("timezone"), CodeUnknownType, "unknown time zone %q", schedule.Timezone)
+		}
+	}
+}
+
+// validateVisibility проверяет уровень видимости блока или элемента
+func validateVisibility(r *Report, path, visibility string) {
+	switch visibility {
+	case "", models.VisibilityPublic, models.VisibilityPerformers, models.VisibilityCrew:
+	default:
+		r.Errorf(path, CodeUnknownType, "unknown visibility %q, expected %s, %s or %s",
+			visibility, models.VisibilityPublic, models.VisibilityPerformers, models.VisibilityCrew)
+	}
+}
+
+// validateBlock проверяет блок и его элементы и возвращает длительность блока,
+// с которой он будет сохранен
+func validateBlock(r *Report, i int, block *models.Block) int {
+	if strings.TrimSpace(block.Name) == "" {
+		r.Errorf(Pointer("blocks", i, "name"), CodeRequired, "block must have a name")
+	}
+	if block.TechBreakDuration < 0 {
+		r.Errorf(Pointer("blocks", i, "tech_break_duration"), CodeNonNegative, "tech break duration must not be negative")
+	}
+	if block.ItemGap < 0 {
+		r.Errorf(Pointer("blocks", i, "item_gap"), CodeNonNegative, "item gap must not be negative")
+	}
+	switch block.SlackPolicy {
+	case "", models.SlackPolicyEnd, models.SlackPolicyStart, models.SlackPolicySpread:
+	default:
+		r.Errorf(Pointer("blocks", i, "slack_policy"), CodeUnknownType,
+			"unknown slack policy %q, expected %s, %s or %s",
+			block.SlackPolicy, models.SlackPolicyEnd, models.SlackPolicyStart, models.SlackPolicySpread)
+	}
+	validateVisibility(r, Pointer("blocks", i, "visibility"), block.Visibility)
+	if len(block.Items) == 0 {
+		r.Warnf(Pointer("blocks", i, "items"), CodeBlockWithoutItems, "block %q has no items", block.Name)
+	}
+
+	for j, item := range block.Items {
+		if strings.TrimSpace(item.Name) == "" {
+			r.Errorf(Pointer("blocks", i, "items", j, "name"), CodeRequired, "item must have a name")
+		}
+		if item.Duration <= 0 {
+			r.Errorf(Pointer("blocks", i, "items", j, "duration"), CodePositive, "item duration must be positive")
+		}
+		if item.CallOffset < 0 {
+			r.Errorf(Pointer("blocks", i, "items", j, "call_offset"), CodeNonNegative, "item call offset must not be negative")
+		}
+		validateVisibility(r, Pointer("blocks", i, "items", j, "visibility"), item.Visibility)
+		if item.Priority < 0 {
+			r.Errorf(Pointer("blocks", i, "items", j, "priority"), CodeNonNegative, "item priority must not be negative")
+		}
+		switch {
+		case item.MinDuration < 0:
+			r.Errorf(Pointer("blocks", i, "items", j, "min_duration"), CodeNonNegative, "item minimum duration must not be negative")
+		case item.MinDuration > item.Duration && item.Duration > 0:
+			r.Warnf(Pointer("blocks", i, "items", j, "duration"), CodeBelowMinimum,
+				"item %q lasts %d minutes, less than its minimum %d", item.Name, item.Duration, item.MinDuration)
+		}
+	}
+
+	itemsDuration := block.ContentDuration()
+	switch {
+	case block.Duration > 0 && block.Duration < itemsDuration:
+		r.Errorf(Pointer("blocks", i, "duration"), CodeItemsExceedBlock,
+			"block duration %d is less than the sum of item durations and gaps %d", block.Duration, itemsDuration)
+		return block.Duration
+	case block.Duration > 0:
+		return block.Duration
+	case itemsDuration > 0:
+		return itemsDuration
+	default:
+		r.Errorf(Pointer("blocks", i, "duration"), CodePositive, "block must have a positive duration or items with durations")
+		return 0
+	}
+}
@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+)
+
+func TestValidScheduleHasNoErrors(t *testing.T) {
+	report := ValidateSchedule(domaintest.NewSchedule("Фестиваль"))
+
+	if !report.Valid {
+		t.Fatalf("schedule should be valid, got %+v", report.Issues)
+	}
+	if err := report.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil", err)
+	}
+}
+
+func TestValidateScheduleCollectsAllIssues(t *testing.T) {
+	schedule := domaintest.NewSchedule("")
+	schedule.Slug = "Летний-фест"
+	schedule.Language = "de"
+	schedule.Timezone = "Mars/Olympus"
+	schedule.EndDate = schedule.StartDate.Add(time.Hour)
+	schedule.Blocks[0].TechBreakDuration = -5
+	schedule.Blocks[0].Items[0].Priority = -1
+	schedule.Blocks[0].Items[0].CallOffset = -10
+	schedule.Blocks[0].Items[0].Visibility = "vip"
+	schedule.Blocks[1].Visibility = "staff"
+	schedule.Blocks[0].Items[1].Name = ""
+	schedule.Blocks[0].Items[1].MinDuration = 10
+	schedule.Blocks[1].Duration = 10
+	schedule.Blocks[1].ItemGap = -1
+	schedule.Blocks[1].SlackPolicy = "middle"
+	schedule.Blocks[1].Items[0].Duration = 0
+	schedule.Blocks = append(schedule.Blocks, models.Block{Name: "Открытие"})
+
+	report := ValidateSchedule(schedule)
+
+	want := map[string]string{
+		"/name":                         CodeRequired,
+		"/slug":                         CodeInvalidFormat,
+		"/language":                     CodeUnknownType,
+		"/timezone":                     CodeUnknownType,
+		"/blocks/0/tech_break_duration": CodeNonNegative,
+		"/blocks/0/items/0/priority":    CodeNonNegative,
+		"/blocks/0/items/0/call_offset": CodeNonNegative,
+		"/blocks/0/items/0/visibility":  CodeUnknownType,
+		"/blocks/1/visibility":          CodeUnknownType,
+		"/blocks/0/items/1/name":        CodeRequired,
+		"/blocks/0/items/1/duration":    CodeBelowMinimum,
+		"/blocks/1/items/0/duration":    CodePositive,
+		"/blocks/1/item_gap":            CodeNonNegative,
+		"/blocks/1/slack_policy":        CodeUnknownType,
+		"/blocks/2/duration":            CodePositive,
+		"/blocks/2/items":               CodeBlockWithoutItems,
+		"/blocks/2/name":                CodeDuplicateName,
+	}
+	got := make(map[string]Issue)
+	for _, issue := range report.Issues {
+		got[issue.Path] = issue
+	}
+	for path, code := range want {
+		issue, ok := got[path]
+		if !ok {
+			t.Errorf("missing issue at %s, got %+v", path, report.Issues)
+			continue
+		}
+		if issue.Code != code {
+			t.Errorf("%s: code = %q, want %q", path, issue.Code, code)
+		}
+	}
+
+	if got["/blocks/2/items"].Severity != SeverityWarning {
+		t.Errorf("block without items should be a warning")
+	}
+	if got["/blocks/0/items/1/duration"].Severity != SeverityWarning {
+		t.Errorf("item below its minimum should be a warning")
+	}
+	if report.Valid {
+		t.Fatal("report with errors must not be valid")
+	}
+
+	err := report.Err()
+	if !errors.Is(err, utils.ErrValidation) {
+		t.Fatalf("Err() should wrap ErrValidation, got %v", err)
+	}
+	var validationErr *Error
+	if !errors.As(err, &validationErr) || len(validationErr.Issues) != len(report.Issues) {
+		t.Fatalf("Err() should carry all issues, got %v", err)
+	}
+}
+
+func TestValidateScheduleWindow(t *testing.T) {
+	schedule := domaintest.NewSchedule("Фестиваль")
+	// Открытие 30+10 минут, косплей 60 минут: второй блок заканчивается через 100 минут
+	schedule.EndDate = schedule.StartDate.Add(90 * time.Minute)
+
+	report := ValidateSchedule(schedule)
+	if len(report.Issues) != 1 || report.Issues[0].Path != "/blocks/1" || report.Issues[0].Code != CodeExceedsScheduleEnd {
+		t.Fatalf("want a single exceeds_schedule_end issue at /blocks/1, got %+v", report.Issues)
+	}
+
+	schedule.EndDate = schedule.StartDate.Add(-time.Minute)
+	report = ValidateSchedule(schedule)
+	if report.Issues[0].Path != "/end_date" || report.Issues[0].Code != CodeDateOrder {
+		t.Fatalf("want date_order at /end_date first, got %+v", report.Issues)
+	}
+}
+
+func TestPointerEscapesSegments(t *testing.T) {
+	if got := Pointer("blocks", 2, "a/b~c"); got != "/blocks/2/a~1b~0c" {
+		t.Fatalf("Pointer() = %q", got)
+	}
+}
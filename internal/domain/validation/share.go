@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/views"
+)
+
+// ValidateShareLink проверяет новую ссылку: представление должно существовать
+// и не зависеть от выступающего, а срок действия — закончиться в будущем, но
+// не позже maxLifetime от now
+func ValidateShareLink(link *models.ShareLink, now time.Time, maxLifetime time.Duration) Report {
+	var r Report
+
+	view, ok := views.Get(link.View)
+	switch {
+	case link.View == "":
+		r.Errorf(Pointer("view"), CodeRequired, "share link must name a view")
+	case !ok:
+		r.Errorf(Pointer("view"), CodeUnknownType, "unknown view %q", link.View)
+	case view.ByPerformer:
+		r.Errorf(Pointer("view"), CodeInvalidTarget, "view %q depends on a performer and cannot be shared", link.View)
+	}
+
+	if link.Version != nil && *link.Version <= 0 {
+		r.Errorf(Pointer("version"), CodePositive, "version must be positive")
+	}
+
+	if !link.ExpiresAt.After(now) {
+		r.Errorf(Pointer("expires_at"), CodeDateOrder, "share link must expire in the future")
+	} else if link.ExpiresAt.After(now.Add(maxLifetime)) {
+		r.Errorf(Pointer("expires_at"), CodeLifetimeExceeded, "share link cannot live longer than %s", maxLifetime)
+	}
+
+	return r.Finish()
+}
@@ -0,0 +1,39 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/views"
+)
+
+func TestValidateShareLink(t *testing.T) {
+	now := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+	version := 0
+
+	tests := []struct {
+		name string
+		link models.ShareLink
+		want map[string]string
+	}{
+		{"valid", models.ShareLink{View: views.Crew, ExpiresAt: now.Add(24 * time.Hour)}, map[string]string{}},
+		{"no view", models.ShareLink{ExpiresAt: now.Add(time.Hour)}, map[string]string{"/view": CodeRequired}},
+		{"unknown view", models.ShareLink{View: "press", ExpiresAt: now.Add(time.Hour)}, map[string]string{"/view": CodeUnknownType}},
+		{"performer view", models.ShareLink{View: views.Performers, ExpiresAt: now.Add(time.Hour)}, map[string]string{"/view": CodeInvalidTarget}},
+		{"expired", models.ShareLink{View: views.Public, Version: &version, ExpiresAt: now}, map[string]string{
+			"/version":    CodePositive,
+			"/expires_at": CodeDateOrder,
+		}},
+		{"too long", models.ShareLink{View: views.Public, ExpiresAt: now.Add(31 * 24 * time.Hour)}, map[string]string{"/expires_at": CodeLifetimeExceeded}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateShareLink(&tt.link, now, 30*24*time.Hour)
+			assertIssues(t, report, tt.want)
+			if len(report.Issues) != len(tt.want) {
+				t.Errorf("want %d issues, got %+v", len(tt.want), report.Issues)
+			}
+		})
+	}
+}
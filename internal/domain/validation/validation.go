@@ -0,0 +1,150 @@
+// Package validation проверяет расписание целиком и собирает все найденные
+// проблемы, а не только первую. Каждая проблема адресуется JSON Pointer
+// (RFC 6901) на поле входного документа, например /blocks/2/items/0/duration.
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cor-events-scheduler/pkg/utils"
+)
+
+// Severity — серьезность проблемы: ошибки блокируют сохранение, предупреждения нет
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Коды проблем
+const (
+	CodeRequired             = "required"
+	CodePositive             = "positive"
+	CodeNonNegative          = "non_negative"
+	CodeDateOrder            = "date_order"
+	CodeItemsExceedBlock     = "items_exceed_block"
+	CodeExceedsScheduleEnd   = "exceeds_schedule_end"
+	CodeBlockWithoutItems    = "block_without_items"
+	CodeDuplicateName        = "duplicate_name"
+	CodeUnknownType          = "unknown_type"
+	CodeUnknownBlock         = "unknown_block"
+	CodeAmbiguousBlock       = "ambiguous_block"
+	CodeInvalidTarget        = "invalid_target"
+	CodeConstraintCycle      = "constraint_cycle"
+	CodeConstraintConflict   = "constraint_conflict"
+	CodeConstraintViolated   = "constraint_violated"
+	CodeBelowMinimum         = "below_minimum"
+	CodeUnknownPerformer     = "unknown_performer"
+	CodePerformerUnavailable = "performer_unavailable"
+	CodePerformerConflict    = "performer_conflict"
+	CodeUnknownResource      = "unknown_resource"
+	CodeDuplicateBooking     = "duplicate_booking"
+	CodeResourceOverbooked   = "resource_overbooked"
+	CodeInvalidFormat        = "invalid_format"
+	CodeLifetimeExceeded     = "lifetime_exceeded"
+)
+
+// Issue — одна проблема расписания
+type Issue struct {
+	Path     string   `json:"path"`
+	Code     string   `json:"code"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// Report — результат проверки. Valid ложно, если есть хотя бы одна ошибка.
+type Report struct {
+	Valid  bool    `json:"valid"`
+	Issues []Issue `json:"issues"`
+}
+
+// Errorf добавляет ошибку
+func (r *Report) Errorf(path, code, format string, args ...interface{}) {
+	r.add(path, code, SeverityError, format, args...)
+}
+
+// Warnf добавляет предупреждение
+func (r *Report) Warnf(path, code, format string, args ...interface{}) {
+	r.add(path, code, SeverityWarning, format, args...)
+}
+
+func (r *Report) add(path, code string, severity Severity, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, Issue{
+		Path:     path,
+		Code:     code,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Merge добавляет проблемы другого отчета и пересчитывает Valid
+func (r *Report) Merge(other Report) {
+	r.Issues = append(r.Issues, other.Issues...)
+	r.Finish()
+}
+
+// HasErrors сообщает, есть ли проблемы с серьезностью error
+func (r *Report) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Err возвращает *Error с ошибками отчета или nil, если ошибок нет
+func (r *Report) Err() error {
+	if !r.HasErrors() {
+		return nil
+	}
+	return &Error{Issues: r.Issues}
+}
+
+// Finish вычисляет Valid по найденным проблемам и возвращает готовый отчет
+func (r *Report) Finish() Report {
+	if r.Issues == nil {
+		r.Issues = []Issue{}
+	}
+	r.Valid = !r.HasErrors()
+	return *r
+}
+
+// Error — отказ в сохранении из-за ошибок проверки; оборачивает utils.ErrValidation
+type Error struct {
+	Issues []Issue
+}
+
+func (e *Error) Error() string {
+	var messages []string
+	for _, issue := range e.Issues {
+		if issue.Severity == SeverityError {
+			messages = append(messages, issue.Path+": "+issue.Message)
+		}
+	}
+	return fmt.Sprintf("%s: %s", utils.ErrValidation, strings.Join(messages, "; "))
+}
+
+func (e *Error) Unwrap() error {
+	return utils.ErrValidation
+}
+
+// Pointer строит JSON Pointer из сегментов пути
+func Pointer(segments ...interface{}) string {
+	var b strings.Builder
+	for _, segment := range segments {
+		b.WriteByte('/')
+		switch s := segment.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(s))
+		case string:
+			b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(s))
+		default:
+			b.WriteString(fmt.Sprint(s))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,257 @@
+// Package views строит представления расписания для разных аудиторий. Каждое
+// представление описывается данными: какой уровень видимости ему доступен и
+// какие поля расписания, блоков и элементов в него попадают. Все
+// представления строятся одной проекцией, поэтому новое представление — это
+// новая запись в definitions.
+package views
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// Названия встроенных представлений
+const (
+	Public     = "public"
+	Performers = "performers"
+	Crew       = "crew"
+)
+
+// View — именованное представление расписания
+type View struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Audience — уровень видимости аудитории: блоки и элементы с более
+	// закрытым уровнем в представление не попадают
+	Audience string `json:"audience"`
+	// ByPerformer оставляет только элементы выступающего из запроса и блоки с ними
+	ByPerformer    bool     `json:"by_performer"`
+	ScheduleFields []string `json:"schedule_fields"`
+	BlockFields    []string `json:"block_fields"`
+	ItemFields     []string `json:"item_fields"`
+}
+
+var definitions = []View{
+	{
+		Name:           Public,
+		Description:    "Программа для участников без внутренних блоков, элементов и описаний",
+		Audience:       models.VisibilityPublic,
+		ScheduleFields: []string{"name", "language", "timezone", "start_date", "end_date", "blocks"},
+		BlockFields:    []string{"name", "track", "start_time", "duration", "items"},
+		ItemFields:     []string{"name", "start_time", "end_time", "duration"},
+	},
+	{
+		Name:           Performers,
+		Description:    "Выходы выступающего со временем сбора",
+		Audience:       models.VisibilityPerformers,
+		ByPerformer:    true,
+		ScheduleFields: []string{"id", "name", "timezone", "start_date", "end_date", "blocks"},
+		BlockFields:    []string{"id", "name", "track", "start_time", "end_time", "items"},
+		ItemFields:     []string{"id", "name", "type", "description", "call_time", "start_time", "end_time", "duration"},
+	},
+	{
+		Name:           Crew,
+		Description:    "Полное расписание для команды с техперерывами и заметками",
+		Audience:       models.VisibilityCrew,
+		ScheduleFields: []string{"id", "name", "slug", "language", "timezone", "start_date", "end_date", "blocks"},
+		BlockFields: []string{"id", "name", "type", "track", "visibility", "start_time", "end_time", "duration",
+			"tech_break_duration", "tech_break_end", "notes", "items"},
+		ItemFields: []string{"id", "name", "type", "description", "visibility", "call_time", "start_time", "end_time",
+			"duration", "performer_ids", "notes"},
+	},
+}
+
+// Get возвращает представление по названию
+func Get(name string) (View, bool) {
+	for _, view := range definitions {
+		if view.Name == name {
+			return view, true
+		}
+	}
+	return View{}, false
+}
+
+// List возвращает все представления
+func List() []View {
+	return append([]View{}, definitions...)
+}
+
+// Filter возвращает копию расписания с блоками и элементами, которые видит
+// аудитория представления. Времена элементов рассчитываются до отбора, так что
+// скрытые элементы по-прежнему занимают свое время. performerID учитывается
+// только в представлениях ByPerformer.
+func (v View) Filter(schedule *models.Schedule, performerID uint) *models.Schedule {
+	filtered := *schedule
+	filtered.Blocks = make([]models.Block, 0, len(schedule.Blocks))
+
+	for _, block := range schedule.Blocks {
+		if !models.VisibleTo(block.Visibility, v.Audience) {
+			continue
+		}
+		block.Items = append([]models.BlockItem{}, block.Items...)
+		block.LayoutItems()
+
+		items := make([]models.BlockItem, 0, len(block.Items))
+		for _, item := range block.Items {
+			if models.VisibleTo(item.Visibility, v.Audience) && (!v.ByPerformer || hasPerformer(item, performerID)) {
+				items = append(items, item)
+			}
+		}
+		if v.ByPerformer && len(items) == 0 {
+			continue
+		}
+		block.Items = items
+		filtered.Blocks = append(filtered.Blocks, block)
+	}
+	return &filtered
+}
+
+// Project возвращает поля представления отобранного Filter расписания
+func (v View) Project(schedule *models.Schedule) Object {
+	object := make(Object, 0, len(v.ScheduleFields))
+	for _, name := range v.ScheduleFields {
+		if name == "blocks" {
+			blocks := make([]Object, len(schedule.Blocks))
+			for i := range schedule.Blocks {
+				blocks[i] = v.projectBlock(&schedule.Blocks[i])
+			}
+			object = append(object, Field{Key: name, Value: blocks})
+			continue
+		}
+		if value, ok := scheduleFields[name]; ok {
+			object = append(object, Field{Key: name, Value: value(schedule)})
+		}
+	}
+	return object
+}
+
+func (v View) projectBlock(block *models.Block) Object {
+	object := make(Object, 0, len(v.BlockFields))
+	for _, name := range v.BlockFields {
+		if name == "items" {
+			items := make([]Object, len(block.Items))
+			for i := range block.Items {
+				items[i] = v.projectItem(&block.Items[i])
+			}
+			object = append(object, Field{Key: name, Value: items})
+			continue
+		}
+		if value, ok := blockFields[name]; ok {
+			object = append(object, Field{Key: name, Value: value(block)})
+		}
+	}
+	return object
+}
+
+func (v View) projectItem(item *models.BlockItem) Object {
+	object := make(Object, 0, len(v.ItemFields))
+	for _, name := range v.ItemFields {
+		if value, ok := itemFields[name]; ok {
+			object = append(object, Field{Key: name, Value: value(item)})
+		}
+	}
+	return object
+}
+
+func hasPerformer(item models.BlockItem, performerID uint) bool {
+	for _, id := range item.PerformerIDs {
+		if id == performerID {
+			return true
+		}
+	}
+	return false
+}
+
+// Поля, доступные представлениям. blocks и items — вложенные списки,
+// которые строятся по BlockFields и ItemFields.
+var (
+	scheduleFields = map[string]func(*models.Schedule) any{
+		"id":         func(s *models.Schedule) any { return s.ID },
+		"name":       func(s *models.Schedule) any { return s.Name },
+		"slug":       func(s *models.Schedule) any { return s.Slug },
+		"language":   func(s *models.Schedule) any { return s.Language },
+		"timezone":   func(s *models.Schedule) any { return s.Timezone },
+		"start_date": func(s *models.Schedule) any { return s.StartDate },
+		"end_date":   func(s *models.Schedule) any { return s.EndDate },
+	}
+	blockFields = map[string]func(*models.Block) any{
+		"id":                  func(b *models.Block) any { return b.ID },
+		"name":                func(b *models.Block) any { return b.Name },
+		"type":                func(b *models.Block) any { return b.Type },
+		"track":               func(b *models.Block) any { return b.Track },
+		"visibility":          func(b *models.Block) any { return visibility(b.Visibility) },
+		"start_time":          func(b *models.Block) any { return b.StartTime },
+		"end_time":            func(b *models.Block) any { return b.StartTime.Add(time.Duration(b.Duration) * time.Minute) },
+		"duration":            func(b *models.Block) any { return b.Duration },
+		"tech_break_duration": func(b *models.Block) any { return b.TechBreakDuration },
+		"tech_break_end":      func(b *models.Block) any { return b.EndTime() },
+		"notes":               func(b *models.Block) any { return b.Notes },
+	}
+	itemFields = map[string]func(*models.BlockItem) any{
+		"id":            func(i *models.BlockItem) any { return i.ID },
+		"name":          func(i *models.BlockItem) any { return i.Name },
+		"type":          func(i *models.BlockItem) any { return i.Type },
+		"description":   func(i *models.BlockItem) any { return i.Description },
+		"visibility":    func(i *models.BlockItem) any { return visibility(i.Visibility) },
+		"call_time":     func(i *models.BlockItem) any { return i.CallTime() },
+		"start_time":    func(i *models.BlockItem) any { return i.StartTime },
+		"end_time":      func(i *models.BlockItem) any { return i.EndTime },
+		"duration":      func(i *models.BlockItem) any { return i.Duration },
+		"performer_ids": func(i *models.BlockItem) any { return models.SortedIDs(i.PerformerIDs) },
+		"notes":         func(i *models.BlockItem) any { return i.Notes },
+	}
+)
+
+// visibility возвращает уровень видимости с подставленным значением по умолчанию
+func visibility(value string) string {
+	if value == "" {
+		return models.VisibilityPublic
+	}
+	return value
+}
+
+// Field — поле представления
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Object — поля представления в порядке его определения
+type Object []Field
+
+// MarshalJSON записывает поля JSON-объектом в порядке определения
+func (o Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Get возвращает значение поля; ok ложно, если поля нет в представлении
+func (o Object) Get(key string) (value any, ok bool) {
+	for _, field := range o {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
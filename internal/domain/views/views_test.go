@@ -0,0 +1,137 @@
+package views
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// newSchedule строит расписание domaintest, где «Приветствие» видно только
+// команде, у «Гимна» есть выступающий и время сбора, а «Косплей» закрыт для
+// участников
+func newSchedule() *models.Schedule {
+	schedule := domaintest.NewSchedule("Фестиваль")
+	schedule.ID = 1
+	opening := &schedule.Blocks[0]
+	opening.Notes = "Проверить микрофоны"
+	opening.Items[0].Visibility = models.VisibilityCrew
+	opening.Items[1].PerformerIDs = []uint{7}
+	opening.Items[1].CallOffset = 30
+	schedule.Blocks[1].Visibility = models.VisibilityPerformers
+	schedule.Blocks[1].Items[0].PerformerIDs = []uint{8}
+	return schedule
+}
+
+func mustGet(t *testing.T, name string) View {
+	t.Helper()
+	view, ok := Get(name)
+	if !ok {
+		t.Fatalf("view %q must exist", name)
+	}
+	return view
+}
+
+func names(schedule *models.Schedule) []string {
+	var result []string
+	for _, block := range schedule.Blocks {
+		result = append(result, block.Name)
+		for _, item := range block.Items {
+			result = append(result, "  "+item.Name)
+		}
+	}
+	return result
+}
+
+func TestFilterByAudience(t *testing.T) {
+	tests := []struct {
+		view        string
+		performerID uint
+		want        string
+	}{
+		{Public, 0, "Открытие|  Гимн"},
+		{Performers, 7, "Открытие|  Гимн"},
+		{Performers, 8, "Косплей|  Участник 1"},
+		{Performers, 9, ""},
+		{Crew, 0, "Открытие|  Приветствие|  Гимн|Косплей|  Участник 1"},
+	}
+	for _, tt := range tests {
+		schedule := newSchedule()
+		filtered := mustGet(t, tt.view).Filter(schedule, tt.performerID)
+		if got := strings.Join(names(filtered), "|"); got != tt.want {
+			t.Errorf("%s for performer %d: want %q, got %q", tt.view, tt.performerID, tt.want, got)
+		}
+		if len(schedule.Blocks) != 2 || len(schedule.Blocks[0].Items) != 2 {
+			t.Fatal("filter must not change the source schedule")
+		}
+	}
+}
+
+func TestHiddenItemsKeepTheirTime(t *testing.T) {
+	schedule := newSchedule()
+	// Элементы без рассчитанных времен, как у старых расписаний
+	for i := range schedule.Blocks[0].Items {
+		schedule.Blocks[0].Items[i].StartTime = time.Time{}
+	}
+
+	filtered := mustGet(t, Public).Filter(schedule, 0)
+	anthem := filtered.Blocks[0].Items[0]
+	// Приветствие 10:05–10:15 скрыто, но гимн идет после него
+	if want := schedule.StartDate.Add(21 * time.Minute); !anthem.StartTime.Equal(want) {
+		t.Fatalf("want anthem at %v, got %v", want, anthem.StartTime)
+	}
+}
+
+func TestProjectFieldsInDefinitionOrder(t *testing.T) {
+	view := mustGet(t, Performers)
+	data, err := json.Marshal(view.Project(view.Filter(newSchedule(), 7)))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	want := `{"id":1,"name":"Фестиваль","timezone":"","start_date":"2024-04-01T10:00:00Z","end_date":"2024-04-01T16:00:00Z",` +
+		`"blocks":[{"id":0,"name":"Открытие","track":"Главная сцена","start_time":"2024-04-01T10:00:00Z","end_time":"2024-04-01T10:30:00Z",` +
+		`"items":[{"id":0,"name":"Гимн","type":"music","description":"Хор","call_time":"2024-04-01T09:51:00Z",` +
+		`"start_time":"2024-04-01T10:21:00Z","end_time":"2024-04-01T10:26:00Z","duration":5}]}]}`
+	if string(data) != want {
+		t.Fatalf("unexpected projection:\nwant %s\n got %s", want, data)
+	}
+}
+
+func TestCrewSeesEverything(t *testing.T) {
+	view := mustGet(t, Crew)
+	object := view.Project(view.Filter(newSchedule(), 0))
+
+	blocks, _ := object.Get("blocks")
+	opening := blocks.([]Object)[0]
+	for key, want := range map[string]any{
+		"notes":          "Проверить микрофоны",
+		"visibility":     models.VisibilityPublic,
+		"tech_break_end": time.Date(2024, 4, 1, 10, 40, 0, 0, time.UTC),
+	} {
+		if got, ok := opening.Get(key); !ok || got != want {
+			t.Errorf("%s: want %v, got %v", key, want, got)
+		}
+	}
+	if _, ok := object.Get("slug"); !ok {
+		t.Error("crew view must include the slug")
+	}
+}
+
+func TestDefinitionsUseKnownFields(t *testing.T) {
+	for _, view := range List() {
+		check := func(kind string, fields []string, known func(string) bool) {
+			for _, field := range fields {
+				if !known(field) {
+					t.Errorf("view %s: unknown %s field %q", view.Name, kind, field)
+				}
+			}
+		}
+		check("schedule", view.ScheduleFields, func(f string) bool { _, ok := scheduleFields[f]; return ok || f == "blocks" })
+		check("block", view.BlockFields, func(f string) bool { _, ok := blockFields[f]; return ok || f == "items" })
+		check("item", view.ItemFields, func(f string) bool { _, ok := itemFields[f]; return ok })
+	}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cor-events-scheduler/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AgendaHandler struct {
+	service *services.AgendaService
+	logger  *zap.Logger
+}
+
+func NewAgendaHandler(service *services.AgendaService, logger *zap.Logger) *AgendaHandler {
+	return &AgendaHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary Get agenda
+// @Description Get every block, and with items=true every item, from all schedules overlapping [from, to), sorted by start time.
+// @Description schedule_id, type and track may be repeated; they filter blocks, and items follow their block. The range must not exceed 31 days.
+// @Tags agenda
+// @Produce json
+// @Param from query string true "Range start, RFC 3339"
+// @Param to query string true "Range end, RFC 3339"
+// @Param schedule_id query []int false "Schedule IDs" collectionFormat(multi)
+// @Param type query []string false "Block types" collectionFormat(multi)
+// @Param track query []string false "Block tracks" collectionFormat(multi)
+// @Param items query bool false "Include block items"
+// @Success 200 {object} services.Agenda
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/agenda [get]
+func (h *AgendaHandler) GetAgenda(c *gin.Context) {
+	var query services.AgendaQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondError(c, h.logger, "Failed to bind query", invalidInput(err))
+		return
+	}
+
+	agenda, err := h.service.GetAgenda(c.Request.Context(), query)
+	if err != nil {
+		respondError(c, h.logger, "Failed to get agenda", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, agenda)
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"cor-events-scheduler/internal/domain/validation"
+	"cor-events-scheduler/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ProblemContentType — тип содержимого ответов об ошибках (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// Problem — описание ошибки по RFC 7807. Code — стабильный машиночитаемый код,
+// Type строится из него; Detail содержит текст ошибки только для ошибок клиента.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+	// Errors — все проблемы проверки расписания, если запрос отклонен валидацией
+	Errors []validation.Issue `json:"errors,omitempty"`
+}
+
+// Коды ошибок API
+const (
+	CodeNotFound          = "not-found"
+	CodeConflict          = "conflict"
+	CodeValidationFailed  = "validation-failed"
+	CodeScheduleOverlap   = "schedule-overlap"
+	CodeInvalidTimeFormat = "invalid-time-format"
+	CodeInvalidInput      = "invalid-input"
+	CodeInternal          = "internal"
+)
+
+type problemType struct {
+	err    error
+	status int
+	code   string
+	title  string
+}
+
+// problemTypes сопоставляет ошибки из pkg/utils со статусами; порядок важен,
+// так как ошибка может оборачивать несколько сигнальных ошибок
+var problemTypes = []problemType{
+	{utils.ErrNotFound, http.StatusNotFound, CodeNotFound, "Resource not found"},
+	{utils.ErrConflict, http.StatusConflict, CodeConflict, "Resource conflict"},
+	{utils.ErrValidation, http.StatusUnprocessableEntity, CodeValidationFailed, "Schedule validation failed"},
+	{utils.ErrScheduleOverlap, http.StatusUnprocessableEntity, CodeScheduleOverlap, "Schedule blocks overlap"},
+	{utils.ErrInvalidTimeFormat, http.StatusBadRequest, CodeInvalidTimeFormat, "Invalid time format"},
+	{utils.ErrInvalidInput, http.StatusBadRequest, CodeInvalidInput, "Invalid input"},
+}
+
+// NewProblem строит описание ошибки для запроса
+func NewProblem(c *gin.Context, err error) Problem {
+	for _, pt := range problemTypes {
+		if errors.Is(err, pt.err) {
+			problem := Problem{
+				Type:     problemTypeURI(pt.code),
+				Title:    pt.title,
+				Status:   pt.status,
+				Detail:   err.Error(),
+				Instance: c.Request.URL.Path,
+				Code:     pt.code,
+			}
+
+			var validationErr *validation.Error
+			if errors.As(err, &validationErr) {
+				problem.Errors = validationErr.Issues
+			}
+			return problem
+		}
+	}
+
+	// Детали внутренних ошибок остаются в логах
+	return Problem{
+		Type:     problemTypeURI(CodeInternal),
+		Title:    "Internal server error",
+		Status:   http.StatusInternalServerError,
+		Instance: c.Request.URL.Path,
+		Code:     CodeInternal,
+	}
+}
+
+// respondError записывает ошибку в лог и отвечает application/problem+json
+func respondError(c *gin.Context, logger *zap.Logger, msg string, err error) {
+	problem := NewProblem(c, err)
+
+	if problem.Status >= http.StatusInternalServerError {
+		logger.Error(msg, zap.Error(err))
+	} else {
+		logger.Info(msg, zap.Error(err), zap.Int("status", problem.Status))
+	}
+
+	writeProblem(c, problem)
+}
+
+func writeProblem(c *gin.Context, problem Problem) {
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// NoRoute отвечает на запросы к неизвестным путям
+func NoRoute(c *gin.Context) {
+	writeProblem(c, NewProblem(c, fmt.Errorf("%w: no route for %s %s", utils.ErrNotFound, c.Request.Method, c.Request.URL.Path)))
+}
+
+// Recovery отвечает на панику обработчика описанием внутренней ошибки
+func Recovery(c *gin.Context, recovered any) {
+	writeProblem(c, NewProblem(c, fmt.Errorf("panic: %v", recovered)))
+}
+
+func problemTypeURI(code string) string {
+	return "/problems/" + code
+}
+
+// parseID читает положительный числовой идентификатор из параметра пути
+func parseID(c *gin.Context, name string) (uint, error) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid %s format: %v", utils.ErrInvalidInput, name, err)
+	}
+	return uint(id), nil
+}
+
+// invalidInput оборачивает ошибку разбора запроса в utils.ErrInvalidInput
+func invalidInput(err error) error {
+	return fmt.Errorf("%w: %v", utils.ErrInvalidInput, err)
+}
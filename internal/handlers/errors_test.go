@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cor-events-scheduler/internal/domain/validation"
+	"cor-events-scheduler/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestRespondErrorMapsToProblem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		detail bool
+	}{
+		{"not found", fmt.Errorf("failed to get schedule: %w", utils.ErrNotFound), http.StatusNotFound, CodeNotFound, true},
+		{"overlap", fmt.Errorf("invalid schedule times: %w", utils.ErrScheduleOverlap), http.StatusUnprocessableEntity, CodeScheduleOverlap, true},
+		{"invalid input", invalidInput(errors.New("bad cursor")), http.StatusBadRequest, CodeInvalidInput, true},
+		{"conflict wins over input", fmt.Errorf("%w: restore: %w", utils.ErrConflict, utils.ErrInvalidInput), http.StatusConflict, CodeConflict, true},
+		{"raw gorm error", gorm.ErrRecordNotFound, http.StatusInternalServerError, CodeInternal, false},
+		{"database", fmt.Errorf("%w: connection reset", utils.ErrDatabaseOperation), http.StatusInternalServerError, CodeInternal, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/schedules/1", nil)
+
+			respondError(c, zap.NewNop(), "test", tt.err)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+				t.Fatalf("content type = %q, want %q", ct, ProblemContentType)
+			}
+
+			var problem Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if problem.Code != tt.code || problem.Status != tt.status || problem.Instance != "/api/v1/schedules/1" {
+				t.Fatalf("unexpected problem: %+v", problem)
+			}
+			if (problem.Detail != "") != tt.detail {
+				t.Fatalf("detail = %q, exposed = %v, want %v", problem.Detail, problem.Detail != "", tt.detail)
+			}
+		})
+	}
+}
+
+func TestValidationProblemListsIssues(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/schedules", nil)
+
+	var report validation.Report
+	report.Errorf("/blocks/0/name", validation.CodeRequired, "block must have a name")
+	report.Errorf("/blocks/1/items/0/duration", validation.CodePositive, "item duration must be positive")
+	respondError(c, zap.NewNop(), "test", report.Err())
+
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if w.Code != http.StatusUnprocessableEntity || problem.Code != CodeValidationFailed {
+		t.Fatalf("unexpected problem: %d %+v", w.Code, problem)
+	}
+	if len(problem.Errors) != 2 || problem.Errors[1].Path != "/blocks/1/items/0/duration" {
+		t.Fatalf("problem should list every issue, got %+v", problem.Errors)
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cor-events-scheduler/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type FitHandler struct {
+	service *services.FitService
+	logger  *zap.Logger
+}
+
+func NewFitHandler(service *services.FitService, logger *zap.Logger) *FitHandler {
+	return &FitHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary Fit schedule to a new end date
+// @Description Compress or stretch item durations, block slack and tech breaks so the schedule ends exactly at end_date.
+// @Description Strategies: proportional (default), priority (items with a higher priority change less) and minimum (items never go below min_duration).
+// @Description Durations are rounded to whole minutes preserving the total. The schedule is saved only when accept is true.
+// @Tags schedules
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param request body services.FitRequest true "New end date, strategy and whether to save the result"
+// @Success 200 {object} services.FitResponse
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/fit [post]
+func (h *FitHandler) FitSchedule(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	var request services.FitRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, h.logger, "Failed to bind JSON", invalidInput(err))
+		return
+	}
+
+	response, err := h.service.FitSchedule(c.Request.Context(), id, request)
+	if err != nil {
+		respondError(c, h.logger, "Failed to fit schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
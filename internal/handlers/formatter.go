@@ -2,10 +2,11 @@ package handlers
 
 import (
 	"net/http"
-	"strconv"
 
 	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/validation"
 	"cor-events-scheduler/internal/services"
+	"cor-events-scheduler/internal/web"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -24,39 +25,112 @@ func NewFormatterHandler(service *services.FormatterService, logger *zap.Logger)
 }
 
 // @Summary Get public schedule
-// @Description Get a formatted public version of a schedule
+// @Description Get the schedule in the public view: blocks and items visible to attendees, without descriptions. Same as GET /views/public.
 // @Tags schedules
 // @Accept json
 // @Produce json
 // @Param id path int true "Schedule ID"
-// @Success 200 {object} services.PublicSchedule
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Success 200 {object} object
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /api/v1/schedules/{id}/public [get]
 func (h *FormatterHandler) GetPublicSchedule(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, err := parseID(c, "id")
 	if err != nil {
-		h.logger.Error("Invalid ID format", zap.Error(err))
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid ID format",
-			Details: err.Error(),
-		})
+		respondError(c, h.logger, "Invalid ID format", err)
 		return
 	}
 
-	schedule, err := h.service.FormatPublicSchedule(c.Request.Context(), uint(id))
+	schedule, err := h.service.FormatPublicSchedule(c.Request.Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to format schedule", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to format schedule",
-			Details: err.Error(),
-		})
+		respondError(c, h.logger, "Failed to format schedule", err)
 		return
 	}
 
 	c.JSON(http.StatusOK, schedule)
 }
 
+// @Summary Get schedule view
+// @Description Get the schedule projected through a named view. Each view sees blocks and items up to its audience visibility and only the fields of its definition.
+// @Description The performers view keeps only the items of performer_id, with call times, and requires it.
+// @Tags schedules
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param view path string true "View name: public, performers or crew"
+// @Param performer_id query int false "Performer ID for performer views"
+// @Success 200 {object} object
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/views/{view} [get]
+func (h *FormatterHandler) GetView(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	var query services.ViewQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondError(c, h.logger, "Failed to bind query", invalidInput(err))
+		return
+	}
+
+	view, err := h.service.FormatView(c.Request.Context(), id, c.Param("view"), query)
+	if err != nil {
+		respondError(c, h.logger, "Failed to format schedule view", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// @Summary List schedule views
+// @Description List the named views with their audience and the schedule, block and item fields they include
+// @Tags schedules
+// @Produce json
+// @Success 200 {array} views.View
+// @Router /api/v1/views [get]
+func (h *FormatterHandler) ListViews(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.ListViews())
+}
+
+// @Summary Get public schedule page
+// @Description Server-rendered HTML page of the schedule with the given slug: the timeline by day in the schedule's language and time zone,
+// @Description with the current block highlighted and a print stylesheet. With embed=true the page has no header or background and may be framed by any site.
+// @Tags public
+// @Produce html
+// @Param slug path string true "Schedule slug"
+// @Param embed query bool false "Render for an iframe"
+// @Success 200 {string} string
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /p/{slug} [get]
+func (h *FormatterHandler) GetPublicPage(c *gin.Context) {
+	var query services.PublicPageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondError(c, h.logger, "Failed to bind query", invalidInput(err))
+		return
+	}
+
+	body, err := h.service.RenderPublicPage(c.Request.Context(), c.Param("slug"), web.Options{Embed: query.Embed})
+	if err != nil {
+		respondError(c, h.logger, "Failed to render public page", err)
+		return
+	}
+
+	// Встраивать в чужие сайты можно только страницу в режиме embed
+	if query.Embed {
+		c.Header("Content-Security-Policy", "frame-ancestors *")
+	} else {
+		c.Header("Content-Security-Policy", "frame-ancestors 'self'")
+	}
+	c.Header("Cache-Control", "public, max-age=60")
+	c.Data(http.StatusOK, web.ContentType, body)
+}
+
 // @Summary Get text schedule
 // @Description Get a text representation of a schedule
 // @Tags schedules
@@ -64,27 +138,20 @@ func (h *FormatterHandler) GetPublicSchedule(c *gin.Context) {
 // @Produce text/plain
 // @Param id path int true "Schedule ID"
 // @Success 200 {string} string
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /api/v1/schedules/{id}/text [get]
 func (h *FormatterHandler) GetScheduleText(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, err := parseID(c, "id")
 	if err != nil {
-		h.logger.Error("Invalid ID format", zap.Error(err))
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid ID format",
-			Details: err.Error(),
-		})
+		respondError(c, h.logger, "Invalid ID format", err)
 		return
 	}
 
-	text, err := h.service.FormatScheduleText(c.Request.Context(), uint(id))
+	text, err := h.service.FormatScheduleText(c.Request.Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to format schedule text", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to format schedule text",
-			Details: err.Error(),
-		})
+		respondError(c, h.logger, "Failed to format schedule text", err)
 		return
 	}
 
@@ -93,18 +160,32 @@ func (h *FormatterHandler) GetScheduleText(c *gin.Context) {
 }
 
 // Вспомогательные структуры
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Details string `json:"details,omitempty"`
+
+// ScheduleResponse — сохраненное расписание и отчет проверки с предупреждениями
+// и результатами правил площадки
+type ScheduleResponse struct {
+	*models.Schedule
+	Validation validation.Report `json:"validation"`
 }
 
+// ListSchedulesResponse — страница кратких сведений о расписаниях
 type ListSchedulesResponse struct {
+	Data []models.ScheduleSummary `json:"data"`
+	Meta CursorMeta               `json:"meta"`
+}
+
+// ListFullSchedulesResponse — страница полных расписаний (include=blocks)
+type ListFullSchedulesResponse struct {
 	Data []models.Schedule `json:"data"`
-	Meta PaginationMeta    `json:"meta"`
+	Meta CursorMeta        `json:"meta"`
+}
+
+type CursorMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
 }
 
-type PaginationMeta struct {
-	Page     int `json:"page"`
-	PageSize int `json:"page_size"`
-	Total    int `json:"total"`
+func newCursorMeta(limit int, nextCursor string) CursorMeta {
+	return CursorMeta{Limit: limit, NextCursor: nextCursor, HasMore: nextCursor != ""}
 }
@@ -27,20 +27,3 @@ func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 		)
 	}
 }
-
-func ErrorMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Next()
-
-		// Проверяем наличие ошибок
-		if len(c.Errors) > 0 {
-			// Берем последнюю ошибку
-			err := c.Errors.Last()
-
-			// Отправляем соответствующий ответ
-			c.JSON(c.Writer.Status(), gin.H{
-				"error": err.Error(),
-			})
-		}
-	}
-}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cor-events-scheduler/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// nowHeartbeat — как часто поток статуса повторяет событие без смены блока или
+// элемента: так правки расписания и обратный отсчет доходят до табло, а
+// прокси не закрывают простаивающее соединение
+const nowHeartbeat = 30 * time.Second
+
+type NowHandler struct {
+	service *services.NowService
+	logger  *zap.Logger
+}
+
+func NewNowHandler(service *services.NowService, logger *zap.Logger) *NowHandler {
+	return &NowHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary Get what is on now
+// @Description Get the current and next block and item of the planned timeline at the given moment, with countdown and remaining seconds.
+// @Description A block runs for its duration and is followed by its tech break. next_change is the next boundary after at when the status changes.
+// @Tags schedules
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param at query string false "Moment, RFC 3339; defaults to now"
+// @Success 200 {object} live.Status
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/now [get]
+func (h *NowHandler) GetNow(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	var query services.NowQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondError(c, h.logger, "Failed to bind query", invalidInput(err))
+		return
+	}
+	if query.At.IsZero() {
+		query.At = time.Now()
+	}
+
+	status, err := h.service.GetNow(c.Request.Context(), id, query.At)
+	if err != nil {
+		respondError(c, h.logger, "Failed to get current status", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// @Summary Stream what is on now
+// @Description Server-sent events for stage displays. A "status" event with the same body as GET /now is sent immediately,
+// @Description at every block, item and tech break boundary, and at least every 30 seconds. An "error" event ends the stream.
+// @Tags schedules
+// @Produce text/event-stream
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} live.Status
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/now/stream [get]
+func (h *NowHandler) StreamNow(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	status, err := h.service.GetNow(ctx, id, time.Now())
+	if err != nil {
+		respondError(c, h.logger, "Failed to get current status", err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for {
+		c.SSEvent("status", status)
+		c.Writer.Flush()
+
+		wait := nowHeartbeat
+		if status.NextChange != nil {
+			wait = min(wait, time.Until(*status.NextChange))
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		status, err = h.service.GetNow(ctx, id, time.Now())
+		if err != nil {
+			problem := NewProblem(c, err)
+			h.logger.Info("Stopped current status stream", zap.Error(err), zap.Int("status", problem.Status))
+			c.SSEvent("error", problem)
+			c.Writer.Flush()
+			return
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"cor-events-scheduler/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type OptimizerHandler struct {
+	service *services.OptimizerService
+	logger  *zap.Logger
+}
+
+func NewOptimizerHandler(service *services.OptimizerService, logger *zap.Logger) *OptimizerHandler {
+	return &OptimizerHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary Optimize block order
+// @Description Propose a block order and tech break allocation that reduce changeover time and risk.
+// @Description Pinned blocks keep their positions, precedence pairs keep their order.
+// @Description The schedule is saved only when accept is true; accept must carry the revision returned by the preview.
+// @Description A revision that no longer matches the schedule or its rules is rejected with 409, optimize again.
+// @Tags schedules
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param request body services.OptimizeRequest false "Constraints and whether to save the proposal"
+// @Success 200 {object} services.OptimizeResponse
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/optimize [post]
+func (h *OptimizerHandler) OptimizeSchedule(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	var request services.OptimizeRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, h.logger, "Failed to bind JSON", invalidInput(err))
+		return
+	}
+
+	response, err := h.service.OptimizeSchedule(c.Request.Context(), id, request)
+	if err != nil {
+		respondError(c, h.logger, "Failed to optimize schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/services"
+	"cor-events-scheduler/pkg/ical"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type PerformerHandler struct {
+	service *services.PerformerService
+	logger  *zap.Logger
+}
+
+func NewPerformerHandler(service *services.PerformerService, logger *zap.Logger) *PerformerHandler {
+	return &PerformerHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary Create performer
+// @Description Create a performer with availability windows and the buffer needed between appearances
+// @Tags performers
+// @Accept json
+// @Produce json
+// @Param performer body models.Performer true "Performer object"
+// @Success 201 {object} models.Performer
+// @Failure 400 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/performers [post]
+func (h *PerformerHandler) CreatePerformer(c *gin.Context) {
+	var performer models.Performer
+	if err := c.ShouldBindJSON(&performer); err != nil {
+		respondError(c, h.logger, "Failed to bind JSON", invalidInput(err))
+		return
+	}
+
+	if err := h.service.CreatePerformer(c.Request.Context(), &performer); err != nil {
+		respondError(c, h.logger, "Failed to create performer", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, performer)
+}
+
+// @Summary List performers
+// @Description Get all performers ordered by name
+// @Tags performers
+// @Produce json
+// @Success 200 {array} models.Performer
+// @Failure 500 {object} Problem
+// @Router /api/v1/performers [get]
+func (h *PerformerHandler) ListPerformers(c *gin.Context) {
+	performers, err := h.service.ListPerformers(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, "Failed to list performers", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, performers)
+}
+
+// @Summary Get performer
+// @Description Get a performer by ID
+// @Tags performers
+// @Produce json
+// @Param id path int true "Performer ID"
+// @Success 200 {object} models.Performer
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/performers/{id} [get]
+func (h *PerformerHandler) GetPerformer(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	performer, err := h.service.GetPerformer(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, h.logger, "Failed to get performer", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, performer)
+}
+
+// @Summary Update performer
+// @Description Replace a performer and its availability windows
+// @Tags performers
+// @Accept json
+// @Produce json
+// @Param id path int true "Performer ID"
+// @Param performer body models.Performer true "Performer object"
+// @Success 200 {object} models.Performer
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/performers/{id} [put]
+func (h *PerformerHandler) UpdatePerformer(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	var performer models.Performer
+	if err := c.ShouldBindJSON(&performer); err != nil {
+		respondError(c, h.logger, "Failed to bind JSON", invalidInput(err))
+		return
+	}
+
+	performer.ID = id
+	if err := h.service.UpdatePerformer(c.Request.Context(), &performer); err != nil {
+		respondError(c, h.logger, "Failed to update performer", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, performer)
+}
+
+// @Summary Delete performer
+// @Description Delete a performer and remove it from all schedule items
+// @Tags performers
+// @Success 204 "No Content"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/performers/{id} [delete]
+func (h *PerformerHandler) DeletePerformer(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	if err := h.service.DeletePerformer(c.Request.Context(), id); err != nil {
+		respondError(c, h.logger, "Failed to delete performer", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get performer itinerary
+// @Description Get the appearances of a performer across all schedules ordered by start time
+// @Tags performers
+// @Produce json
+// @Param id path int true "Performer ID"
+// @Success 200 {object} services.Itinerary
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/performers/{id}/itinerary [get]
+func (h *PerformerHandler) GetItinerary(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	itinerary, err := h.service.GetItinerary(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, h.logger, "Failed to get performer itinerary", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, itinerary)
+}
+
+// @Summary Get performer itinerary as iCalendar
+// @Description Get the appearances of a performer across all schedules as an iCalendar (.ics) feed for calendar subscriptions
+// @Tags performers
+// @Produce text/calendar
+// @Param id path int true "Performer ID"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/performers/{id}/itinerary.ics [get]
+func (h *PerformerHandler) GetItineraryCalendar(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	body, err := h.service.GetItineraryCalendar(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, h.logger, "Failed to get performer itinerary", err)
+		return
+	}
+
+	c.Data(http.StatusOK, ical.ContentType, body)
+}
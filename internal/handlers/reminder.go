@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cor-events-scheduler/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ReminderHandler struct {
+	service *services.ReminderService
+	logger  *zap.Logger
+}
+
+func NewReminderHandler(service *services.ReminderService, logger *zap.Logger) *ReminderHandler {
+	return &ReminderHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary List reminders
+// @Description List the reminders planned before the start of each block and each item with performers, ordered by fire time.
+// @Description Reminders are recalculated whenever the schedule is saved; sent_at is set once a reminder has been taken for delivery.
+// @Tags reminders
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Success 200 {array} models.Reminder
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/reminders [get]
+func (h *ReminderHandler) ListReminders(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	reminders, err := h.service.ListReminders(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, h.logger, "Failed to list reminders", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reminders)
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ResourceHandler struct {
+	service *services.ResourceService
+	logger  *zap.Logger
+}
+
+func NewResourceHandler(service *services.ResourceService, logger *zap.Logger) *ResourceHandler {
+	return &ResourceHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary Create resource
+// @Description Create a shared resource such as a stage, room, projector or interpreter with its capacity
+// @Tags resources
+// @Accept json
+// @Produce json
+// @Param resource body models.Resource true "Resource object"
+// @Success 201 {object} models.Resource
+// @Failure 400 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/resources [post]
+func (h *ResourceHandler) CreateResource(c *gin.Context) {
+	var resource models.Resource
+	if err := c.ShouldBindJSON(&resource); err != nil {
+		respondError(c, h.logger, "Failed to bind JSON", invalidInput(err))
+		return
+	}
+
+	if err := h.service.CreateResource(c.Request.Context(), &resource); err != nil {
+		respondError(c, h.logger, "Failed to create resource", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resource)
+}
+
+// @Summary List resources
+// @Description Get all resources
+// @Tags resources
+// @Produce json
+// @Success 200 {array} models.Resource
+// @Failure 500 {object} Problem
+// @Router /api/v1/resources [get]
+func (h *ResourceHandler) ListResources(c *gin.Context) {
+	resources, err := h.service.ListResources(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, "Failed to list resources", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resources)
+}
+
+// @Summary Get resource
+// @Description Get a resource by ID
+// @Tags resources
+// @Produce json
+// @Param id path int true "Resource ID"
+// @Success 200 {object} models.Resource
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/resources/{id} [get]
+func (h *ResourceHandler) GetResource(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	resource, err := h.service.GetResource(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, h.logger, "Failed to get resource", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resource)
+}
+
+// @Summary Update resource
+// @Description Replace a resource
+// @Tags resources
+// @Accept json
+// @Produce json
+// @Param id path int true "Resource ID"
+// @Param resource body models.Resource true "Resource object"
+// @Success 200 {object} models.Resource
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/resources/{id} [put]
+func (h *ResourceHandler) UpdateResource(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	var resource models.Resource
+	if err := c.ShouldBindJSON(&resource); err != nil {
+		respondError(c, h.logger, "Failed to bind JSON", invalidInput(err))
+		return
+	}
+
+	resource.ID = id
+	if err := h.service.UpdateResource(c.Request.Context(), &resource); err != nil {
+		respondError(c, h.logger, "Failed to update resource", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resource)
+}
+
+// @Summary Delete resource
+// @Description Delete a resource and its bookings in all schedules
+// @Tags resources
+// @Success 204 "No Content"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/resources/{id} [delete]
+func (h *ResourceHandler) DeleteResource(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	if err := h.service.DeleteResource(c.Request.Context(), id); err != nil {
+		respondError(c, h.logger, "Failed to delete resource", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get resource free/busy
+// @Description Get the periods in [from, to) when the resource is booked across all schedules, with used and available units, and the periods when it is completely free
+// @Tags resources
+// @Produce json
+// @Param id path int true "Resource ID"
+// @Param from query string true "Range start, RFC 3339"
+// @Param to query string true "Range end, RFC 3339"
+// @Success 200 {object} services.FreeBusy
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/resources/{id}/freebusy [get]
+func (h *ResourceHandler) GetFreeBusy(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	var query services.FreeBusyQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondError(c, h.logger, "Failed to bind query", invalidInput(err))
+		return
+	}
+
+	freeBusy, err := h.service.GetFreeBusy(c.Request.Context(), id, query)
+	if err != nil {
+		respondError(c, h.logger, "Failed to get resource free/busy", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, freeBusy)
+}
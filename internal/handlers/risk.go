@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cor-events-scheduler/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type RiskHandler struct {
+	service *services.RiskService
+	logger  *zap.Logger
+}
+
+func NewRiskHandler(service *services.RiskService, logger *zap.Logger) *RiskHandler {
+	return &RiskHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary Analyze schedule risk
+// @Description Score the schedule and each block from 0 to 1 with per-factor explanations
+// @Tags schedules
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} risk.Assessment
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/risk [get]
+func (h *RiskHandler) GetScheduleRisk(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	assessment, err := h.service.AnalyzeSchedule(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, h.logger, "Failed to analyze schedule risk", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assessment)
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type RuleHandler struct {
+	service *services.RuleService
+	logger  *zap.Logger
+}
+
+func NewRuleHandler(service *services.RuleService, logger *zap.Logger) *RuleHandler {
+	return &RuleHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary Get organization rules
+// @Description Get the default venue rule set applied to schedules without their own rules
+// @Tags rules
+// @Produce json
+// @Success 200 {object} services.RuleSetDocument
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/rules [get]
+func (h *RuleHandler) GetOrganizationRules(c *gin.Context) {
+	h.getRuleSet(c, models.OrganizationRuleSetID)
+}
+
+// @Summary Replace organization rules
+// @Description Replace the default venue rule set. The body is YAML or JSON.
+// @Tags rules
+// @Accept plain
+// @Produce json
+// @Param rules body string true "Rule set in YAML or JSON"
+// @Success 200 {object} services.RuleSetDocument
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/rules [put]
+func (h *RuleHandler) SaveOrganizationRules(c *gin.Context) {
+	h.saveRuleSet(c, models.OrganizationRuleSetID)
+}
+
+// @Summary Delete organization rules
+// @Description Delete the default venue rule set
+// @Tags rules
+// @Success 204 "No Content"
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/rules [delete]
+func (h *RuleHandler) DeleteOrganizationRules(c *gin.Context) {
+	h.deleteRuleSet(c, models.OrganizationRuleSetID)
+}
+
+// @Summary Get schedule rules
+// @Description Get the venue rule set of a schedule
+// @Tags rules
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} services.RuleSetDocument
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/rules [get]
+func (h *RuleHandler) GetScheduleRules(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+	h.getRuleSet(c, id)
+}
+
+// @Summary Replace schedule rules
+// @Description Replace the venue rule set of a schedule; it takes precedence over the organization rules. The body is YAML or JSON.
+// @Tags rules
+// @Accept plain
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param rules body string true "Rule set in YAML or JSON"
+// @Success 200 {object} services.RuleSetDocument
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/rules [put]
+func (h *RuleHandler) SaveScheduleRules(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+	h.saveRuleSet(c, id)
+}
+
+// @Summary Delete schedule rules
+// @Description Delete the venue rule set of a schedule so that the organization rules apply
+// @Tags rules
+// @Param id path int true "Schedule ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/rules [delete]
+func (h *RuleHandler) DeleteScheduleRules(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+	h.deleteRuleSet(c, id)
+}
+
+func (h *RuleHandler) getRuleSet(c *gin.Context, scheduleID uint) {
+	document, err := h.service.GetRuleSet(c.Request.Context(), scheduleID)
+	if err != nil {
+		respondError(c, h.logger, "Failed to get rule set", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, document)
+}
+
+func (h *RuleHandler) saveRuleSet(c *gin.Context, scheduleID uint) {
+	source, err := c.GetRawData()
+	if err != nil {
+		respondError(c, h.logger, "Failed to read rule set", invalidInput(err))
+		return
+	}
+
+	document, err := h.service.SaveRuleSet(c.Request.Context(), scheduleID, source)
+	if err != nil {
+		respondError(c, h.logger, "Failed to save rule set", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, document)
+}
+
+func (h *RuleHandler) deleteRuleSet(c *gin.Context, scheduleID uint) {
+	if err := h.service.DeleteRuleSet(c.Request.Context(), scheduleID); err != nil {
+		respondError(c, h.logger, "Failed to delete rule set", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -3,6 +3,8 @@ package handlers
 import (
 	"cor-events-scheduler/internal/domain/models"
 	"cor-events-scheduler/internal/services"
+	"cor-events-scheduler/pkg/utils"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -23,36 +25,57 @@ func NewSchedulerHandler(service *services.SchedulerService, logger *zap.Logger)
 }
 
 // @Summary Create schedule
-// @Description Create a new schedule
+// @Description Create a new schedule.
+// @Description Since the venue rules release the response is the schedule with an added validation field (warnings only) instead of the bare schedule; clients with strict decoding must accept the extra field.
 // @Tags schedules
 // @Accept json
 // @Produce json
 // @Param schedule body models.Schedule true "Schedule object"
-// @Success 201 {object} models.Schedule
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Success 201 {object} ScheduleResponse
+// @Failure 400 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /api/v1/schedules [post]
 func (h *SchedulerHandler) CreateSchedule(c *gin.Context) {
 	var schedule models.Schedule
 	if err := c.ShouldBindJSON(&schedule); err != nil {
-		h.logger.Error("Failed to bind JSON", zap.Error(err))
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid request format",
-			Details: err.Error(),
-		})
+		respondError(c, h.logger, "Failed to bind JSON", invalidInput(err))
 		return
 	}
 
-	if err := h.service.CreateSchedule(c.Request.Context(), &schedule); err != nil {
-		h.logger.Error("Failed to create schedule", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to create schedule",
-			Details: err.Error(),
-		})
+	report, err := h.service.CreateSchedule(c.Request.Context(), &schedule)
+	if err != nil {
+		respondError(c, h.logger, "Failed to create schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ScheduleResponse{Schedule: &schedule, Validation: report})
+}
+
+// @Summary Validate schedule
+// @Description Check a schedule without saving it, including venue rules, and report every problem with its JSON pointer, code and severity
+// @Tags schedules
+// @Accept json
+// @Produce json
+// @Param schedule body models.Schedule true "Schedule object"
+// @Success 200 {object} validation.Report
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/validate [post]
+func (h *SchedulerHandler) ValidateSchedule(c *gin.Context) {
+	var schedule models.Schedule
+	if err := c.ShouldBindJSON(&schedule); err != nil {
+		respondError(c, h.logger, "Failed to bind JSON", invalidInput(err))
+		return
+	}
+
+	report, err := h.service.ValidateSchedule(c.Request.Context(), &schedule)
+	if err != nil {
+		respondError(c, h.logger, "Failed to validate schedule", err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, schedule)
+	c.JSON(http.StatusOK, report)
 }
 
 // @Summary Get schedule
@@ -62,27 +85,20 @@ func (h *SchedulerHandler) CreateSchedule(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Schedule ID"
 // @Success 200 {object} models.Schedule
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /api/v1/schedules/{id} [get]
 func (h *SchedulerHandler) GetSchedule(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, err := parseID(c, "id")
 	if err != nil {
-		h.logger.Error("Invalid ID format", zap.Error(err))
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid ID format",
-			Details: err.Error(),
-		})
+		respondError(c, h.logger, "Invalid ID format", err)
 		return
 	}
 
-	schedule, err := h.service.GetSchedule(c.Request.Context(), uint(id))
+	schedule, err := h.service.GetSchedule(c.Request.Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get schedule", zap.Error(err))
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error:   "Schedule not found",
-			Details: err.Error(),
-		})
+		respondError(c, h.logger, "Failed to get schedule", err)
 		return
 	}
 
@@ -90,49 +106,41 @@ func (h *SchedulerHandler) GetSchedule(c *gin.Context) {
 }
 
 // @Summary Update schedule
-// @Description Update an existing schedule
+// @Description Update an existing schedule.
+// @Description Since the venue rules release the response is the schedule with an added validation field (warnings only) instead of the bare schedule; clients with strict decoding must accept the extra field.
+// @Description Without the constraints field (or with null) the saved constraints are kept; an empty list removes them.
 // @Tags schedules
 // @Accept json
 // @Produce json
 // @Param id path int true "Schedule ID"
 // @Param schedule body models.Schedule true "Schedule object"
-// @Success 200 {object} models.Schedule
-// @Failure 400 {object} ErrorResponse
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Success 200 {object} ScheduleResponse
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /api/v1/schedules/{id} [put]
 func (h *SchedulerHandler) UpdateSchedule(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, err := parseID(c, "id")
 	if err != nil {
-		h.logger.Error("Invalid ID format", zap.Error(err))
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid ID format",
-			Details: err.Error(),
-		})
+		respondError(c, h.logger, "Invalid ID format", err)
 		return
 	}
 
 	var schedule models.Schedule
 	if err := c.ShouldBindJSON(&schedule); err != nil {
-		h.logger.Error("Failed to bind JSON", zap.Error(err))
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid request format",
-			Details: err.Error(),
-		})
+		respondError(c, h.logger, "Failed to bind JSON", invalidInput(err))
 		return
 	}
 
-	schedule.ID = uint(id)
-	if err := h.service.UpdateSchedule(c.Request.Context(), &schedule); err != nil {
-		h.logger.Error("Failed to update schedule", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to update schedule",
-			Details: err.Error(),
-		})
+	schedule.ID = id
+	report, err := h.service.UpdateSchedule(c.Request.Context(), &schedule)
+	if err != nil {
+		respondError(c, h.logger, "Failed to update schedule", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, schedule)
+	c.JSON(http.StatusOK, ScheduleResponse{Schedule: &schedule, Validation: report})
 }
 
 // @Summary Delete schedule
@@ -142,26 +150,19 @@ func (h *SchedulerHandler) UpdateSchedule(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Schedule ID"
 // @Success 204 "No Content"
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /api/v1/schedules/{id} [delete]
 func (h *SchedulerHandler) DeleteSchedule(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, err := parseID(c, "id")
 	if err != nil {
-		h.logger.Error("Invalid ID format", zap.Error(err))
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Invalid ID format",
-			Details: err.Error(),
-		})
+		respondError(c, h.logger, "Invalid ID format", err)
 		return
 	}
 
-	if err := h.service.DeleteSchedule(c.Request.Context(), uint(id)); err != nil {
-		h.logger.Error("Failed to delete schedule", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to delete schedule",
-			Details: err.Error(),
-		})
+	if err := h.service.DeleteSchedule(c.Request.Context(), id); err != nil {
+		respondError(c, h.logger, "Failed to delete schedule", err)
 		return
 	}
 
@@ -169,35 +170,53 @@ func (h *SchedulerHandler) DeleteSchedule(c *gin.Context) {
 }
 
 // @Summary List schedules
-// @Description Get a paginated list of schedules
+// @Description Get a keyset-paginated list of schedule summaries (ListSchedulesResponse), or full schedules with include=blocks (ListFullSchedulesResponse).
+// @Description Breaking change: page-based pagination was replaced by cursors, meta no longer has page, page_size and total, page other than 1 is rejected with 400, and page_size is accepted as an alias for limit.
 // @Tags schedules
 // @Accept json
 // @Produce json
-// @Param page query int false "Page number" default(1)
-// @Param page_size query int false "Items per page" default(10)
-// @Success 200 {object} ListSchedulesResponse
-// @Failure 500 {object} ErrorResponse
+// @Param limit query int false "Items per page" default(10)
+// @Param page_size query int false "Deprecated alias for limit, used when limit is not set"
+// @Param cursor query string false "Opaque cursor from meta.next_cursor of the previous page"
+// @Param include query string false "Set to 'blocks' to return full schedules with blocks and items"
+// @Success 200 {object} ListSchedulesResponse "Summaries; with include=blocks the data holds full schedules as in ListFullSchedulesResponse"
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /api/v1/schedules [get]
 func (h *SchedulerHandler) ListSchedules(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	// page_size — прежнее имя limit; принимается, чтобы старые клиенты с
+	// page=1 получали страницу запрошенного размера
+	limitParam := c.Query("limit")
+	if limitParam == "" {
+		limitParam = c.DefaultQuery("page_size", "10")
+	}
+	limit, _ := strconv.Atoi(limitParam)
+	pagination := utils.PaginationParams{PageSize: limit}
+	limit = pagination.GetLimit()
+	cursor := c.Query("cursor")
+
+	// Постраничная навигация заменена курсорной: клиент, который просит
+	// следующую страницу по номеру, получил бы первую страницу снова
+	if page := c.Query("page"); page != "" && page != "1" {
+		respondError(c, h.logger, "Page-based listing is no longer supported",
+			fmt.Errorf("%w: page is no longer supported, follow meta.next_cursor instead", utils.ErrInvalidInput))
+		return
+	}
 
-	schedules, total, err := h.service.ListSchedules(c.Request.Context(), page, pageSize)
-	if err != nil {
-		h.logger.Error("Failed to list schedules", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to list schedules",
-			Details: err.Error(),
-		})
+	if c.Query("include") == "blocks" {
+		schedules, nextCursor, err := h.service.ListSchedules(c.Request.Context(), cursor, limit)
+		if err != nil {
+			respondError(c, h.logger, "Failed to list schedules", err)
+			return
+		}
+		c.JSON(http.StatusOK, ListFullSchedulesResponse{Data: schedules, Meta: newCursorMeta(limit, nextCursor)})
 		return
 	}
 
-	c.JSON(http.StatusOK, ListSchedulesResponse{
-		Data: schedules,
-		Meta: PaginationMeta{
-			Page:     page,
-			PageSize: pageSize,
-			Total:    int(total),
-		},
-	})
+	summaries, nextCursor, err := h.service.ListScheduleSummaries(c.Request.Context(), cursor, limit)
+	if err != nil {
+		respondError(c, h.logger, "Failed to list schedules", err)
+		return
+	}
+	c.JSON(http.StatusOK, ListSchedulesResponse{Data: summaries, Meta: newCursorMeta(limit, nextCursor)})
 }
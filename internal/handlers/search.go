@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/services"
+	"cor-events-scheduler/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type SearchHandler struct {
+	service *services.SearchService
+	logger  *zap.Logger
+}
+
+func NewSearchHandler(service *services.SearchService, logger *zap.Logger) *SearchHandler {
+	return &SearchHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type SearchResponse struct {
+	Query string             `json:"query"`
+	Hits  []models.SearchHit `json:"hits"`
+}
+
+// @Summary Search schedules
+// @Description Full-text search across schedule, block and item names and descriptions
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "Search query"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Success 200 {object} SearchResponse
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		respondError(c, h.logger, "Missing search query", invalidInput(errors.New("query parameter q is required")))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	pagination := utils.PaginationParams{Page: page, PageSize: pageSize}
+
+	hits, err := h.service.Search(c.Request.Context(), query, pagination.GetOffset(), pagination.GetLimit())
+	if err != nil {
+		respondError(c, h.logger, "Failed to search schedules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SearchResponse{
+		Query: query,
+		Hits:  hits,
+	})
+}
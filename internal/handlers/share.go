@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cor-events-scheduler/internal/services"
+	"cor-events-scheduler/internal/web"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ShareHandler struct {
+	service *services.ShareService
+	logger  *zap.Logger
+}
+
+func NewShareHandler(service *services.ShareService, logger *zap.Logger) *ShareHandler {
+	return &ShareHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary Create share link
+// @Description Issue a signed read-only link to one view of the schedule, optionally pinned to one version.
+// @Description The link expires after 7 days unless expires_at is given, and no later than in 90 days. Performer views cannot be shared.
+// @Tags share-links
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param link body services.ShareLinkRequest true "Link scope"
+// @Success 201 {object} models.ShareLink
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/share-links [post]
+func (h *ShareHandler) CreateLink(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	var request services.ShareLinkRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, h.logger, "Failed to bind JSON", invalidInput(err))
+		return
+	}
+
+	link, err := h.service.CreateLink(c.Request.Context(), id, request)
+	if err != nil {
+		respondError(c, h.logger, "Failed to create share link", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
+
+// @Summary List share links
+// @Description List the active share links of the schedule with their tokens and access counts. With all=true revoked and expired links are included, without tokens.
+// @Tags share-links
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param all query bool false "Include revoked and expired links"
+// @Success 200 {array} models.ShareLink
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/share-links [get]
+func (h *ShareHandler) ListLinks(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	var query services.ShareLinksQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondError(c, h.logger, "Failed to bind query", invalidInput(err))
+		return
+	}
+
+	links, err := h.service.ListLinks(c.Request.Context(), id, query)
+	if err != nil {
+		respondError(c, h.logger, "Failed to list share links", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// @Summary Revoke share link
+// @Description Revoke a share link; its token stops working immediately. Revoking twice keeps the first revocation time.
+// @Tags share-links
+// @Param id path int true "Schedule ID"
+// @Param link_id path int true "Share link ID"
+// @Success 204
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/share-links/{link_id} [delete]
+func (h *ShareHandler) RevokeLink(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+	linkID, err := parseID(c, "link_id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid link ID format", err)
+		return
+	}
+
+	if err := h.service.RevokeLink(c.Request.Context(), id, linkID); err != nil {
+		respondError(c, h.logger, "Failed to revoke share link", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get shared schedule
+// @Description Get the schedule projected through the view of a share link. Forged, expired and revoked tokens all give 404.
+// @Tags shared
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} object
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/shared/{token} [get]
+func (h *ShareHandler) GetSharedView(c *gin.Context) {
+	object, err := h.service.SharedView(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		respondError(c, h.logger, "Failed to open share link", err)
+		return
+	}
+
+	setSharedHeaders(c)
+	c.JSON(http.StatusOK, object)
+}
+
+// @Summary Get shared text schedule
+// @Description Get a text representation of the schedule in the view of a share link
+// @Tags shared
+// @Produce text/plain
+// @Param token path string true "Share token"
+// @Success 200 {string} string
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/shared/{token}/text [get]
+func (h *ShareHandler) GetSharedText(c *gin.Context) {
+	text, err := h.service.SharedText(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		respondError(c, h.logger, "Failed to open share link", err)
+		return
+	}
+
+	setSharedHeaders(c)
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.String(http.StatusOK, text)
+}
+
+// @Summary Get shared schedule page
+// @Description Server-rendered HTML page of the schedule in the view of a share link, with the same print stylesheet as the public page.
+// @Tags shared
+// @Produce html
+// @Param token path string true "Share token"
+// @Success 200 {string} string
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /s/{token} [get]
+func (h *ShareHandler) GetSharedPage(c *gin.Context) {
+	body, err := h.service.SharedPage(c.Request.Context(), c.Param("token"), web.Options{})
+	if err != nil {
+		respondError(c, h.logger, "Failed to open share link", err)
+		return
+	}
+
+	setSharedHeaders(c)
+	c.Header("Content-Security-Policy", "frame-ancestors 'self'")
+	c.Data(http.StatusOK, web.ContentType, body)
+}
+
+// setSharedHeaders запрещает кэшировать ответ по ссылке, чтобы отзыв действовал
+// сразу и каждое обращение попадало в счетчик, и не передает токен в Referer
+func setSharedHeaders(c *gin.Context) {
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+}
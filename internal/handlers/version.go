@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cor-events-scheduler/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type VersionHandler struct {
+	service *services.VersionService
+	logger  *zap.Logger
+}
+
+func NewVersionHandler(service *services.VersionService, logger *zap.Logger) *VersionHandler {
+	return &VersionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary List schedule versions
+// @Description Get version history of a schedule, newest first
+// @Tags versions
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Success 200 {array} models.VersionMetadata
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/versions [get]
+func (h *VersionHandler) GetVersionHistory(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return
+	}
+
+	history, err := h.service.GetVersionHistory(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, h.logger, "Failed to get version history", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// @Summary Get schedule version
+// @Description Get a single schedule version with its snapshot
+// @Tags versions
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param version path int true "Version number"
+// @Success 200 {object} models.ScheduleVersion
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/versions/{version} [get]
+func (h *VersionHandler) GetVersion(c *gin.Context) {
+	id, version, ok := h.parseVersionParams(c)
+	if !ok {
+		return
+	}
+
+	scheduleVersion, err := h.service.GetVersion(c.Request.Context(), id, version)
+	if err != nil {
+		respondError(c, h.logger, "Failed to get version", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scheduleVersion)
+}
+
+// @Summary Restore schedule version
+// @Description Restore a schedule from a version snapshot and record it as a new version
+// @Tags versions
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param version path int true "Version number"
+// @Param X-User header string false "Author of the restore"
+// @Success 200 {object} models.Schedule
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/versions/{version}/restore [post]
+func (h *VersionHandler) RestoreVersion(c *gin.Context) {
+	id, version, ok := h.parseVersionParams(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.service.RestoreVersion(c.Request.Context(), id, version, c.GetHeader("X-User"))
+	if err != nil {
+		respondError(c, h.logger, "Failed to restore version", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// @Summary Get version changes
+// @Description Compare the snapshot of a version with the previous one, or with version from (0 compares with the schedule without blocks).
+// @Description Blocks and items are matched by ID; the changes are returned as records and as sentences in lang, by default the schedule language.
+// @Tags versions
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param version path int true "Version number"
+// @Param from query int false "Version to compare with"
+// @Param lang query string false "Text language: ru or en"
+// @Success 200 {object} services.VersionChanges
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/schedules/{id}/versions/{version}/changes [get]
+func (h *VersionHandler) GetVersionChanges(c *gin.Context) {
+	id, version, ok := h.parseVersionParams(c)
+	if !ok {
+		return
+	}
+
+	var query services.VersionChangesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondError(c, h.logger, "Failed to bind query", invalidInput(err))
+		return
+	}
+
+	changes, err := h.service.CompareVersions(c.Request.Context(), id, version, query)
+	if err != nil {
+		respondError(c, h.logger, "Failed to compare versions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
+
+func (h *VersionHandler) parseVersionParams(c *gin.Context) (uint, int, bool) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		respondError(c, h.logger, "Invalid ID format", err)
+		return 0, 0, false
+	}
+
+	version, err := parseID(c, "version")
+	if err != nil {
+		respondError(c, h.logger, "Invalid version format", err)
+		return 0, 0, false
+	}
+
+	return id, int(version), true
+}
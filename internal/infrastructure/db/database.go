@@ -2,42 +2,81 @@
 package db
 
 import (
+	"context"
 	"cor-events-scheduler/internal/config"
-	"cor-events-scheduler/internal/domain/models"
+	"database/sql"
 	"fmt"
 	"log"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// NewDatabase подключается к базе и проверяет, что все миграции применены.
+// Схема не меняется при старте: миграции применяются командой migrate.
 func NewDatabase(cfg *config.Config) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.DBName,
-	)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+	db, err := Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrator.EnsureCurrent(context.Background()); err != nil {
+		return nil, err
+	}
+
+	log.Println("Database connection established, schema is up to date")
+	return db, nil
+}
+
+// Open подключается к базе без проверки схемы
+func Open(cfg *config.Config) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+
+	switch cfg.Database.Driver {
+	case config.DriverSQLite:
+		// Внешние ключи в SQLite по умолчанию выключены; WAL позволяет читать во время записи
+		dsn := fmt.Sprintf("%s?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)",
+			cfg.Database.Path,
+		)
+		sqlDB, err := sql.Open(sqlite.DriverName, dsn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		dialector = &sqlite.Dialector{DSN: dsn, Conn: newUTCPool(sqlDB)}
+	default:
+		dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+			cfg.Database.Host,
+			cfg.Database.Port,
+			cfg.Database.User,
+			cfg.Database.Password,
+			cfg.Database.DBName,
+		)
+		dialector = postgres.Open(dsn)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
+		// Ошибки драйвера приводятся к gorm.ErrDuplicatedKey и т.п. для сопоставления с ошибками домена
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	// Обновленная миграция только для необходимых моделей
-	if err := db.AutoMigrate(
-		&models.Schedule{},
-		&models.Block{},
-		&models.BlockItem{},
-		&models.ScheduleVersion{},
-	); err != nil {
-		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	if cfg.Database.Driver == config.DriverSQLite {
+		// SQLite допускает только одного писателя; одно соединение исключает SQLITE_BUSY
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get database handle: %w", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
 	}
 
-	log.Println("Database connection established and migrations completed")
 	return db, nil
 }
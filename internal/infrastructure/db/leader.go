@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"cor-events-scheduler/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+var (
+	_ domain.LeaderLock = (*AdvisoryLock)(nil)
+	_ domain.LeaderLock = (*LocalLock)(nil)
+)
+
+// NewLeaderLock возвращает выбор ведущего по ключу key: в PostgreSQL — через
+// advisory lock, общий для всех подов; SQLite обслуживает один процесс,
+// поэтому он всегда ведущий
+func NewLeaderLock(db *gorm.DB, key int64) (domain.LeaderLock, error) {
+	if db.Dialector.Name() != "postgres" {
+		return NewLocalLock(), nil
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database handle: %w", err)
+	}
+	return NewAdvisoryLock(sqlDB, key), nil
+}
+
+// AdvisoryLock держит pg_try_advisory_lock на выделенном соединении. Если
+// соединение обрывается, PostgreSQL снимает блокировку сам и ее может взять
+// другой под.
+type AdvisoryLock struct {
+	db  *sql.DB
+	key int64
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+func NewAdvisoryLock(db *sql.DB, key int64) *AdvisoryLock {
+	return &AdvisoryLock{db: db, key: key}
+}
+
+// Acquire берет блокировку или проверяет, что уже взятая жива
+func (l *AdvisoryLock) Acquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		if err := l.conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+		// Соединение потеряно вместе с блокировкой
+		l.conn.Close()
+		l.conn = nil
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&acquired); err != nil {
+		conn.Close()
+		return false, fmt.Errorf("failed to try leader lock: %w", err)
+	}
+	if !acquired {
+		conn.Close()
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+// Release снимает блокировку и возвращает соединение в пул
+func (l *AdvisoryLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+	defer func() {
+		l.conn.Close()
+		l.conn = nil
+	}()
+	if _, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		return fmt.Errorf("failed to release leader lock: %w", err)
+	}
+	return nil
+}
+
+// LocalLock — выбор ведущего для хранилищ одного процесса: всегда ведущий
+type LocalLock struct{}
+
+func NewLocalLock() *LocalLock {
+	return &LocalLock{}
+}
+
+func (LocalLock) Acquire(context.Context) (bool, error) { return true, nil }
+
+func (LocalLock) Release(context.Context) error { return nil }
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestNewLeaderLockForSQLite(t *testing.T) {
+	lock, err := NewLeaderLock(openSQLiteTestDB(t), 1)
+	if err != nil {
+		t.Fatalf("NewLeaderLock: %v", err)
+	}
+	if _, ok := lock.(*LocalLock); !ok {
+		t.Fatalf("want LocalLock for SQLite, got %T", lock)
+	}
+	if leader, err := lock.Acquire(context.Background()); err != nil || !leader {
+		t.Fatalf("local lock must always lead: %v, %v", leader, err)
+	}
+}
+
+// Проверка PostgreSQL запускается с TEST_DATABASE_DSN, как набор проверок репозиториев
+func TestAdvisoryLockElectsOneLeader(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	database, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		t.Fatalf("failed to get database handle: %v", err)
+	}
+
+	ctx := context.Background()
+	const key = 7_202_504_199
+	first, second := NewAdvisoryLock(sqlDB, key), NewAdvisoryLock(sqlDB, key)
+
+	if leader, err := first.Acquire(ctx); err != nil || !leader {
+		t.Fatalf("first must lead: %v, %v", leader, err)
+	}
+	if leader, err := second.Acquire(ctx); err != nil || leader {
+		t.Fatalf("second must wait while first leads: %v, %v", leader, err)
+	}
+	if leader, err := first.Acquire(ctx); err != nil || !leader {
+		t.Fatalf("first must keep leading: %v, %v", leader, err)
+	}
+
+	if err := first.Release(ctx); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if leader, err := second.Acquire(ctx); err != nil || !leader {
+		t.Fatalf("second must lead after release: %v, %v", leader, err)
+	}
+	if err := second.Release(ctx); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS schedule_versions;
+DROP TABLE IF EXISTS block_items;
+DROP TABLE IF EXISTS blocks;
+DROP TABLE IF EXISTS schedules;
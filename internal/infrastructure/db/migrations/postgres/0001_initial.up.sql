@@ -0,0 +1,53 @@
+-- Базовая схема. IF NOT EXISTS позволяет принять базы, созданные прежним AutoMigrate.
+CREATE TABLE IF NOT EXISTS schedules (
+    id         BIGSERIAL PRIMARY KEY,
+    name       TEXT        NOT NULL,
+    start_date TIMESTAMPTZ NOT NULL,
+    end_date   TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_schedules_deleted_at ON schedules (deleted_at);
+
+CREATE TABLE IF NOT EXISTS blocks (
+    id                  BIGSERIAL PRIMARY KEY,
+    schedule_id         BIGINT      NOT NULL,
+    name                TEXT        NOT NULL,
+    type                TEXT,
+    start_time          TIMESTAMPTZ,
+    duration            BIGINT      NOT NULL,
+    tech_break_duration BIGINT,
+    "order"             BIGINT      NOT NULL,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at          TIMESTAMPTZ,
+    CONSTRAINT fk_schedules_blocks FOREIGN KEY (schedule_id) REFERENCES schedules (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_blocks_deleted_at ON blocks (deleted_at);
+
+CREATE TABLE IF NOT EXISTS block_items (
+    id          BIGSERIAL PRIMARY KEY,
+    block_id    BIGINT      NOT NULL,
+    name        TEXT        NOT NULL,
+    type        TEXT,
+    description TEXT,
+    duration    BIGINT      NOT NULL,
+    "order"     BIGINT      NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at  TIMESTAMPTZ,
+    CONSTRAINT fk_blocks_items FOREIGN KEY (block_id) REFERENCES blocks (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_block_items_deleted_at ON block_items (deleted_at);
+
+CREATE TABLE IF NOT EXISTS schedule_versions (
+    id          BIGSERIAL PRIMARY KEY,
+    schedule_id BIGINT,
+    version     BIGINT,
+    data        JSONB,
+    changes     TEXT,
+    created_by  TEXT,
+    created_at  TIMESTAMPTZ,
+    is_active   BOOLEAN
+);
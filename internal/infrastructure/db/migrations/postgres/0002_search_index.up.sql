@@ -0,0 +1,13 @@
+-- Поисковый индекс по расписаниям, блокам и элементам; document заполняется репозиторием
+CREATE TABLE IF NOT EXISTS search_entries (
+    id          BIGSERIAL PRIMARY KEY,
+    schedule_id BIGINT NOT NULL,
+    block_id    BIGINT,
+    item_id     BIGINT,
+    kind        TEXT   NOT NULL,
+    title       TEXT,
+    body        TEXT,
+    document    TSVECTOR
+);
+CREATE INDEX IF NOT EXISTS idx_search_entries_schedule_id ON search_entries (schedule_id);
+CREATE INDEX IF NOT EXISTS idx_search_entries_document ON search_entries USING GIN (document);
@@ -0,0 +1,3 @@
+-- Индексы для выборки блоков расписания и элементов блока
+CREATE INDEX IF NOT EXISTS idx_blocks_schedule_id ON blocks (schedule_id);
+CREATE INDEX IF NOT EXISTS idx_block_items_block_id ON block_items (block_id);
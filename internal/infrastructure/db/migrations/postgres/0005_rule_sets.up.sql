@@ -0,0 +1,6 @@
+-- Наборы правил площадки; schedule_id = 0 — набор организации по умолчанию
+CREATE TABLE rule_sets (
+    schedule_id BIGINT      PRIMARY KEY,
+    source      TEXT        NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,12 @@
+-- Ограничения между блоками расписания; блоки задаются по имени
+CREATE TABLE block_constraints (
+    id           BIGSERIAL PRIMARY KEY,
+    schedule_id  BIGINT NOT NULL,
+    type         TEXT   NOT NULL,
+    block        TEXT   NOT NULL,
+    target_block TEXT,
+    target_type  TEXT,
+    minutes      BIGINT,
+    CONSTRAINT fk_schedules_constraints FOREIGN KEY (schedule_id) REFERENCES schedules (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_block_constraints_schedule_id ON block_constraints (schedule_id);
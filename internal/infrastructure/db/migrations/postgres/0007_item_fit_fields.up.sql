@@ -0,0 +1,3 @@
+-- Приоритет элемента и минимальная длительность для подгонки расписания
+ALTER TABLE block_items ADD COLUMN IF NOT EXISTS priority BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE block_items ADD COLUMN IF NOT EXISTS min_duration BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE block_items DROP COLUMN IF EXISTS end_time;
+ALTER TABLE block_items DROP COLUMN IF EXISTS start_time;
+ALTER TABLE blocks DROP COLUMN IF EXISTS slack_policy;
+ALTER TABLE blocks DROP COLUMN IF EXISTS item_gap;
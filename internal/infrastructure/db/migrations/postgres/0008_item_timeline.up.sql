@@ -0,0 +1,6 @@
+-- Перестановка между элементами блока и размещение свободного времени блока
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS item_gap BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS slack_policy TEXT NOT NULL DEFAULT '';
+-- Рассчитанные времена начала и конца элементов
+ALTER TABLE block_items ADD COLUMN IF NOT EXISTS start_time TIMESTAMPTZ;
+ALTER TABLE block_items ADD COLUMN IF NOT EXISTS end_time TIMESTAMPTZ;
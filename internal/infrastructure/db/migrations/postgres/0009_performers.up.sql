@@ -0,0 +1,29 @@
+-- Выступающие, общие для всех расписаний
+CREATE TABLE performers (
+    id             BIGSERIAL PRIMARY KEY,
+    name           TEXT        NOT NULL,
+    contact        TEXT,
+    buffer_minutes BIGINT      NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Окна доступности выступающих
+CREATE TABLE performer_availability (
+    id           BIGSERIAL PRIMARY KEY,
+    performer_id BIGINT      NOT NULL,
+    start_time   TIMESTAMPTZ NOT NULL,
+    end_time     TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_performers_availability FOREIGN KEY (performer_id) REFERENCES performers (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_performer_availability_performer_id ON performer_availability (performer_id);
+
+-- Связи элементов блоков с выступающими
+CREATE TABLE item_performers (
+    item_id      BIGINT NOT NULL,
+    performer_id BIGINT NOT NULL,
+    PRIMARY KEY (item_id, performer_id),
+    CONSTRAINT fk_item_performers_item FOREIGN KEY (item_id) REFERENCES block_items (id) ON DELETE CASCADE,
+    CONSTRAINT fk_item_performers_performer FOREIGN KEY (performer_id) REFERENCES performers (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_item_performers_performer_id ON item_performers (performer_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS resource_bookings;
+DROP TABLE IF EXISTS resources;
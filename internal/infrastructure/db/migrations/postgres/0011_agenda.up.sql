@@ -0,0 +1,8 @@
+-- Трек блока (сцена, поток программы) для фильтрации ленты
+ALTER TABLE blocks ADD COLUMN track TEXT NOT NULL DEFAULT '';
+-- Конец блока без техперерыва для выборок по интервалу времени
+ALTER TABLE blocks ADD COLUMN end_time TIMESTAMPTZ;
+UPDATE blocks SET end_time = start_time + duration * INTERVAL '1 minute' WHERE start_time IS NOT NULL;
+
+CREATE INDEX idx_blocks_time_range ON blocks (start_time, end_time);
+CREATE INDEX idx_block_items_time_range ON block_items (start_time, end_time);
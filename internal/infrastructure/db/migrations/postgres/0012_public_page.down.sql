@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_schedules_slug;
+ALTER TABLE schedules DROP COLUMN IF EXISTS timezone;
+ALTER TABLE schedules DROP COLUMN IF EXISTS language;
+ALTER TABLE schedules DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,7 @@
+-- Адрес, язык и часовой пояс публичной страницы расписания /p/{slug}
+ALTER TABLE schedules ADD COLUMN slug TEXT NOT NULL DEFAULT '';
+ALTER TABLE schedules ADD COLUMN language TEXT NOT NULL DEFAULT '';
+ALTER TABLE schedules ADD COLUMN timezone TEXT NOT NULL DEFAULT '';
+
+-- Адрес уникален среди неудаленных расписаний, у которых он задан
+CREATE UNIQUE INDEX idx_schedules_slug ON schedules (slug) WHERE slug <> '' AND deleted_at IS NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE block_items DROP COLUMN IF EXISTS notes;
+ALTER TABLE block_items DROP COLUMN IF EXISTS visibility;
+ALTER TABLE block_items DROP COLUMN IF EXISTS call_offset;
+ALTER TABLE blocks DROP COLUMN IF EXISTS notes;
+ALTER TABLE blocks DROP COLUMN IF EXISTS visibility;
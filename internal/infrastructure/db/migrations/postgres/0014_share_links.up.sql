@@ -0,0 +1,16 @@
+-- Ссылки для просмотра расписания без учетной записи. Сам токен не хранится:
+-- он подписывается заново из полей ссылки.
+CREATE TABLE share_links (
+    id               BIGSERIAL PRIMARY KEY,
+    schedule_id      BIGINT      NOT NULL,
+    view             TEXT        NOT NULL,
+    version          BIGINT,
+    label            TEXT        NOT NULL DEFAULT '',
+    expires_at       TIMESTAMPTZ NOT NULL,
+    revoked_at       TIMESTAMPTZ,
+    access_count     BIGINT      NOT NULL DEFAULT 0,
+    last_accessed_at TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_share_links_schedule FOREIGN KEY (schedule_id) REFERENCES schedules (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_share_links_schedule_id ON share_links (schedule_id);
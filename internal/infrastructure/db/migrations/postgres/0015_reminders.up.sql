@@ -0,0 +1,16 @@
+-- Напоминания о начале блоков и выступлений. sent_at заполняется до отправки,
+-- поэтому после перезапуска напоминание не отправляется повторно.
+CREATE TABLE reminders (
+    id          BIGSERIAL PRIMARY KEY,
+    schedule_id BIGINT      NOT NULL,
+    block_id    BIGINT      NOT NULL,
+    item_id     BIGINT      NOT NULL DEFAULT 0,
+    minutes     INTEGER     NOT NULL,
+    starts_at   TIMESTAMPTZ NOT NULL,
+    fire_at     TIMESTAMPTZ NOT NULL,
+    sent_at     TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_reminders_schedule FOREIGN KEY (schedule_id) REFERENCES schedules (id) ON DELETE CASCADE,
+    CONSTRAINT uq_reminders_target UNIQUE (schedule_id, block_id, item_id, minutes)
+);
+CREATE INDEX idx_reminders_due ON reminders (fire_at) WHERE sent_at IS NULL;
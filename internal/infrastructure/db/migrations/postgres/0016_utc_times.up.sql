@@ -0,0 +1,2 @@
+-- TIMESTAMPTZ сравнивается как момент времени; перевод в UTC нужен только SQLite
+SELECT 1;
@@ -0,0 +1,2 @@
+ALTER TABLE block_constraints DROP COLUMN IF EXISTS target_block_id;
+ALTER TABLE block_constraints DROP COLUMN IF EXISTS block_id;
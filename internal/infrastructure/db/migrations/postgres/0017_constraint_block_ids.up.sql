@@ -0,0 +1,25 @@
+-- Ограничения ссылаются на блоки по ID, имя остается для новых блоков без ID
+ALTER TABLE block_constraints ADD COLUMN block_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE block_constraints ADD COLUMN target_block_id BIGINT NOT NULL DEFAULT 0;
+
+-- Сохраненные ограничения получают ID блоков с однозначными именами
+UPDATE block_constraints SET block_id = (
+    SELECT MIN(b.id) FROM blocks b
+    WHERE b.schedule_id = block_constraints.schedule_id AND b.deleted_at IS NULL
+      AND TRIM(b.name) = TRIM(block_constraints.block)
+)
+WHERE (
+    SELECT COUNT(*) FROM blocks b
+    WHERE b.schedule_id = block_constraints.schedule_id AND b.deleted_at IS NULL
+      AND TRIM(b.name) = TRIM(block_constraints.block)
+) = 1;
+UPDATE block_constraints SET target_block_id = (
+    SELECT MIN(b.id) FROM blocks b
+    WHERE b.schedule_id = block_constraints.schedule_id AND b.deleted_at IS NULL
+      AND TRIM(b.name) = TRIM(block_constraints.target_block)
+)
+WHERE (
+    SELECT COUNT(*) FROM blocks b
+    WHERE b.schedule_id = block_constraints.schedule_id AND b.deleted_at IS NULL
+      AND TRIM(b.name) = TRIM(block_constraints.target_block)
+) = 1;
@@ -0,0 +1,52 @@
+CREATE TABLE IF NOT EXISTS schedules (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    name       TEXT     NOT NULL,
+    start_date DATETIME NOT NULL,
+    end_date   DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_schedules_deleted_at ON schedules (deleted_at);
+
+CREATE TABLE IF NOT EXISTS blocks (
+    id                  INTEGER PRIMARY KEY AUTOINCREMENT,
+    schedule_id         INTEGER  NOT NULL,
+    name                TEXT     NOT NULL,
+    type                TEXT,
+    start_time          DATETIME,
+    duration            INTEGER  NOT NULL,
+    tech_break_duration INTEGER,
+    "order"             INTEGER  NOT NULL,
+    created_at          DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at          DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at          DATETIME,
+    CONSTRAINT fk_schedules_blocks FOREIGN KEY (schedule_id) REFERENCES schedules (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_blocks_deleted_at ON blocks (deleted_at);
+
+CREATE TABLE IF NOT EXISTS block_items (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    block_id    INTEGER  NOT NULL,
+    name        TEXT     NOT NULL,
+    type        TEXT,
+    description TEXT,
+    duration    INTEGER  NOT NULL,
+    "order"     INTEGER  NOT NULL,
+    created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at  DATETIME,
+    CONSTRAINT fk_blocks_items FOREIGN KEY (block_id) REFERENCES blocks (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_block_items_deleted_at ON block_items (deleted_at);
+
+CREATE TABLE IF NOT EXISTS schedule_versions (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    schedule_id INTEGER,
+    version     INTEGER,
+    data        TEXT,
+    changes     TEXT,
+    created_by  TEXT,
+    created_at  DATETIME,
+    is_active   NUMERIC
+);
@@ -0,0 +1,11 @@
+-- Поисковый индекс как виртуальная таблица FTS5. Токенизатор porter дает стемминг
+-- для английского; русский текст ищется по словоформам без учета регистра.
+CREATE VIRTUAL TABLE IF NOT EXISTS search_entries USING fts5(
+    title,
+    body,
+    kind UNINDEXED,
+    schedule_id UNINDEXED,
+    block_id UNINDEXED,
+    item_id UNINDEXED,
+    tokenize = 'porter unicode61 remove_diacritics 2'
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_block_items_block_id;
+DROP INDEX IF EXISTS idx_blocks_schedule_id;
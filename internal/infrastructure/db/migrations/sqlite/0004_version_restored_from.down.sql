@@ -0,0 +1 @@
+ALTER TABLE schedule_versions DROP COLUMN restored_from;
@@ -0,0 +1,2 @@
+-- Номер версии, из которой была восстановлена версия
+ALTER TABLE schedule_versions ADD COLUMN restored_from INTEGER;
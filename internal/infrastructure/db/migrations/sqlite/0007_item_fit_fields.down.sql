@@ -0,0 +1,2 @@
+ALTER TABLE block_items DROP COLUMN min_duration;
+ALTER TABLE block_items DROP COLUMN priority;
@@ -0,0 +1,3 @@
+-- Приоритет элемента и минимальная длительность для подгонки расписания
+ALTER TABLE block_items ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE block_items ADD COLUMN min_duration INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE block_items DROP COLUMN end_time;
+ALTER TABLE block_items DROP COLUMN start_time;
+ALTER TABLE blocks DROP COLUMN slack_policy;
+ALTER TABLE blocks DROP COLUMN item_gap;
@@ -0,0 +1,6 @@
+-- Перестановка между элементами блока и размещение свободного времени блока
+ALTER TABLE blocks ADD COLUMN item_gap INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE blocks ADD COLUMN slack_policy TEXT NOT NULL DEFAULT '';
+-- Рассчитанные времена начала и конца элементов
+ALTER TABLE block_items ADD COLUMN start_time DATETIME;
+ALTER TABLE block_items ADD COLUMN end_time DATETIME;
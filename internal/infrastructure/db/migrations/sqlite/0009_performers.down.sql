@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS item_performers;
+DROP TABLE IF EXISTS performer_availability;
+DROP TABLE IF EXISTS performers;
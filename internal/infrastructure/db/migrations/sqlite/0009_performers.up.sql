@@ -0,0 +1,29 @@
+-- Выступающие, общие для всех расписаний
+CREATE TABLE performers (
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    name           TEXT     NOT NULL,
+    contact        TEXT,
+    buffer_minutes INTEGER  NOT NULL DEFAULT 0,
+    created_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Окна доступности выступающих
+CREATE TABLE performer_availability (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    performer_id INTEGER  NOT NULL,
+    start_time   DATETIME NOT NULL,
+    end_time     DATETIME NOT NULL,
+    CONSTRAINT fk_performers_availability FOREIGN KEY (performer_id) REFERENCES performers (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_performer_availability_performer_id ON performer_availability (performer_id);
+
+-- Связи элементов блоков с выступающими
+CREATE TABLE item_performers (
+    item_id      INTEGER NOT NULL,
+    performer_id INTEGER NOT NULL,
+    PRIMARY KEY (item_id, performer_id),
+    CONSTRAINT fk_item_performers_item FOREIGN KEY (item_id) REFERENCES block_items (id) ON DELETE CASCADE,
+    CONSTRAINT fk_item_performers_performer FOREIGN KEY (performer_id) REFERENCES performers (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_item_performers_performer_id ON item_performers (performer_id);
@@ -0,0 +1,24 @@
+-- Ресурсы площадки, общие для всех расписаний
+CREATE TABLE resources (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    name        TEXT     NOT NULL,
+    type        TEXT,
+    description TEXT,
+    capacity    INTEGER  NOT NULL DEFAULT 1,
+    created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Брони ресурсов блоками и элементами; у брони блока item_id пуст
+CREATE TABLE resource_bookings (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    resource_id INTEGER NOT NULL,
+    block_id    INTEGER NOT NULL,
+    item_id     INTEGER,
+    quantity    INTEGER NOT NULL,
+    CONSTRAINT fk_resource_bookings_resource FOREIGN KEY (resource_id) REFERENCES resources (id) ON DELETE CASCADE,
+    CONSTRAINT fk_resource_bookings_block FOREIGN KEY (block_id) REFERENCES blocks (id) ON DELETE CASCADE,
+    CONSTRAINT fk_resource_bookings_item FOREIGN KEY (item_id) REFERENCES block_items (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_resource_bookings_resource_id ON resource_bookings (resource_id);
+CREATE INDEX idx_resource_bookings_block_id ON resource_bookings (block_id);
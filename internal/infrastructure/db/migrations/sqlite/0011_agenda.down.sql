@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_block_items_time_range;
+DROP INDEX IF EXISTS idx_blocks_time_range;
+ALTER TABLE blocks DROP COLUMN end_time;
+ALTER TABLE blocks DROP COLUMN track;
@@ -0,0 +1,9 @@
+-- Трек блока (сцена, поток программы) для фильтрации ленты
+ALTER TABLE blocks ADD COLUMN track TEXT NOT NULL DEFAULT '';
+-- Конец блока без техперерыва для выборок по интервалу времени; формат
+-- совпадает с тем, в котором драйвер записывает время
+ALTER TABLE blocks ADD COLUMN end_time DATETIME;
+UPDATE blocks SET end_time = strftime('%Y-%m-%d %H:%M:%S+00:00', start_time, '+' || duration || ' minutes') WHERE start_time IS NOT NULL;
+
+CREATE INDEX idx_blocks_time_range ON blocks (start_time, end_time);
+CREATE INDEX idx_block_items_time_range ON block_items (start_time, end_time);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_schedules_slug;
+ALTER TABLE schedules DROP COLUMN timezone;
+ALTER TABLE schedules DROP COLUMN language;
+ALTER TABLE schedules DROP COLUMN slug;
@@ -0,0 +1,5 @@
+ALTER TABLE block_items DROP COLUMN notes;
+ALTER TABLE block_items DROP COLUMN visibility;
+ALTER TABLE block_items DROP COLUMN call_offset;
+ALTER TABLE blocks DROP COLUMN notes;
+ALTER TABLE blocks DROP COLUMN visibility;
@@ -0,0 +1,7 @@
+-- Видимость блоков и элементов для разных аудиторий, заметки команды и
+-- время сбора выступающих
+ALTER TABLE blocks ADD COLUMN visibility TEXT NOT NULL DEFAULT '';
+ALTER TABLE blocks ADD COLUMN notes TEXT NOT NULL DEFAULT '';
+ALTER TABLE block_items ADD COLUMN call_offset INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE block_items ADD COLUMN visibility TEXT NOT NULL DEFAULT '';
+ALTER TABLE block_items ADD COLUMN notes TEXT NOT NULL DEFAULT '';
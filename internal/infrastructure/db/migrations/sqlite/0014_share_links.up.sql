@@ -0,0 +1,16 @@
+-- Ссылки для просмотра расписания без учетной записи. Сам токен не хранится:
+-- он подписывается заново из полей ссылки.
+CREATE TABLE share_links (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    schedule_id      INTEGER  NOT NULL,
+    view             TEXT     NOT NULL,
+    version          INTEGER,
+    label            TEXT     NOT NULL DEFAULT '',
+    expires_at       DATETIME NOT NULL,
+    revoked_at       DATETIME,
+    access_count     INTEGER  NOT NULL DEFAULT 0,
+    last_accessed_at DATETIME,
+    created_at       DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_share_links_schedule FOREIGN KEY (schedule_id) REFERENCES schedules (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_share_links_schedule_id ON share_links (schedule_id);
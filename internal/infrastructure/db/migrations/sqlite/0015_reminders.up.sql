@@ -0,0 +1,16 @@
+-- Напоминания о начале блоков и выступлений. sent_at заполняется до отправки,
+-- поэтому после перезапуска напоминание не отправляется повторно.
+CREATE TABLE reminders (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    schedule_id INTEGER  NOT NULL,
+    block_id    INTEGER  NOT NULL,
+    item_id     INTEGER  NOT NULL DEFAULT 0,
+    minutes     INTEGER  NOT NULL,
+    starts_at   DATETIME NOT NULL,
+    fire_at     DATETIME NOT NULL,
+    sent_at     DATETIME,
+    created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_reminders_schedule FOREIGN KEY (schedule_id) REFERENCES schedules (id) ON DELETE CASCADE,
+    CONSTRAINT uq_reminders_target UNIQUE (schedule_id, block_id, item_id, minutes)
+);
+CREATE INDEX idx_reminders_due ON reminders (fire_at) WHERE sent_at IS NULL;
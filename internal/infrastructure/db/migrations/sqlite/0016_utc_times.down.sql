@@ -0,0 +1,2 @@
+-- Исходные смещения не сохранились; время остается в UTC
+SELECT 1;
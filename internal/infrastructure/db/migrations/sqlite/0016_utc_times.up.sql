@@ -0,0 +1,34 @@
+-- SQLite хранит время текстом со смещением и сравнивает его как строку.
+-- Теперь время записывается в UTC; здесь в UTC переводятся уже сохраненные
+-- значения с другим смещением (доли секунды у них отбрасываются).
+UPDATE schedules SET start_date = strftime('%Y-%m-%d %H:%M:%S', start_date) || '+00:00' WHERE substr(start_date, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(start_date, -6) <> '+00:00';
+UPDATE schedules SET end_date = strftime('%Y-%m-%d %H:%M:%S', end_date) || '+00:00' WHERE substr(end_date, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(end_date, -6) <> '+00:00';
+UPDATE schedules SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || '+00:00' WHERE substr(created_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(created_at, -6) <> '+00:00';
+UPDATE schedules SET updated_at = strftime('%Y-%m-%d %H:%M:%S', updated_at) || '+00:00' WHERE substr(updated_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(updated_at, -6) <> '+00:00';
+UPDATE schedules SET deleted_at = strftime('%Y-%m-%d %H:%M:%S', deleted_at) || '+00:00' WHERE substr(deleted_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(deleted_at, -6) <> '+00:00';
+UPDATE blocks SET start_time = strftime('%Y-%m-%d %H:%M:%S', start_time) || '+00:00' WHERE substr(start_time, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(start_time, -6) <> '+00:00';
+UPDATE blocks SET end_time = strftime('%Y-%m-%d %H:%M:%S', end_time) || '+00:00' WHERE substr(end_time, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(end_time, -6) <> '+00:00';
+UPDATE blocks SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || '+00:00' WHERE substr(created_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(created_at, -6) <> '+00:00';
+UPDATE blocks SET updated_at = strftime('%Y-%m-%d %H:%M:%S', updated_at) || '+00:00' WHERE substr(updated_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(updated_at, -6) <> '+00:00';
+UPDATE blocks SET deleted_at = strftime('%Y-%m-%d %H:%M:%S', deleted_at) || '+00:00' WHERE substr(deleted_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(deleted_at, -6) <> '+00:00';
+UPDATE block_items SET start_time = strftime('%Y-%m-%d %H:%M:%S', start_time) || '+00:00' WHERE substr(start_time, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(start_time, -6) <> '+00:00';
+UPDATE block_items SET end_time = strftime('%Y-%m-%d %H:%M:%S', end_time) || '+00:00' WHERE substr(end_time, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(end_time, -6) <> '+00:00';
+UPDATE block_items SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || '+00:00' WHERE substr(created_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(created_at, -6) <> '+00:00';
+UPDATE block_items SET updated_at = strftime('%Y-%m-%d %H:%M:%S', updated_at) || '+00:00' WHERE substr(updated_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(updated_at, -6) <> '+00:00';
+UPDATE block_items SET deleted_at = strftime('%Y-%m-%d %H:%M:%S', deleted_at) || '+00:00' WHERE substr(deleted_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(deleted_at, -6) <> '+00:00';
+UPDATE schedule_versions SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || '+00:00' WHERE substr(created_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(created_at, -6) <> '+00:00';
+UPDATE rule_sets SET updated_at = strftime('%Y-%m-%d %H:%M:%S', updated_at) || '+00:00' WHERE substr(updated_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(updated_at, -6) <> '+00:00';
+UPDATE performers SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || '+00:00' WHERE substr(created_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(created_at, -6) <> '+00:00';
+UPDATE performers SET updated_at = strftime('%Y-%m-%d %H:%M:%S', updated_at) || '+00:00' WHERE substr(updated_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(updated_at, -6) <> '+00:00';
+UPDATE performer_availability SET start_time = strftime('%Y-%m-%d %H:%M:%S', start_time) || '+00:00' WHERE substr(start_time, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(start_time, -6) <> '+00:00';
+UPDATE performer_availability SET end_time = strftime('%Y-%m-%d %H:%M:%S', end_time) || '+00:00' WHERE substr(end_time, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(end_time, -6) <> '+00:00';
+UPDATE resources SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || '+00:00' WHERE substr(created_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(created_at, -6) <> '+00:00';
+UPDATE resources SET updated_at = strftime('%Y-%m-%d %H:%M:%S', updated_at) || '+00:00' WHERE substr(updated_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(updated_at, -6) <> '+00:00';
+UPDATE share_links SET expires_at = strftime('%Y-%m-%d %H:%M:%S', expires_at) || '+00:00' WHERE substr(expires_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(expires_at, -6) <> '+00:00';
+UPDATE share_links SET revoked_at = strftime('%Y-%m-%d %H:%M:%S', revoked_at) || '+00:00' WHERE substr(revoked_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(revoked_at, -6) <> '+00:00';
+UPDATE share_links SET last_accessed_at = strftime('%Y-%m-%d %H:%M:%S', last_accessed_at) || '+00:00' WHERE substr(last_accessed_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(last_accessed_at, -6) <> '+00:00';
+UPDATE share_links SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || '+00:00' WHERE substr(created_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(created_at, -6) <> '+00:00';
+UPDATE reminders SET starts_at = strftime('%Y-%m-%d %H:%M:%S', starts_at) || '+00:00' WHERE substr(starts_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(starts_at, -6) <> '+00:00';
+UPDATE reminders SET fire_at = strftime('%Y-%m-%d %H:%M:%S', fire_at) || '+00:00' WHERE substr(fire_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(fire_at, -6) <> '+00:00';
+UPDATE reminders SET sent_at = strftime('%Y-%m-%d %H:%M:%S', sent_at) || '+00:00' WHERE substr(sent_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(sent_at, -6) <> '+00:00';
+UPDATE reminders SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || '+00:00' WHERE substr(created_at, -6) GLOB '[+-][0-9][0-9]:[0-9][0-9]' AND substr(created_at, -6) <> '+00:00';
@@ -0,0 +1,2 @@
+ALTER TABLE block_constraints DROP COLUMN target_block_id;
+ALTER TABLE block_constraints DROP COLUMN block_id;
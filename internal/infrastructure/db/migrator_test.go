@@ -0,0 +1,236 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"cor-events-scheduler/internal/config"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openSQLiteTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	database, err := Open(&config.Config{
+		Database: config.DatabaseConfig{
+			Driver: config.DriverSQLite,
+			Path:   filepath.Join(t.TempDir(), "scheduler.db"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	database.Logger = logger.Default.LogMode(logger.Silent)
+
+	t.Cleanup(func() {
+		if sqlDB, err := database.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return database
+}
+
+func appliedVersions(t *testing.T, migrator *Migrator) []int {
+	t.Helper()
+
+	statuses, err := migrator.Status(context.Background())
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+
+	var versions []int
+	for _, status := range statuses {
+		if status.AppliedAt != nil {
+			versions = append(versions, status.Version)
+		}
+	}
+	return versions
+}
+
+func TestMigrationsHaveUpAndDown(t *testing.T) {
+	for _, dialect := range []string{"postgres", "sqlite"} {
+		migrations, err := loadMigrations(dialect)
+		if err != nil {
+			t.Fatalf("%s: %v", dialect, err)
+		}
+		if len(migrations) == 0 {
+			t.Fatalf("%s: no migrations", dialect)
+		}
+		for i, migration := range migrations {
+			if migration.Version != i+1 {
+				t.Fatalf("%s: migration %d has version %d, want consecutive numbering", dialect, i, migration.Version)
+			}
+		}
+	}
+}
+
+func TestMigratorUpDownTo(t *testing.T) {
+	ctx := context.Background()
+	database := openSQLiteTestDB(t)
+
+	migrator, err := NewMigrator(database)
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+	latest := migrator.Latest()
+
+	if err := migrator.EnsureCurrent(ctx); !errors.Is(err, ErrSchemaBehind) {
+		t.Fatalf("fresh database should be behind, got %v", err)
+	}
+
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if got := appliedVersions(t, migrator); len(got) != latest {
+		t.Fatalf("applied %v after up, want %d migrations", got, latest)
+	}
+	if err := migrator.EnsureCurrent(ctx); err != nil {
+		t.Fatalf("schema should be current after up: %v", err)
+	}
+	// Повторный up ничего не делает
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("second up: %v", err)
+	}
+
+	if err := migrator.Down(ctx); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	if got := appliedVersions(t, migrator); len(got) != latest-1 {
+		t.Fatalf("applied %v after down, want %d migrations", got, latest-1)
+	}
+
+	if err := migrator.To(ctx, 1); err != nil {
+		t.Fatalf("to 1: %v", err)
+	}
+	if got := appliedVersions(t, migrator); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("applied %v after to 1, want [1]", got)
+	}
+
+	if err := migrator.To(ctx, 0); err != nil {
+		t.Fatalf("to 0: %v", err)
+	}
+	if database.Migrator().HasTable("schedules") {
+		t.Fatal("schedules table should be dropped after rolling back everything")
+	}
+
+	// Полный цикл вверх после отката всех миграций
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up after full rollback: %v", err)
+	}
+	if !database.Migrator().HasColumn("schedule_versions", "restored_from") {
+		t.Fatal("schedule_versions.restored_from should exist after up")
+	}
+
+	if err := migrator.To(ctx, latest+1); err == nil {
+		t.Fatal("migrating to an unknown version should fail")
+	}
+}
+
+func TestNewDatabaseRefusesOutdatedSchema(t *testing.T) {
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{
+			Driver: config.DriverSQLite,
+			Path:   filepath.Join(t.TempDir(), "scheduler.db"),
+		},
+	}
+
+	database, err := Open(cfg)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	database.Logger = logger.Default.LogMode(logger.Silent)
+	migrator, err := NewMigrator(database)
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+	if err := migrator.To(context.Background(), 1); err != nil {
+		t.Fatalf("to 1: %v", err)
+	}
+	if sqlDB, err := database.DB(); err == nil {
+		sqlDB.Close()
+	}
+
+	if _, err := NewDatabase(cfg); !errors.Is(err, ErrSchemaBehind) {
+		t.Fatalf("NewDatabase should refuse an outdated schema, got %v", err)
+	}
+}
+
+func TestUTCMigrationNormalizesStoredTimes(t *testing.T) {
+	ctx := context.Background()
+	database := openSQLiteTestDB(t)
+
+	migrator, err := NewMigrator(database)
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+	if err := migrator.To(ctx, 15); err != nil {
+		t.Fatalf("to 15: %v", err)
+	}
+	// Строки, записанные до перехода на UTC, хранят смещение как есть
+	if err := database.Exec(`INSERT INTO schedules (name, start_date, end_date) VALUES
+		('msk', '2024-04-01 13:00:00.5+03:00', '2024-04-01 19:00:00+03:00'),
+		('utc', '2024-04-01 10:00:00.5+00:00', '2024-04-01 16:00:00+00:00')`).Error; err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+
+	var rows []struct{ Name, StartDate, EndDate string }
+	if err := database.Raw("SELECT name, CAST(start_date AS TEXT) AS start_date, CAST(end_date AS TEXT) AS end_date FROM schedules ORDER BY id").Scan(&rows).Error; err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	want := []string{
+		"msk 2024-04-01 10:00:00+00:00 2024-04-01 16:00:00+00:00",
+		"utc 2024-04-01 10:00:00.5+00:00 2024-04-01 16:00:00+00:00",
+	}
+	for i, row := range rows {
+		if got := row.Name + " " + row.StartDate + " " + row.EndDate; i >= len(want) || got != want[i] {
+			t.Fatalf("row %d = %q, want %q", i, got, want)
+		}
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+}
+
+func TestConstraintBlockIDsMigrationResolvesUniqueNames(t *testing.T) {
+	ctx := context.Background()
+	database := openSQLiteTestDB(t)
+
+	migrator, err := NewMigrator(database)
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+	if err := migrator.To(ctx, 16); err != nil {
+		t.Fatalf("to 16: %v", err)
+	}
+	// Имя "Дубль" неоднозначно, удаленный блок не считается
+	for _, statement := range []string{
+		`INSERT INTO schedules (id, name, start_date, end_date) VALUES (1, 'Фестиваль', '2024-04-01 10:00:00+00:00', '2024-04-01 16:00:00+00:00')`,
+		`INSERT INTO blocks (id, schedule_id, name, duration, "order", deleted_at) VALUES
+			(1, 1, 'Открытие', 30, 1, NULL), (2, 1, 'Финал', 30, 2, NULL), (3, 1, 'Финал', 30, 3, '2024-03-01 00:00:00+00:00'),
+			(4, 1, 'Дубль', 30, 4, NULL), (5, 1, 'Дубль', 30, 5, NULL)`,
+		`INSERT INTO block_constraints (schedule_id, type, block, target_block, target_type) VALUES
+			(1, 'after', 'Финал', 'Открытие', NULL), (1, 'after', 'Дубль', NULL, 'opening')`,
+	} {
+		if err := database.Exec(statement).Error; err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+
+	var rows []struct{ BlockID, TargetBlockID uint }
+	if err := database.Raw("SELECT block_id, target_block_id FROM block_constraints ORDER BY id").Scan(&rows).Error; err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if len(rows) != 2 || rows[0].BlockID != 2 || rows[0].TargetBlockID != 1 || rows[1].BlockID != 0 || rows[1].TargetBlockID != 0 {
+		t.Fatalf("unexpected block IDs %+v", rows)
+	}
+}
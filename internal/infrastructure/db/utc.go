@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// utcPool приводит параметры запросов со временем к UTC. SQLite хранит время
+// текстом вместе со смещением и сравнивает его как строку, поэтому одно и то
+// же время, записанное в разных зонах, неверно упорядочивается и не попадает
+// в интервалы. В UTC записываются и значения, и границы условий.
+type utcPool struct {
+	utcConn
+	db *sql.DB
+}
+
+// utcTx — транзакция utcPool. Она не начинает вложенных транзакций: GORM
+// отличает открытую транзакцию по отсутствию BeginTx.
+type utcTx struct {
+	utcConn
+	tx *sql.Tx
+}
+
+// utcConn приводит параметры к UTC и передает запрос пулу или транзакции
+type utcConn struct {
+	pool gorm.ConnPool
+}
+
+var (
+	_ gorm.ConnPool         = utcPool{}
+	_ gorm.ConnPoolBeginner = utcPool{}
+	_ gorm.GetDBConnector   = utcPool{}
+	_ gorm.TxCommitter      = (*utcTx)(nil)
+)
+
+func newUTCPool(db *sql.DB) utcPool {
+	return utcPool{utcConn: utcConn{pool: db}, db: db}
+}
+
+func (p utcConn) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.pool.PrepareContext(ctx, query)
+}
+
+func (p utcConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.pool.ExecContext(ctx, query, utcArgs(args)...)
+}
+
+func (p utcConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.pool.QueryContext(ctx, query, utcArgs(args)...)
+}
+
+func (p utcConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.pool.QueryRowContext(ctx, query, utcArgs(args)...)
+}
+
+// BeginTx начинает транзакцию, параметры которой тоже приводятся к UTC
+func (p utcPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	tx, err := p.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &utcTx{utcConn: utcConn{pool: tx}, tx: tx}, nil
+}
+
+func (p utcPool) GetDBConn() (*sql.DB, error) {
+	return p.db, nil
+}
+
+func (t *utcTx) Commit() error   { return t.tx.Commit() }
+func (t *utcTx) Rollback() error { return t.tx.Rollback() }
+
+func utcArgs(args []interface{}) []interface{} {
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case time.Time:
+			args[i] = v.UTC()
+		case *time.Time:
+			if v != nil {
+				args[i] = v.UTC()
+			}
+		case gorm.DeletedAt:
+			if v.Valid {
+				args[i] = v.Time.UTC()
+			}
+		case sql.NullTime:
+			if v.Valid {
+				args[i] = v.Time.UTC()
+			}
+		}
+	}
+	return args
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+)
+
+var _ domain.AgendaRepository = (*AgendaRepository)(nil)
+
+type AgendaRepository struct {
+	store *Store
+}
+
+func NewAgendaRepository(store *Store) *AgendaRepository {
+	return &AgendaRepository{store: store}
+}
+
+// ListAgenda перебирает блоки и элементы всех расписаний
+func (r *AgendaRepository) ListAgenda(ctx context.Context, filter models.AgendaFilter) ([]models.AgendaEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	schedules := make(map[uint]bool, len(filter.ScheduleIDs))
+	for _, id := range filter.ScheduleIDs {
+		schedules[id] = true
+	}
+
+	entries := []models.AgendaEntry{}
+	for _, schedule := range r.store.schedules {
+		if len(schedules) > 0 && !schedules[schedule.ID] {
+			continue
+		}
+		for _, block := range schedule.Blocks {
+			if !matches(filter.Types, block.Type) || !matches(filter.Tracks, block.Track) {
+				continue
+			}
+
+			entry := models.AgendaEntry{
+				Kind:         models.AgendaKindBlock,
+				ScheduleID:   schedule.ID,
+				ScheduleName: schedule.Name,
+				BlockID:      block.ID,
+				BlockName:    block.Name,
+				Type:         block.Type,
+				Track:        block.Track,
+				StartTime:    block.StartTime,
+				EndTime:      block.StartTime.Add(time.Duration(block.Duration) * time.Minute),
+			}
+			if overlaps(entry, filter) {
+				entries = append(entries, entry)
+			}
+			if !filter.Items {
+				continue
+			}
+
+			for _, item := range block.Items {
+				entry := entry
+				entry.Kind = models.AgendaKindItem
+				entry.ItemID = item.ID
+				entry.ItemName = item.Name
+				entry.Type = item.Type
+				entry.StartTime = item.StartTime
+				entry.EndTime = item.EndTime
+				if overlaps(entry, filter) {
+					entries = append(entries, entry)
+				}
+			}
+		}
+	}
+
+	models.SortAgenda(entries)
+	return entries, nil
+}
+
+// matches сообщает, входит ли значение в список; пустой список подходит всем
+func matches(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps сообщает, пересекается ли запись с интервалом ленты
+func overlaps(entry models.AgendaEntry, filter models.AgendaFilter) bool {
+	return entry.StartTime.Before(filter.To) && entry.EndTime.After(filter.From)
+}
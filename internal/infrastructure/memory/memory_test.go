@@ -0,0 +1,24 @@
+package memory
+
+import (
+	"testing"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+)
+
+func TestConformance(t *testing.T) {
+	domaintest.Run(t, func(t *testing.T) domaintest.Repositories {
+		store := NewStore()
+		return domaintest.Repositories{
+			Schedules:  NewScheduleRepository(store),
+			Versions:   NewVersionRepository(store),
+			Search:     NewSearchRepository(store),
+			RuleSets:   NewRuleSetRepository(store),
+			Performers: NewPerformerRepository(store),
+			Resources:  NewResourceRepository(store),
+			Agenda:     NewAgendaRepository(store),
+			ShareLinks: NewShareLinkRepository(store),
+			Reminders:  NewReminderRepository(store),
+		}
+	})
+}
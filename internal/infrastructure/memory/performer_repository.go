@@ -0,0 +1,209 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+)
+
+var _ domain.PerformerRepository = (*PerformerRepository)(nil)
+
+type PerformerRepository struct {
+	store *Store
+}
+
+func NewPerformerRepository(store *Store) *PerformerRepository {
+	return &PerformerRepository{store: store}
+}
+
+// CreatePerformer создает выступающего вместе с окнами доступности
+func (r *PerformerRepository) CreatePerformer(ctx context.Context, performer *models.Performer) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	r.store.nextPerformerID++
+	performer.ID = r.store.nextPerformerID
+	performer.CreatedAt = now
+	performer.UpdatedAt = now
+	r.store.assignAvailabilityIDs(performer)
+
+	r.store.performers[performer.ID] = copyPerformer(performer)
+	return nil
+}
+
+// UpdatePerformer обновляет выступающего и заменяет его окна доступности
+func (r *PerformerRepository) UpdatePerformer(ctx context.Context, performer *models.Performer) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.performers[performer.ID]
+	if !ok {
+		return fmt.Errorf("failed to get existing performer: %w", utils.ErrNotFound)
+	}
+
+	performer.CreatedAt = existing.CreatedAt
+	performer.UpdatedAt = time.Now()
+	r.store.assignAvailabilityIDs(performer)
+
+	r.store.performers[performer.ID] = copyPerformer(performer)
+	return nil
+}
+
+// GetPerformer получает выступающего по ID
+func (r *PerformerRepository) GetPerformer(ctx context.Context, id uint) (*models.Performer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	performer, ok := r.store.performers[id]
+	if !ok {
+		return nil, fmt.Errorf("failed to get performer: %w", utils.ErrNotFound)
+	}
+	return copyPerformer(performer), nil
+}
+
+// GetPerformers получает выступающих с указанными ID
+func (r *PerformerRepository) GetPerformers(ctx context.Context, ids []uint) ([]models.Performer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	performers := []models.Performer{}
+	for _, id := range models.SortedIDs(ids) {
+		if performer, ok := r.store.performers[id]; ok {
+			performers = append(performers, *copyPerformer(performer))
+		}
+	}
+	return performers, nil
+}
+
+// ListPerformers возвращает всех выступающих
+func (r *PerformerRepository) ListPerformers(ctx context.Context) ([]models.Performer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	performers := make([]models.Performer, 0, len(r.store.performers))
+	for _, performer := range r.store.performers {
+		performers = append(performers, *copyPerformer(performer))
+	}
+	sort.Slice(performers, func(i, j int) bool { return performers[i].ID < performers[j].ID })
+	return performers, nil
+}
+
+// DeletePerformer удаляет выступающего и его связи с элементами
+func (r *PerformerRepository) DeletePerformer(ctx context.Context, id uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.performers[id]; !ok {
+		return fmt.Errorf("failed to get performer for deletion: %w", utils.ErrNotFound)
+	}
+	delete(r.store.performers, id)
+
+	for _, schedule := range r.store.schedules {
+		for i := range schedule.Blocks {
+			for j := range schedule.Blocks[i].Items {
+				item := &schedule.Blocks[i].Items[j]
+				kept := item.PerformerIDs[:0]
+				for _, performerID := range item.PerformerIDs {
+					if performerID != id {
+						kept = append(kept, performerID)
+					}
+				}
+				item.PerformerIDs = kept
+			}
+		}
+	}
+	return nil
+}
+
+// ListAppearances возвращает выступления в элементах сохраненных расписаний
+func (r *PerformerRepository) ListAppearances(ctx context.Context, performerIDs []uint) ([]models.Appearance, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	wanted := make(map[uint]bool, len(performerIDs))
+	for _, id := range performerIDs {
+		wanted[id] = true
+	}
+
+	appearances := []models.Appearance{}
+	for _, schedule := range r.store.schedules {
+		for _, block := range schedule.Blocks {
+			for _, item := range block.Items {
+				for _, performerID := range item.PerformerIDs {
+					if !wanted[performerID] {
+						continue
+					}
+					appearances = append(appearances, models.Appearance{
+						PerformerID:  performerID,
+						ScheduleID:   schedule.ID,
+						ScheduleName: schedule.Name,
+						BlockID:      block.ID,
+						BlockName:    block.Name,
+						ItemID:       item.ID,
+						ItemName:     item.Name,
+						StartTime:    item.StartTime,
+						EndTime:      item.EndTime,
+					})
+				}
+			}
+		}
+	}
+
+	sort.Slice(appearances, func(i, j int) bool {
+		a, b := appearances[i], appearances[j]
+		if !a.StartTime.Equal(b.StartTime) {
+			return a.StartTime.Before(b.StartTime)
+		}
+		if a.PerformerID != b.PerformerID {
+			return a.PerformerID < b.PerformerID
+		}
+		return a.ItemID < b.ItemID
+	})
+	return appearances, nil
+}
+
+// assignAvailabilityIDs присваивает ID окнам доступности выступающего
+func (s *Store) assignAvailabilityIDs(performer *models.Performer) {
+	if performer.Availability == nil {
+		performer.Availability = []models.PerformerAvailability{}
+	}
+	for i := range performer.Availability {
+		s.nextAvailabilityID++
+		performer.Availability[i].ID = s.nextAvailabilityID
+		performer.Availability[i].PerformerID = performer.ID
+	}
+}
@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+)
+
+var _ domain.ReminderRepository = (*ReminderRepository)(nil)
+
+type ReminderRepository struct {
+	store *Store
+}
+
+func NewReminderRepository(store *Store) *ReminderRepository {
+	return &ReminderRepository{store: store}
+}
+
+// SyncReminders приводит напоминания расписания к planned
+func (r *ReminderRepository) SyncReminders(ctx context.Context, scheduleID uint, planned []models.Reminder, now time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	byKey := make(map[models.ReminderKey]*models.Reminder)
+	for _, reminder := range r.store.reminders {
+		if reminder.ScheduleID == scheduleID {
+			byKey[reminder.Key()] = reminder
+		}
+	}
+
+	for _, reminder := range planned {
+		key := reminder.Key()
+		current, ok := byKey[key]
+		delete(byKey, key)
+
+		switch {
+		case !ok:
+			r.store.nextReminderID++
+			reminder.ID = r.store.nextReminderID
+			reminder.ScheduleID = scheduleID
+			reminder.SentAt = nil
+			reminder.CreatedAt = time.Now()
+			r.store.reminders[reminder.ID] = copyReminder(&reminder)
+		case !current.FireAt.Equal(reminder.FireAt):
+			current.StartsAt = reminder.StartsAt
+			current.FireAt = reminder.FireAt
+			if reminder.FireAt.After(now) {
+				current.SentAt = nil
+			}
+		}
+	}
+
+	for _, reminder := range byKey {
+		delete(r.store.reminders, reminder.ID)
+	}
+	return nil
+}
+
+// ListReminders возвращает напоминания расписания
+func (r *ReminderRepository) ListReminders(ctx context.Context, scheduleID uint) ([]models.Reminder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	reminders := []models.Reminder{}
+	for _, reminder := range r.store.reminders {
+		if reminder.ScheduleID == scheduleID {
+			reminders = append(reminders, *copyReminder(reminder))
+		}
+	}
+	sortReminders(reminders)
+	return reminders, nil
+}
+
+// DueReminders возвращает наступившие неотправленные напоминания
+func (r *ReminderRepository) DueReminders(ctx context.Context, now time.Time, limit int) ([]models.Reminder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	reminders := []models.Reminder{}
+	for _, reminder := range r.store.reminders {
+		if reminder.SentAt == nil && !reminder.FireAt.After(now) {
+			reminders = append(reminders, *copyReminder(reminder))
+		}
+	}
+	sortReminders(reminders)
+	if len(reminders) > limit {
+		reminders = reminders[:limit]
+	}
+	return reminders, nil
+}
+
+// ClaimReminder отмечает напоминание отправленным
+func (r *ReminderRepository) ClaimReminder(ctx context.Context, id uint, at time.Time) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	reminder, ok := r.store.reminders[id]
+	if !ok || reminder.SentAt != nil {
+		return false, nil
+	}
+	reminder.SentAt = &at
+	return true, nil
+}
+
+func sortReminders(reminders []models.Reminder) {
+	sort.Slice(reminders, func(i, j int) bool {
+		if !reminders[i].FireAt.Equal(reminders[j].FireAt) {
+			return reminders[i].FireAt.Before(reminders[j].FireAt)
+		}
+		return reminders[i].ID < reminders[j].ID
+	})
+}
+
+func copyReminder(reminder *models.Reminder) *models.Reminder {
+	cp := *reminder
+	if reminder.SentAt != nil {
+		sentAt := *reminder.SentAt
+		cp.SentAt = &sentAt
+	}
+	return &cp
+}
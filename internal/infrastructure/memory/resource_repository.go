@@ -0,0 +1,195 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+)
+
+var _ domain.ResourceRepository = (*ResourceRepository)(nil)
+
+type ResourceRepository struct {
+	store *Store
+}
+
+func NewResourceRepository(store *Store) *ResourceRepository {
+	return &ResourceRepository{store: store}
+}
+
+// CreateResource создает ресурс
+func (r *ResourceRepository) CreateResource(ctx context.Context, resource *models.Resource) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	r.store.nextResourceID++
+	resource.ID = r.store.nextResourceID
+	resource.CreatedAt = now
+	resource.UpdatedAt = now
+
+	stored := *resource
+	r.store.resources[resource.ID] = &stored
+	return nil
+}
+
+// UpdateResource обновляет ресурс
+func (r *ResourceRepository) UpdateResource(ctx context.Context, resource *models.Resource) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.resources[resource.ID]
+	if !ok {
+		return fmt.Errorf("failed to get existing resource: %w", utils.ErrNotFound)
+	}
+
+	resource.CreatedAt = existing.CreatedAt
+	resource.UpdatedAt = time.Now()
+
+	stored := *resource
+	r.store.resources[resource.ID] = &stored
+	return nil
+}
+
+// GetResource получает ресурс по ID
+func (r *ResourceRepository) GetResource(ctx context.Context, id uint) (*models.Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	resource, ok := r.store.resources[id]
+	if !ok {
+		return nil, fmt.Errorf("failed to get resource: %w", utils.ErrNotFound)
+	}
+	cp := *resource
+	return &cp, nil
+}
+
+// GetResources получает ресурсы с указанными ID
+func (r *ResourceRepository) GetResources(ctx context.Context, ids []uint) ([]models.Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	resources := []models.Resource{}
+	for _, id := range models.SortedIDs(ids) {
+		if resource, ok := r.store.resources[id]; ok {
+			resources = append(resources, *resource)
+		}
+	}
+	return resources, nil
+}
+
+// ListResources возвращает все ресурсы
+func (r *ResourceRepository) ListResources(ctx context.Context) ([]models.Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	resources := make([]models.Resource, 0, len(r.store.resources))
+	for _, resource := range r.store.resources {
+		resources = append(resources, *resource)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].ID < resources[j].ID })
+	return resources, nil
+}
+
+// DeleteResource удаляет ресурс и его брони
+func (r *ResourceRepository) DeleteResource(ctx context.Context, id uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.resources[id]; !ok {
+		return fmt.Errorf("failed to get resource for deletion: %w", utils.ErrNotFound)
+	}
+	delete(r.store.resources, id)
+
+	for _, schedule := range r.store.schedules {
+		for i := range schedule.Blocks {
+			block := &schedule.Blocks[i]
+			block.Resources = withoutResource(block.Resources, id)
+			for j := range block.Items {
+				block.Items[j].Resources = withoutResource(block.Items[j].Resources, id)
+			}
+		}
+	}
+	return nil
+}
+
+// ListUsages возвращает брони ресурсов в сохраненных расписаниях,
+// пересекающиеся с интервалом [from, to)
+func (r *ResourceRepository) ListUsages(ctx context.Context, resourceIDs []uint, from, to time.Time) ([]models.ResourceUsage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	wanted := make(map[uint]bool, len(resourceIDs))
+	for _, id := range resourceIDs {
+		wanted[id] = true
+	}
+
+	usages := []models.ResourceUsage{}
+	for _, schedule := range r.store.schedules {
+		for i := range schedule.Blocks {
+			for _, usage := range schedule.Blocks[i].ResourceUsages(schedule.ID, schedule.Name) {
+				if wanted[usage.ResourceID] && usage.StartTime.Before(to) && usage.EndTime.After(from) {
+					usages = append(usages, usage)
+				}
+			}
+		}
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		a, b := usages[i], usages[j]
+		if !a.StartTime.Equal(b.StartTime) {
+			return a.StartTime.Before(b.StartTime)
+		}
+		if a.ResourceID != b.ResourceID {
+			return a.ResourceID < b.ResourceID
+		}
+		if a.BlockID != b.BlockID {
+			return a.BlockID < b.BlockID
+		}
+		return a.ItemID < b.ItemID
+	})
+	return usages, nil
+}
+
+// withoutResource убирает брони ресурса из списка
+func withoutResource(assignments []models.ResourceAssignment, id uint) []models.ResourceAssignment {
+	kept := assignments[:0]
+	for _, assignment := range assignments {
+		if assignment.ResourceID != id {
+			kept = append(kept, assignment)
+		}
+	}
+	return kept
+}
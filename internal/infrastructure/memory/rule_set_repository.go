@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+)
+
+var _ domain.RuleSetRepository = (*RuleSetRepository)(nil)
+
+type RuleSetRepository struct {
+	store *Store
+}
+
+func NewRuleSetRepository(store *Store) *RuleSetRepository {
+	return &RuleSetRepository{store: store}
+}
+
+// GetRuleSet получает набор правил расписания или организации
+func (r *RuleSetRepository) GetRuleSet(ctx context.Context, scheduleID uint) (*models.RuleSet, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ruleSet, ok := r.store.ruleSets[scheduleID]
+	if !ok {
+		return nil, fmt.Errorf("failed to get rule set: %w", utils.ErrNotFound)
+	}
+	return &ruleSet, nil
+}
+
+// SaveRuleSet создает или заменяет набор правил
+func (r *RuleSetRepository) SaveRuleSet(ctx context.Context, ruleSet *models.RuleSet) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	ruleSet.UpdatedAt = time.Now()
+	r.store.ruleSets[ruleSet.ScheduleID] = *ruleSet
+	return nil
+}
+
+// DeleteRuleSet удаляет набор правил
+func (r *RuleSetRepository) DeleteRuleSet(ctx context.Context, scheduleID uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.ruleSets[scheduleID]; !ok {
+		return fmt.Errorf("failed to get rule set for deletion: %w", utils.ErrNotFound)
+	}
+	delete(r.store.ruleSets, scheduleID)
+	return nil
+}
@@ -0,0 +1,346 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+)
+
+var _ domain.ScheduleRepository = (*ScheduleRepository)(nil)
+
+type ScheduleRepository struct {
+	store *Store
+}
+
+func NewScheduleRepository(store *Store) *ScheduleRepository {
+	return &ScheduleRepository{store: store}
+}
+
+// Create создает новое расписание
+func (r *ScheduleRepository) Create(ctx context.Context, schedule *models.Schedule) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.store.checkSlug(schedule.Slug, 0); err != nil {
+		return fmt.Errorf("failed to create schedule: %w", err)
+	}
+
+	now := time.Now()
+	refs := schedule.ResolveConstraints()
+
+	r.store.nextScheduleID++
+	schedule.ID = r.store.nextScheduleID
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+
+	for i := range schedule.Blocks {
+		block := &schedule.Blocks[i]
+		r.store.nextBlockID++
+		block.ID = r.store.nextBlockID
+		block.ScheduleID = schedule.ID
+		block.Order = i + 1
+		block.Resources = models.CopyAssignments(block.Resources)
+		block.CreatedAt = now
+		block.UpdatedAt = now
+
+		for j := range block.Items {
+			item := &block.Items[j]
+			r.store.nextItemID++
+			item.ID = r.store.nextItemID
+			item.BlockID = block.ID
+			item.Order = j + 1
+			item.PerformerIDs = models.SortedIDs(item.PerformerIDs)
+			item.Resources = models.CopyAssignments(item.Resources)
+			item.CreatedAt = now
+			item.UpdatedAt = now
+		}
+	}
+
+	schedule.BindConstraints(refs)
+	r.store.assignConstraintIDs(schedule)
+
+	r.store.schedules[schedule.ID] = copySchedule(schedule)
+	return nil
+}
+
+// Update обновляет существующее расписание
+func (r *ScheduleRepository) Update(ctx context.Context, schedule *models.Schedule) error {
+	return r.update(ctx, schedule, nil)
+}
+
+// UpdateWithVersion обновляет расписание и сохраняет его версию под одной блокировкой
+func (r *ScheduleRepository) UpdateWithVersion(ctx context.Context, schedule *models.Schedule, version domain.VersionFunc) error {
+	return r.update(ctx, schedule, version)
+}
+
+// update обновляет расписание и, если задана version, добавляет версию;
+// при ошибке хранилище не меняется
+func (r *ScheduleRepository) update(ctx context.Context, schedule *models.Schedule, version domain.VersionFunc) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.schedules[schedule.ID]
+	if !ok {
+		return fmt.Errorf("failed to get existing schedule: %w", utils.ErrNotFound)
+	}
+	if err := r.store.checkSlug(schedule.Slug, schedule.ID); err != nil {
+		return fmt.Errorf("failed to update schedule: %w", err)
+	}
+
+	// Запоминаем текущие блоки и элементы расписания, чтобы сохранить их created_at.
+	// ID из запроса, не найденные здесь, считаются новыми записями.
+	knownBlocks := make(map[uint]*models.Block)
+	knownItems := make(map[uint]*models.BlockItem)
+	for i := range existing.Blocks {
+		block := &existing.Blocks[i]
+		knownBlocks[block.ID] = block
+		for j := range block.Items {
+			knownItems[block.Items[j].ID] = &block.Items[j]
+		}
+	}
+
+	now := time.Now()
+	nextBlockID, nextItemID := r.store.nextBlockID, r.store.nextItemID
+
+	// Собираем новое состояние целиком и фиксируем его только в конце
+	updated := copySchedule(schedule)
+	updated.CreatedAt = existing.CreatedAt
+	updated.UpdatedAt = now
+	refs := updated.ResolveConstraints()
+
+	for i := range updated.Blocks {
+		block := &updated.Blocks[i]
+		if known, ok := knownBlocks[block.ID]; ok {
+			block.CreatedAt = known.CreatedAt
+		} else {
+			nextBlockID++
+			block.ID = nextBlockID
+			block.CreatedAt = now
+		}
+		block.ScheduleID = updated.ID
+		block.UpdatedAt = now
+
+		for j := range block.Items {
+			item := &block.Items[j]
+			if known, ok := knownItems[item.ID]; ok {
+				item.CreatedAt = known.CreatedAt
+			} else {
+				nextItemID++
+				item.ID = nextItemID
+				item.CreatedAt = now
+			}
+			item.BlockID = block.ID
+			item.UpdatedAt = now
+		}
+	}
+
+	updated.BindConstraints(refs)
+	r.store.assignConstraintIDs(updated)
+	if version != nil {
+		next, err := version(sortedCopy(updated), r.store.latestVersion(updated.ID))
+		if err != nil {
+			return err
+		}
+		r.store.nextVersionID++
+		next.ID = r.store.nextVersionID
+		r.store.versions = append(r.store.versions, copyVersion(next))
+	}
+	r.store.nextBlockID, r.store.nextItemID = nextBlockID, nextItemID
+	r.store.schedules[updated.ID] = updated
+
+	// Возвращаем присвоенные ID и временные метки в переданное расписание
+	schedule.UpdatedAt = now
+	copy(schedule.Constraints, updated.Constraints)
+	for i := range schedule.Blocks {
+		block := &schedule.Blocks[i]
+		block.ID = updated.Blocks[i].ID
+		block.ScheduleID = updated.ID
+		block.Resources = models.CopyAssignments(block.Resources)
+		block.CreatedAt = updated.Blocks[i].CreatedAt
+		block.UpdatedAt = now
+		for j := range block.Items {
+			block.Items[j].ID = updated.Blocks[i].Items[j].ID
+			block.Items[j].BlockID = block.ID
+			block.Items[j].PerformerIDs = models.SortedIDs(block.Items[j].PerformerIDs)
+			block.Items[j].Resources = models.CopyAssignments(block.Items[j].Resources)
+			block.Items[j].CreatedAt = updated.Blocks[i].Items[j].CreatedAt
+			block.Items[j].UpdatedAt = now
+		}
+	}
+
+	return nil
+}
+
+// GetByID получает расписание по ID
+func (r *ScheduleRepository) GetByID(ctx context.Context, id uint) (*models.Schedule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	schedule, ok := r.store.schedules[id]
+	if !ok {
+		return nil, fmt.Errorf("failed to get schedule: %w", utils.ErrNotFound)
+	}
+
+	return sortedCopy(schedule), nil
+}
+
+// GetBySlug получает расписание по адресу публичной страницы
+func (r *ScheduleRepository) GetBySlug(ctx context.Context, slug string) (*models.Schedule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, schedule := range r.store.schedules {
+		if slug != "" && schedule.Slug == slug {
+			return sortedCopy(schedule), nil
+		}
+	}
+	return nil, fmt.Errorf("failed to get schedule: %w", utils.ErrNotFound)
+}
+
+// Delete удаляет расписание
+func (r *ScheduleRepository) Delete(ctx context.Context, id uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.schedules[id]; !ok {
+		return fmt.Errorf("failed to get schedule for deletion: %w", utils.ErrNotFound)
+	}
+
+	delete(r.store.schedules, id)
+	delete(r.store.ruleSets, id)
+	for linkID, link := range r.store.shareLinks {
+		if link.ScheduleID == id {
+			delete(r.store.shareLinks, linkID)
+		}
+	}
+	for reminderID, reminder := range r.store.reminders {
+		if reminder.ScheduleID == id {
+			delete(r.store.reminders, reminderID)
+		}
+	}
+	return nil
+}
+
+// ListSummaries возвращает облегченные представления расписаний с id больше afterID
+func (r *ScheduleRepository) ListSummaries(ctx context.Context, afterID uint, limit int) ([]models.ScheduleSummary, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	now := time.Now()
+	summaries := make([]models.ScheduleSummary, 0, limit)
+	for _, id := range r.store.pageIDs(afterID, limit) {
+		schedule := r.store.schedules[id]
+		summary := models.ScheduleSummary{
+			ID:         schedule.ID,
+			Name:       schedule.Name,
+			StartDate:  schedule.StartDate,
+			EndDate:    schedule.EndDate,
+			BlockCount: len(schedule.Blocks),
+			Status:     models.ScheduleStatusAt(schedule.StartDate, schedule.EndDate, now),
+			UpdatedAt:  schedule.UpdatedAt,
+		}
+		for _, block := range schedule.Blocks {
+			summary.TotalDuration += block.Duration + block.TechBreakDuration
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// List возвращает расписания с блоками и элементами с id больше afterID
+func (r *ScheduleRepository) List(ctx context.Context, afterID uint, limit int) ([]models.Schedule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	schedules := make([]models.Schedule, 0, limit)
+	for _, id := range r.store.pageIDs(afterID, limit) {
+		schedules = append(schedules, *sortedCopy(r.store.schedules[id]))
+	}
+
+	return schedules, nil
+}
+
+// assignConstraintIDs выдает ограничениям расписания новые ID: при обновлении
+// ограничения заменяются целиком, как в GORM-реализации
+func (s *Store) assignConstraintIDs(schedule *models.Schedule) {
+	for i := range schedule.Constraints {
+		s.nextConstraintID++
+		schedule.Constraints[i].ID = s.nextConstraintID
+		schedule.Constraints[i].ScheduleID = schedule.ID
+	}
+}
+
+// checkSlug возвращает utils.ErrConflict, если непустой адрес уже занят
+// расписанием, отличным от exceptID, как уникальный индекс в GORM-реализации
+func (s *Store) checkSlug(slug string, exceptID uint) error {
+	if slug == "" {
+		return nil
+	}
+	for id, schedule := range s.schedules {
+		if id != exceptID && schedule.Slug == slug {
+			return fmt.Errorf("%w: slug %q is already used by schedule %d", utils.ErrConflict, slug, id)
+		}
+	}
+	return nil
+}
+
+// pageIDs возвращает до limit ID расписаний больше afterID по возрастанию
+func (s *Store) pageIDs(afterID uint, limit int) []uint {
+	ids := make([]uint, 0, len(s.schedules))
+	for id := range s.schedules {
+		if id > afterID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids
+}
+
+// sortedCopy возвращает копию расписания с блоками и элементами, упорядоченными по order
+func sortedCopy(schedule *models.Schedule) *models.Schedule {
+	cp := copySchedule(schedule)
+	sort.SliceStable(cp.Blocks, func(i, j int) bool { return cp.Blocks[i].Order < cp.Blocks[j].Order })
+	for i := range cp.Blocks {
+		items := cp.Blocks[i].Items
+		sort.SliceStable(items, func(a, b int) bool { return items[a].Order < items[b].Order })
+	}
+	return cp
+}
@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+)
+
+var _ domain.SearchRepository = (*SearchRepository)(nil)
+
+type SearchRepository struct {
+	store *Store
+}
+
+func NewSearchRepository(store *Store) *SearchRepository {
+	return &SearchRepository{store: store}
+}
+
+// Search ищет вхождения слов запроса без учета регистра. Стемминга здесь нет:
+// совпадение названия весит больше, чем совпадение типа или описания.
+func (r *SearchRepository) Search(ctx context.Context, query string, offset, limit int) ([]models.SearchHit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	terms := strings.Fields(strings.ToLower(query))
+	hits := make([]models.SearchHit, 0)
+	if len(terms) == 0 {
+		return hits, nil
+	}
+
+	r.store.mu.RLock()
+	for _, id := range r.store.pageIDs(0, len(r.store.schedules)) {
+		schedule := r.store.schedules[id]
+
+		if rank := matchRank(terms, schedule.Name, ""); rank > 0 {
+			hits = append(hits, models.SearchHit{
+				Kind:         models.SearchKindSchedule,
+				Rank:         rank,
+				ScheduleID:   schedule.ID,
+				ScheduleName: schedule.Name,
+				Snippet:      highlight(terms, schedule.Name),
+			})
+		}
+
+		for _, block := range schedule.Blocks {
+			blockID := block.ID
+			if rank := matchRank(terms, block.Name, block.Type); rank > 0 {
+				hits = append(hits, models.SearchHit{
+					Kind:         models.SearchKindBlock,
+					Rank:         rank,
+					ScheduleID:   schedule.ID,
+					ScheduleName: schedule.Name,
+					BlockID:      &blockID,
+					BlockName:    block.Name,
+					Snippet:      highlight(terms, strings.TrimSpace(block.Name+" "+block.Type)),
+				})
+			}
+
+			for _, item := range block.Items {
+				itemID := item.ID
+				if rank := matchRank(terms, item.Name, item.Description); rank > 0 {
+					hits = append(hits, models.SearchHit{
+						Kind:         models.SearchKindItem,
+						Rank:         rank,
+						ScheduleID:   schedule.ID,
+						ScheduleName: schedule.Name,
+						BlockID:      &blockID,
+						BlockName:    block.Name,
+						ItemID:       &itemID,
+						ItemName:     item.Name,
+						Snippet:      highlight(terms, strings.TrimSpace(item.Name+" "+item.Description)),
+					})
+				}
+			}
+		}
+	}
+	r.store.mu.RUnlock()
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Rank > hits[j].Rank })
+
+	if offset >= len(hits) {
+		return hits[:0], nil
+	}
+	hits = hits[offset:]
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// matchRank возвращает долю слов запроса, найденных в тексте; совпадения в title весят вдвое больше
+func matchRank(terms []string, title, body string) float64 {
+	title, body = strings.ToLower(title), strings.ToLower(body)
+
+	var rank float64
+	for _, term := range terms {
+		switch {
+		case strings.Contains(title, term):
+			rank += 1
+		case strings.Contains(body, term):
+			rank += 0.5
+		}
+	}
+	return rank / float64(len(terms))
+}
+
+// highlight экранирует текст и оборачивает найденные слова в <mark>, как ts_headline
+func highlight(terms []string, text string) string {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// Смена регистра изменила длину в байтах, позиции не совпадут
+		return html.EscapeString(text)
+	}
+
+	marked := make([]bool, len(text))
+	for _, term := range terms {
+		for start := 0; ; {
+			idx := strings.Index(lower[start:], term)
+			if idx < 0 {
+				break
+			}
+			for k := start + idx; k < start+idx+len(term); k++ {
+				marked[k] = true
+			}
+			start += idx + len(term)
+		}
+	}
+
+	var b strings.Builder
+	open := false
+	for i := 0; i < len(text); {
+		if marked[i] != open {
+			if marked[i] {
+				b.WriteString("<mark>")
+			} else {
+				b.WriteString("</mark>")
+			}
+			open = marked[i]
+		}
+		j := i + 1
+		for j < len(text) && !isRuneStart(text[j]) {
+			j++
+		}
+		b.WriteString(html.EscapeString(text[i:j]))
+		i = j
+	}
+	if open {
+		b.WriteString("</mark>")
+	}
+	return b.String()
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
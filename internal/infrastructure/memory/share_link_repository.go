@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+)
+
+var _ domain.ShareLinkRepository = (*ShareLinkRepository)(nil)
+
+type ShareLinkRepository struct {
+	store *Store
+}
+
+func NewShareLinkRepository(store *Store) *ShareLinkRepository {
+	return &ShareLinkRepository{store: store}
+}
+
+// CreateShareLink создает ссылку
+func (r *ShareLinkRepository) CreateShareLink(ctx context.Context, link *models.ShareLink) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.nextShareLinkID++
+	link.ID = r.store.nextShareLinkID
+	link.CreatedAt = time.Now()
+
+	r.store.shareLinks[link.ID] = copyShareLink(link)
+	return nil
+}
+
+// GetShareLink получает ссылку по ID
+func (r *ShareLinkRepository) GetShareLink(ctx context.Context, id uint) (*models.ShareLink, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	link, ok := r.store.shareLinks[id]
+	if !ok {
+		return nil, fmt.Errorf("failed to get share link: %w", utils.ErrNotFound)
+	}
+	return copyShareLink(link), nil
+}
+
+// ListShareLinks возвращает ссылки расписания
+func (r *ShareLinkRepository) ListShareLinks(ctx context.Context, scheduleID uint) ([]models.ShareLink, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	links := []models.ShareLink{}
+	for _, link := range r.store.shareLinks {
+		if link.ScheduleID == scheduleID {
+			links = append(links, *copyShareLink(link))
+		}
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].ID < links[j].ID })
+	return links, nil
+}
+
+// RevokeShareLink отзывает ссылку
+func (r *ShareLinkRepository) RevokeShareLink(ctx context.Context, id uint, at time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	link, ok := r.store.shareLinks[id]
+	if !ok {
+		return fmt.Errorf("failed to get share link for revocation: %w", utils.ErrNotFound)
+	}
+	if link.RevokedAt == nil {
+		link.RevokedAt = &at
+	}
+	return nil
+}
+
+// RecordShareAccess учитывает обращение по ссылке
+func (r *ShareLinkRepository) RecordShareAccess(ctx context.Context, id uint, at time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	link, ok := r.store.shareLinks[id]
+	if !ok {
+		return fmt.Errorf("failed to get share link: %w", utils.ErrNotFound)
+	}
+	link.AccessCount++
+	link.LastAccessedAt = &at
+	return nil
+}
+
+func copyShareLink(link *models.ShareLink) *models.ShareLink {
+	cp := *link
+	cp.Token = ""
+	if link.Version != nil {
+		version := *link.Version
+		cp.Version = &version
+	}
+	if link.RevokedAt != nil {
+		revokedAt := *link.RevokedAt
+		cp.RevokedAt = &revokedAt
+	}
+	if link.LastAccessedAt != nil {
+		lastAccessedAt := *link.LastAccessedAt
+		cp.LastAccessedAt = &lastAccessedAt
+	}
+	return &cp
+}
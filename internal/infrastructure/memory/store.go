@@ -0,0 +1,85 @@
+// internal/infrastructure/memory/store.go
+package memory
+
+import (
+	"sync"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+// Store — хранилище в памяти процесса для локальных демонстраций и тестов.
+// Все репозитории, созданные поверх одного Store, видят общие данные.
+// Операции записи выполняются под общей блокировкой над копиями данных
+// и фиксируются только целиком, что повторяет транзакции GORM-реализации.
+type Store struct {
+	mu sync.RWMutex
+
+	schedules  map[uint]*models.Schedule
+	versions   []models.ScheduleVersion
+	ruleSets   map[uint]models.RuleSet
+	performers map[uint]*models.Performer
+	resources  map[uint]*models.Resource
+	shareLinks map[uint]*models.ShareLink
+	reminders  map[uint]*models.Reminder
+
+	nextScheduleID     uint
+	nextBlockID        uint
+	nextItemID         uint
+	nextConstraintID   uint
+	nextVersionID      uint
+	nextPerformerID    uint
+	nextAvailabilityID uint
+	nextResourceID     uint
+	nextShareLinkID    uint
+	nextReminderID     uint
+}
+
+func NewStore() *Store {
+	return &Store{
+		schedules:  make(map[uint]*models.Schedule),
+		ruleSets:   make(map[uint]models.RuleSet),
+		performers: make(map[uint]*models.Performer),
+		resources:  make(map[uint]*models.Resource),
+		shareLinks: make(map[uint]*models.ShareLink),
+		reminders:  make(map[uint]*models.Reminder),
+	}
+}
+
+// copySchedule возвращает глубокую копию расписания
+func copySchedule(schedule *models.Schedule) *models.Schedule {
+	cp := *schedule
+	cp.Blocks = make([]models.Block, len(schedule.Blocks))
+	for i := range schedule.Blocks {
+		cp.Blocks[i] = copyBlock(&schedule.Blocks[i])
+	}
+	cp.Constraints = append([]models.BlockConstraint{}, schedule.Constraints...)
+	return &cp
+}
+
+func copyBlock(block *models.Block) models.Block {
+	cp := *block
+	cp.Resources = models.CopyAssignments(block.Resources)
+	cp.Items = make([]models.BlockItem, len(block.Items))
+	copy(cp.Items, block.Items)
+	for i := range cp.Items {
+		cp.Items[i].PerformerIDs = models.SortedIDs(block.Items[i].PerformerIDs)
+		cp.Items[i].Resources = models.CopyAssignments(block.Items[i].Resources)
+	}
+	return cp
+}
+
+func copyPerformer(performer *models.Performer) *models.Performer {
+	cp := *performer
+	cp.Availability = append([]models.PerformerAvailability{}, performer.Availability...)
+	return &cp
+}
+
+func copyVersion(version *models.ScheduleVersion) models.ScheduleVersion {
+	cp := *version
+	cp.Data = append([]byte(nil), version.Data...)
+	if version.RestoredFrom != nil {
+		restoredFrom := *version.RestoredFrom
+		cp.RestoredFrom = &restoredFrom
+	}
+	return cp
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+)
+
+var _ domain.VersionRepository = (*VersionRepository)(nil)
+
+type VersionRepository struct {
+	store *Store
+}
+
+func NewVersionRepository(store *Store) *VersionRepository {
+	return &VersionRepository{store: store}
+}
+
+// CreateVersion создает новую версию расписания
+func (r *VersionRepository) CreateVersion(ctx context.Context, version *models.ScheduleVersion) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.nextVersionID++
+	version.ID = r.store.nextVersionID
+	r.store.versions = append(r.store.versions, copyVersion(version))
+	return nil
+}
+
+// GetLatestVersion получает последнюю версию расписания
+func (r *VersionRepository) GetLatestVersion(ctx context.Context, scheduleID uint) (*models.ScheduleVersion, error) {
+	versions, err := r.GetVersionsByScheduleID(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("failed to get latest version: %w", utils.ErrNotFound)
+	}
+	return &versions[0], nil
+}
+
+// GetVersionsByScheduleID получает все версии расписания
+func (r *VersionRepository) GetVersionsByScheduleID(ctx context.Context, scheduleID uint) ([]models.ScheduleVersion, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var versions []models.ScheduleVersion
+	for i := range r.store.versions {
+		if r.store.versions[i].ScheduleID == scheduleID {
+			versions = append(versions, copyVersion(&r.store.versions[i]))
+		}
+	}
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].Version > versions[j].Version })
+
+	return versions, nil
+}
+
+// latestVersion возвращает копию последней версии расписания или nil;
+// вызывается под блокировкой хранилища
+func (s *Store) latestVersion(scheduleID uint) *models.ScheduleVersion {
+	var latest *models.ScheduleVersion
+	for i := range s.versions {
+		version := &s.versions[i]
+		if version.ScheduleID == scheduleID && (latest == nil || version.Version > latest.Version) {
+			latest = version
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	cp := copyVersion(latest)
+	return &cp
+}
@@ -0,0 +1,28 @@
+package notifier
+
+import (
+	"context"
+
+	"cor-events-scheduler/internal/domain/notify"
+
+	"go.uber.org/zap"
+)
+
+// Log только записывает уведомления в журнал; канал по умолчанию
+type Log struct {
+	logger *zap.Logger
+}
+
+func NewLog(logger *zap.Logger) *Log {
+	return &Log{logger: logger}
+}
+
+func (l *Log) Notify(_ context.Context, notification notify.Notification) error {
+	l.logger.Info("Performer notification",
+		zap.Uint("schedule_id", notification.ScheduleID),
+		zap.Uint("performer_id", notification.Recipient.ID),
+		zap.String("subject", notification.Subject()),
+		zap.String("text", notification.Text()),
+	)
+	return nil
+}
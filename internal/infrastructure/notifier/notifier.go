@@ -0,0 +1,34 @@
+// Package notifier реализует каналы доставки уведомлений выступающих:
+// журнал, почту, вебхук и Telegram, а также Recorder для тестов.
+package notifier
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"cor-events-scheduler/internal/config"
+	"cor-events-scheduler/internal/domain/notify"
+
+	"go.uber.org/zap"
+)
+
+// requestTimeout ограничивает одно обращение к внешнему сервису
+const requestTimeout = 10 * time.Second
+
+// New создает канал доставки, выбранный в конфигурации
+func New(cfg config.NotifyConfig, logger *zap.Logger) (notify.Notifier, error) {
+	client := &http.Client{Timeout: requestTimeout}
+
+	switch cfg.Driver {
+	case config.NotifyLog:
+		return NewLog(logger), nil
+	case config.NotifySMTP:
+		return NewSMTP(cfg.SMTP), nil
+	case config.NotifyWebhook:
+		return NewWebhook(cfg.WebhookURL, client), nil
+	case config.NotifyTelegram:
+		return NewTelegram(cfg.TelegramToken, client), nil
+	}
+	return nil, fmt.Errorf("unsupported notify driver %q", cfg.Driver)
+}
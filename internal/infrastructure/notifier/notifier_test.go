@@ -0,0 +1,117 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/config"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/notify"
+)
+
+func newNotification(contact string) notify.Notification {
+	from := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	return notify.Notification{
+		Recipient:    notify.Recipient{ID: 7, Name: "Анна", Contact: contact},
+		ScheduleID:   1,
+		ScheduleName: "Фестиваль",
+		Language:     models.LanguageRussian,
+		Moves:        []notify.Move{{ItemID: 3, Name: "Гимн", Block: "Открытие", From: from, To: from.Add(15 * time.Minute)}},
+	}
+}
+
+func TestWebhookPostsNotification(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL, server.Client())
+	if err := webhook.Notify(context.Background(), newNotification("anna@example.com")); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if received["subject"] != "Изменилось время выступления — Фестиваль" {
+		t.Errorf("subject = %v", received["subject"])
+	}
+	if text, _ := received["text"].(string); !strings.Contains(text, "01.06 10:00 → 10:15") {
+		t.Errorf("text = %q", text)
+	}
+	if moves, _ := received["moves"].([]any); len(moves) != 1 {
+		t.Errorf("moves = %v", received["moves"])
+	}
+}
+
+func TestWebhookFailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL, server.Client())
+	if err := webhook.Notify(context.Background(), newNotification("")); err == nil {
+		t.Fatal("expected error for 502 response")
+	}
+}
+
+func TestTelegramSendsMessage(t *testing.T) {
+	var path string
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	telegram := NewTelegram("secret", server.Client())
+	telegram.BaseURL = server.URL
+	if err := telegram.Notify(context.Background(), newNotification("123456")); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if path != "/botsecret/sendMessage" {
+		t.Errorf("path = %q", path)
+	}
+	if received["chat_id"] != "123456" || !strings.Contains(received["text"], "«Гимн»") {
+		t.Errorf("body = %v", received)
+	}
+
+	err := telegram.Notify(context.Background(), newNotification("+7 900 000-00-00"))
+	if !errors.Is(err, notify.ErrNoAddress) {
+		t.Errorf("phone contact: err = %v, want ErrNoAddress", err)
+	}
+}
+
+func TestSMTPSendsEmail(t *testing.T) {
+	mailer := NewSMTP(config.SMTPConfig{Host: "mail.example.com", Port: "587", From: "schedule@example.com"})
+	var addr string
+	var to []string
+	var msg []byte
+	mailer.send = func(a string, _ smtp.Auth, _ string, recipients []string, m []byte) error {
+		addr, to, msg = a, recipients, m
+		return nil
+	}
+
+	if err := mailer.Notify(context.Background(), newNotification("Анна <anna@example.com>")); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if addr != "mail.example.com:587" || len(to) != 1 || to[0] != "anna@example.com" {
+		t.Errorf("addr = %q, to = %v", addr, to)
+	}
+	if !strings.Contains(string(msg), "Subject: =?utf-8?q?") || !strings.Contains(string(msg), "«Гимн»") {
+		t.Errorf("message = %q", msg)
+	}
+
+	err := mailer.Notify(context.Background(), newNotification("@anna_stage"))
+	if !errors.Is(err, notify.ErrNoAddress) {
+		t.Errorf("telegram contact: err = %v, want ErrNoAddress", err)
+	}
+}
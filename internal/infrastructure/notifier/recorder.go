@@ -0,0 +1,32 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+
+	"cor-events-scheduler/internal/domain/notify"
+)
+
+// Recorder запоминает уведомления вместо отправки; используется в тестах
+type Recorder struct {
+	mu            sync.Mutex
+	notifications []notify.Notification
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) Notify(_ context.Context, notification notify.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifications = append(r.notifications, notification)
+	return nil
+}
+
+// Sent возвращает полученные уведомления в порядке отправки
+func (r *Recorder) Sent() []notify.Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]notify.Notification(nil), r.notifications...)
+}
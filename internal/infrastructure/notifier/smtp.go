@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"cor-events-scheduler/internal/config"
+	"cor-events-scheduler/internal/domain/notify"
+)
+
+// SMTP отправляет уведомления письмом на адрес из контакта выступающего
+type SMTP struct {
+	config config.SMTPConfig
+	// send — отправка письма; подменяется в тестах
+	send func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSMTP(cfg config.SMTPConfig) *SMTP {
+	return &SMTP{config: cfg, send: smtp.SendMail}
+}
+
+func (s *SMTP) Notify(_ context.Context, notification notify.Notification) error {
+	to, err := mail.ParseAddress(notification.Recipient.Contact)
+	if err != nil {
+		return fmt.Errorf("%w: %q is not an email address", notify.ErrNoAddress, notification.Recipient.Contact)
+	}
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	addr := net.JoinHostPort(s.config.Host, s.config.Port)
+	msg := message(s.config.From, to.Address, notification)
+	if err := s.send(addr, auth, s.config.From, []string{to.Address}, msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// message собирает письмо в UTF-8 с закодированной темой
+func message(from, to string, notification notify.Notification) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Subject()))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.Write(bytes.ReplaceAll([]byte(notification.Text()), []byte("\n"), []byte("\r\n")))
+	return b.Bytes()
+}
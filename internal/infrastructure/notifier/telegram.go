@@ -0,0 +1,46 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"cor-events-scheduler/internal/domain/notify"
+)
+
+// TelegramAPI — адрес Bot API по умолчанию
+const TelegramAPI = "https://api.telegram.org"
+
+// telegramChat — контакт, по которому бот может написать: chat_id или @username
+var telegramChat = regexp.MustCompile(`^(-?\d+|@\w{5,})$`)
+
+// Telegram отправляет уведомления через Bot API; контакт выступающего —
+// chat_id или @username
+type Telegram struct {
+	// BaseURL — адрес Bot API; подменяется в тестах
+	BaseURL string
+	token   string
+	client  *http.Client
+}
+
+func NewTelegram(token string, client *http.Client) *Telegram {
+	return &Telegram{BaseURL: TelegramAPI, token: token, client: client}
+}
+
+func (t *Telegram) Notify(ctx context.Context, notification notify.Notification) error {
+	chat := notification.Recipient.Contact
+	if !telegramChat.MatchString(chat) {
+		return fmt.Errorf("%w: %q is not a Telegram chat", notify.ErrNoAddress, chat)
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"chat_id": chat,
+		"text":    notification.Text(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	return post(ctx, t.client, fmt.Sprintf("%s/bot%s/sendMessage", t.BaseURL, t.token), body)
+}
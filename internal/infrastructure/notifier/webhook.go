@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"cor-events-scheduler/internal/domain/notify"
+)
+
+// Webhook отправляет уведомления в JSON на заданный адрес; доставку
+// выступающему берет на себя получатель
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string, client *http.Client) *Webhook {
+	return &Webhook{url: url, client: client}
+}
+
+// webhookPayload — тело запроса: уведомление вместе с готовыми темой и текстом
+type webhookPayload struct {
+	notify.Notification
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+}
+
+func (w *Webhook) Notify(ctx context.Context, notification notify.Notification) error {
+	body, err := json.Marshal(webhookPayload{
+		Notification: notification,
+		Subject:      notification.Subject(),
+		Text:         notification.Text(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	return post(ctx, w.client, w.url, body)
+}
+
+// post отправляет JSON и считает ошибкой любой ответ, кроме 2xx
+func post(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification endpoint responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// internal/infrastructure/storage/storage.go
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"cor-events-scheduler/internal/config"
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/repositories"
+	"cor-events-scheduler/internal/infrastructure/db"
+	"cor-events-scheduler/internal/infrastructure/memory"
+)
+
+// reminderLockKey — ключ выбора ведущего, который рассылает напоминания
+const reminderLockKey = 7_202_504_102
+
+// Storage — набор репозиториев хранилища, выбранного в config.DatabaseConfig.Driver
+type Storage struct {
+	Schedules  domain.ScheduleRepository
+	Versions   domain.VersionRepository
+	Search     domain.SearchRepository
+	RuleSets   domain.RuleSetRepository
+	Performers domain.PerformerRepository
+	Resources  domain.ResourceRepository
+	Agenda     domain.AgendaRepository
+	ShareLinks domain.ShareLinkRepository
+	Reminders  domain.ReminderRepository
+	// ReminderLock выбирает под, который рассылает напоминания
+	ReminderLock domain.LeaderLock
+}
+
+// Open подключается к хранилищу и подготавливает его к работе
+func Open(ctx context.Context, cfg *config.Config) (*Storage, error) {
+	switch cfg.Database.Driver {
+	case config.DriverMemory:
+		store := memory.NewStore()
+		return &Storage{
+			Schedules:    memory.NewScheduleRepository(store),
+			Versions:     memory.NewVersionRepository(store),
+			Search:       memory.NewSearchRepository(store),
+			RuleSets:     memory.NewRuleSetRepository(store),
+			Performers:   memory.NewPerformerRepository(store),
+			Resources:    memory.NewResourceRepository(store),
+			Agenda:       memory.NewAgendaRepository(store),
+			ShareLinks:   memory.NewShareLinkRepository(store),
+			Reminders:    memory.NewReminderRepository(store),
+			ReminderLock: db.NewLocalLock(),
+		}, nil
+
+	case config.DriverPostgres, config.DriverSQLite:
+		database, err := db.NewDatabase(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		searchRepo := repositories.NewSearchRepository(database)
+		if err := searchRepo.RebuildIfEmpty(ctx); err != nil {
+			return nil, fmt.Errorf("failed to build search index: %w", err)
+		}
+
+		reminderLock, err := db.NewLeaderLock(database, reminderLockKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Storage{
+			Schedules:    repositories.NewScheduleRepository(database),
+			Versions:     repositories.NewVersionRepository(database),
+			Search:       searchRepo,
+			RuleSets:     repositories.NewRuleSetRepository(database),
+			Performers:   repositories.NewPerformerRepository(database),
+			Resources:    repositories.NewResourceRepository(database),
+			Agenda:       repositories.NewAgendaRepository(database),
+			ShareLinks:   repositories.NewShareLinkRepository(database),
+			Reminders:    repositories.NewReminderRepository(database),
+			ReminderLock: reminderLock,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", cfg.Database.Driver)
+	}
+}
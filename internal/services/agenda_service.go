@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// maxAgendaRange ограничивает интервал ленты, чтобы один запрос не выбирал
+// программу за годы
+const maxAgendaRange = 31 * 24 * time.Hour
+
+// AgendaService собирает ленту блоков и элементов всех расписаний
+type AgendaService struct {
+	agendaRepo domain.AgendaRepository
+	logger     *zap.Logger
+}
+
+func NewAgendaService(agendaRepo domain.AgendaRepository, logger *zap.Logger) *AgendaService {
+	return &AgendaService{
+		agendaRepo: agendaRepo,
+		logger:     logger,
+	}
+}
+
+// AgendaQuery — параметры ленты; повторяющиеся schedule_id, type и track
+// объединяются через ИЛИ
+type AgendaQuery struct {
+	From        time.Time `form:"from" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	To          time.Time `form:"to" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	ScheduleIDs []uint    `form:"schedule_id"`
+	Types       []string  `form:"type"`
+	Tracks      []string  `form:"track"`
+	Items       bool      `form:"items"`
+}
+
+// Agenda — блоки и элементы всех расписаний в интервале [from, to) по времени начала
+type Agenda struct {
+	From    time.Time            `json:"from"`
+	To      time.Time            `json:"to"`
+	Entries []models.AgendaEntry `json:"entries"`
+}
+
+func (s *AgendaService) GetAgenda(ctx context.Context, query AgendaQuery) (*Agenda, error) {
+	if !query.To.After(query.From) {
+		return nil, fmt.Errorf("%w: to must be after from", utils.ErrInvalidInput)
+	}
+	if query.To.Sub(query.From) > maxAgendaRange {
+		return nil, fmt.Errorf("%w: the agenda range must not exceed %d days",
+			utils.ErrInvalidInput, int(maxAgendaRange/(24*time.Hour)))
+	}
+
+	entries, err := s.agendaRepo.ListAgenda(ctx, models.AgendaFilter{
+		From:        query.From,
+		To:          query.To,
+		ScheduleIDs: query.ScheduleIDs,
+		Types:       query.Types,
+		Tracks:      query.Tracks,
+		Items:       query.Items,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agenda: %w", err)
+	}
+
+	return &Agenda{From: query.From, To: query.To, Entries: entries}, nil
+}
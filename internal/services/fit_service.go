@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/fit"
+	"cor-events-scheduler/internal/domain/validation"
+
+	"go.uber.org/zap"
+)
+
+// FitService подгоняет длительности сохраненных расписаний под новое окончание
+// и сохраняет результат, только если клиент его принял
+type FitService struct {
+	scheduleRepo     domain.ScheduleRepository
+	schedulerService *SchedulerService
+	logger           *zap.Logger
+}
+
+func NewFitService(
+	scheduleRepo domain.ScheduleRepository,
+	schedulerService *SchedulerService,
+	logger *zap.Logger,
+) *FitService {
+	return &FitService{
+		scheduleRepo:     scheduleRepo,
+		schedulerService: schedulerService,
+		logger:           logger,
+	}
+}
+
+// FitRequest — новое окончание расписания, стратегия и решение о сохранении результата
+type FitRequest struct {
+	EndDate time.Time `json:"end_date" binding:"required"`
+	// Strategy — proportional (по умолчанию), priority или minimum
+	Strategy string `json:"strategy"`
+	// Accept сохраняет подогнанное расписание так же, как обычное обновление
+	Accept bool `json:"accept"`
+}
+
+// FitResponse — подогнанное расписание и отчет его проверки
+type FitResponse struct {
+	*fit.Result
+	Applied    bool              `json:"applied"`
+	Validation validation.Report `json:"validation"`
+}
+
+// FitSchedule пересчитывает длительности элементов и техперерывов так, чтобы
+// расписание заканчивалось ровно в request.EndDate. Без request.Accept
+// расписание не изменяется.
+func (s *FitService) FitSchedule(ctx context.Context, id uint, request FitRequest) (*FitResponse, error) {
+	schedule, err := s.scheduleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	result, err := fit.Fit(schedule, request.EndDate, request.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	response := &FitResponse{Result: result}
+
+	response.Validation, response.Applied, err = s.schedulerService.previewOrApply(ctx, result.Schedule, request.Accept)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Applied {
+		return response, nil
+	}
+
+	s.logger.Info("Applied fitted schedule",
+		zap.Uint("schedule_id", id),
+		zap.String("strategy", result.Strategy),
+		zap.Int("from_minutes", result.FromMinutes),
+		zap.Int("to_minutes", result.ToMinutes),
+		zap.Int("changes", len(result.Changes)),
+	)
+
+	return response, nil
+}
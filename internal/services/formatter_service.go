@@ -5,6 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/views"
+	"cor-events-scheduler/internal/web"
+	"cor-events-scheduler/pkg/utils"
+
 	"go.uber.org/zap"
 )
 
@@ -20,55 +25,79 @@ func NewFormatterService(scheduleService *SchedulerService, logger *zap.Logger)
 	}
 }
 
-type PublicSchedule struct {
-	StartDate time.Time     `json:"start_date"`
-	EndDate   time.Time     `json:"end_date"`
-	Blocks    []PublicBlock `json:"blocks"`
+// ViewQuery — параметры представления; performer_id обязателен для
+// представлений по выступающему
+type ViewQuery struct {
+	PerformerID uint `form:"performer_id"`
 }
 
-type PublicBlock struct {
-	Name      string       `json:"name"`
-	StartTime time.Time    `json:"start_time"`
-	Duration  int          `json:"duration"`
-	Items     []PublicItem `json:"items"`
+// ListViews возвращает определения всех представлений
+func (s *FormatterService) ListViews() []views.View {
+	return views.List()
 }
 
-type PublicItem struct {
-	Name     string `json:"name"`
-	Duration int    `json:"duration"`
-}
+// FormatView возвращает расписание в представлении с названием name
+func (s *FormatterService) FormatView(ctx context.Context, scheduleID uint, name string, query ViewQuery) (views.Object, error) {
+	view, ok := views.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown view %q", utils.ErrNotFound, name)
+	}
+	if view.ByPerformer && query.PerformerID == 0 {
+		return nil, fmt.Errorf("%w: view %q requires performer_id", utils.ErrInvalidInput, name)
+	}
 
-func (s *FormatterService) FormatPublicSchedule(ctx context.Context, scheduleID uint) (*PublicSchedule, error) {
 	schedule, err := s.scheduleService.GetSchedule(ctx, scheduleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get schedule: %w", err)
 	}
+	return view.Project(view.Filter(schedule, query.PerformerID)), nil
+}
+
+// FormatPublicSchedule возвращает расписание в представлении для участников
+func (s *FormatterService) FormatPublicSchedule(ctx context.Context, scheduleID uint) (views.Object, error) {
+	return s.FormatView(ctx, scheduleID, views.Public, ViewQuery{})
+}
+
+// PublicPageQuery — режим публичной страницы
+type PublicPageQuery struct {
+	Embed bool `form:"embed"`
+}
+
+// RenderPublicPage возвращает HTML-страницу расписания с адресом slug; на
+// странице только то, что видно в представлении для участников
+func (s *FormatterService) RenderPublicPage(ctx context.Context, slug string, options web.Options) ([]byte, error) {
+	schedule, err := s.scheduleService.GetScheduleBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+	view, _ := views.Get(views.Public)
+	return renderPage(view.Filter(schedule, 0), options)
+}
 
-	publicSchedule := &PublicSchedule{
+// renderPage строит HTML-страницу уже отфильтрованного расписания
+func renderPage(schedule *models.Schedule, options web.Options) ([]byte, error) {
+	page := &web.Schedule{
+		Name:      schedule.Name,
+		Language:  schedule.Language,
+		Location:  schedule.Location(),
 		StartDate: schedule.StartDate,
 		EndDate:   schedule.EndDate,
-		Blocks:    make([]PublicBlock, len(schedule.Blocks)),
+		Blocks:    make([]web.Block, len(schedule.Blocks)),
 	}
-
 	for i, block := range schedule.Blocks {
-		publicBlock := PublicBlock{
+		page.Blocks[i] = web.Block{
 			Name:      block.Name,
+			Track:     block.Track,
 			StartTime: block.StartTime,
-			Duration:  block.Duration,
-			Items:     make([]PublicItem, len(block.Items)),
+			EndTime:   block.StartTime.Add(time.Duration(block.Duration) * time.Minute),
+			Items:     make([]web.Item, len(block.Items)),
 		}
-
 		for j, item := range block.Items {
-			publicBlock.Items[j] = PublicItem{
-				Name:     item.Name,
-				Duration: item.Duration,
-			}
+			page.Blocks[i].Items[j] = web.Item{Name: item.Name, StartTime: item.StartTime, EndTime: item.EndTime}
 		}
-
-		publicSchedule.Blocks[i] = publicBlock
 	}
 
-	return publicSchedule, nil
+	return web.Render(page, options)
 }
 
 func (s *FormatterService) FormatScheduleText(ctx context.Context, scheduleID uint) (string, error) {
@@ -76,26 +105,36 @@ func (s *FormatterService) FormatScheduleText(ctx context.Context, scheduleID ui
 	if err != nil {
 		return "", fmt.Errorf("failed to get schedule: %w", err)
 	}
+	for i := range schedule.Blocks {
+		schedule.Blocks[i].LayoutItems()
+	}
+	return formatText(schedule), nil
+}
 
+// formatText возвращает расписание с рассчитанными временами элементов в виде
+// текста; времена выводятся в часовом поясе расписания, как на публичной странице
+func formatText(schedule *models.Schedule) string {
 	var result string
+	location := schedule.Location()
 
 	result += fmt.Sprintf("Расписание с %s по %s\n\n",
-		schedule.StartDate.Format("02.01.2006 15:04"),
-		schedule.EndDate.Format("02.01.2006 15:04"))
+		schedule.StartDate.In(location).Format("02.01.2006 15:04"),
+		schedule.EndDate.In(location).Format("02.01.2006 15:04"))
 
 	for _, block := range schedule.Blocks {
 		result += fmt.Sprintf("Блок: %s\n", block.Name)
-		result += fmt.Sprintf("Начало: %s\n", block.StartTime.Format("15:04"))
+		result += fmt.Sprintf("Начало: %s\n", block.StartTime.In(location).Format("15:04"))
 		result += fmt.Sprintf("Длительность: %d минут\n", block.Duration)
 
 		if len(block.Items) > 0 {
 			result += "Элементы:\n"
 			for _, item := range block.Items {
-				result += fmt.Sprintf("- %s (%d минут)\n", item.Name, item.Duration)
+				result += fmt.Sprintf("- %s–%s %s (%d минут)\n",
+					item.StartTime.In(location).Format("15:04"), item.EndTime.In(location).Format("15:04"), item.Name, item.Duration)
 			}
 		}
 		result += "\n"
 	}
 
-	return result, nil
+	return result
 }
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+)
+
+func TestFormatTextUsesScheduleTimezone(t *testing.T) {
+	schedule := domaintest.NewSchedule("Фестиваль")
+	schedule.Timezone = "Europe/Moscow"
+	processBlockTimes(schedule)
+
+	text := formatText(schedule)
+	for _, want := range []string{"Расписание с 01.04.2024 13:00 по 01.04.2024 19:00", "Начало: 13:00", "- 13:40–14:00 Участник 1"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("text must use the schedule time zone, want %q in:\n%s", want, text)
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package services
 
 import (
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/risk"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// SchedulerMetrics — метрики изменений расписаний. Методы допускают nil,
+// чтобы сервисы можно было собирать без метрик, например в тестах.
 type SchedulerMetrics struct {
 	scheduleCreations  prometheus.Counter
 	scheduleUpdates    prometheus.Counter
@@ -12,7 +17,8 @@ type SchedulerMetrics struct {
 	techBreakDurations prometheus.Histogram
 }
 
-func NewSchedulerMetrics() *SchedulerMetrics {
+// NewSchedulerMetrics создает метрики и регистрирует их в registerer
+func NewSchedulerMetrics(registerer prometheus.Registerer) *SchedulerMetrics {
 	metrics := &SchedulerMetrics{
 		scheduleCreations: prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "schedule_creations_total",
@@ -38,7 +44,7 @@ func NewSchedulerMetrics() *SchedulerMetrics {
 		}),
 	}
 
-	prometheus.MustRegister(
+	registerer.MustRegister(
 		metrics.scheduleCreations,
 		metrics.scheduleUpdates,
 		metrics.scheduleDeletions,
@@ -48,3 +54,34 @@ func NewSchedulerMetrics() *SchedulerMetrics {
 
 	return metrics
 }
+
+func (m *SchedulerMetrics) scheduleCreated() {
+	if m != nil {
+		m.scheduleCreations.Inc()
+	}
+}
+
+func (m *SchedulerMetrics) scheduleUpdated() {
+	if m != nil {
+		m.scheduleUpdates.Inc()
+	}
+}
+
+func (m *SchedulerMetrics) scheduleDeleted() {
+	if m != nil {
+		m.scheduleDeletions.Inc()
+	}
+}
+
+// observeSchedule записывает оценку риска сохраненного расписания и техперерывы
+// между его блоками; перерыв после последнего блока не учитывается
+func (m *SchedulerMetrics) observeSchedule(schedule *models.Schedule, assessment *risk.Assessment) {
+	if m == nil {
+		return
+	}
+
+	m.scheduleRiskScores.Observe(assessment.Score)
+	for i := 0; i < len(schedule.Blocks)-1; i++ {
+		m.techBreakDurations.Observe(float64(schedule.Blocks[i].TechBreakDuration))
+	}
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/notify"
+	"cor-events-scheduler/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// flushTimeout ограничивает отправку уведомлений одной серии правок
+const flushTimeout = 30 * time.Second
+
+// NotificationService сообщает выступающим о смещении их выступлений. Правки
+// одного расписания копятся в течение окна, начинающегося с первой правки, и
+// отправляются одним уведомлением на выступающего. Nil-сервис ничего не делает.
+type NotificationService struct {
+	notifier   notify.Notifier
+	performers *PerformerService
+	threshold  time.Duration
+	window     time.Duration
+	logger     *zap.Logger
+
+	mu      sync.Mutex
+	batches map[uint]*notify.Batch
+	timers  map[uint]*time.Timer
+}
+
+func NewNotificationService(
+	notifier notify.Notifier,
+	performers *PerformerService,
+	threshold time.Duration,
+	window time.Duration,
+	logger *zap.Logger,
+) *NotificationService {
+	return &NotificationService{
+		notifier:   notifier,
+		performers: performers,
+		threshold:  threshold,
+		window:     window,
+		logger:     logger,
+		batches:    make(map[uint]*notify.Batch),
+		timers:     make(map[uint]*time.Timer),
+	}
+}
+
+// ScheduleChanged добавляет правку расписания в серию; before и after не
+// должны изменяться после вызова
+func (s *NotificationService) ScheduleChanged(before, after *models.Schedule) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := after.ID
+	batch, ok := s.batches[id]
+	if !ok {
+		batch = notify.NewBatch(s.threshold)
+		s.batches[id] = batch
+		s.timers[id] = time.AfterFunc(s.window, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+			defer cancel()
+			s.send(ctx, id)
+		})
+	}
+	batch.Add(before, after)
+}
+
+// Flush сразу отправляет все накопленные серии; вызывается при остановке
+func (s *NotificationService) Flush(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	ids := make([]uint, 0, len(s.batches))
+	for id := range s.batches {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+
+	for _, id := range ids {
+		s.send(ctx, id)
+	}
+}
+
+// send забирает серию расписания и отправляет уведомления по ней; ошибки
+// доставки записываются в журнал и не повторяются
+func (s *NotificationService) send(ctx context.Context, scheduleID uint) {
+	s.mu.Lock()
+	batch, ok := s.batches[scheduleID]
+	if ok {
+		s.timers[scheduleID].Stop()
+		delete(s.batches, scheduleID)
+		delete(s.timers, scheduleID)
+	}
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	var recipients []notify.Recipient
+	for _, id := range batch.PerformerIDs() {
+		performer, err := s.performers.GetPerformer(ctx, id)
+		if errors.Is(err, utils.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			s.logger.Error("Failed to get performer to notify", zap.Uint("performer_id", id), zap.Error(err))
+			continue
+		}
+		recipients = append(recipients, notify.Recipient{ID: performer.ID, Name: performer.Name, Contact: performer.Contact})
+	}
+
+	for _, notification := range batch.Notifications(recipients) {
+		fields := []zap.Field{
+			zap.Uint("schedule_id", scheduleID),
+			zap.Uint("performer_id", notification.Recipient.ID),
+			zap.Int("moves", len(notification.Moves)),
+		}
+		err := s.notifier.Notify(ctx, notification)
+		switch {
+		case errors.Is(err, notify.ErrNoAddress):
+			s.logger.Info("Performer has no address for notification", append(fields, zap.Error(err))...)
+		case err != nil:
+			s.logger.Error("Failed to notify performer", append(fields, zap.Error(err))...)
+		default:
+			s.logger.Info("Notified performer", fields...)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/risk"
+	"cor-events-scheduler/internal/infrastructure/memory"
+	"cor-events-scheduler/internal/infrastructure/notifier"
+
+	"go.uber.org/zap"
+)
+
+func TestNotificationsBatchScheduleEdits(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	scheduleRepo := memory.NewScheduleRepository(store)
+	versionRepo := memory.NewVersionRepository(store)
+	ruleService := NewRuleService(memory.NewRuleSetRepository(store), scheduleRepo, zap.NewNop())
+	riskService := NewRiskService(scheduleRepo, risk.NewAnalyzer(risk.Options{}), nil, zap.NewNop())
+	performerService := NewPerformerService(memory.NewPerformerRepository(store), zap.NewNop())
+	resourceService := NewResourceService(memory.NewResourceRepository(store), zap.NewNop())
+
+	recorder := notifier.NewRecorder()
+	// Окно больше времени теста: отправку запускает Flush
+	notifications := NewNotificationService(recorder, performerService, 5*time.Minute, time.Hour, zap.NewNop())
+	schedulerService := NewSchedulerService(scheduleRepo, versionRepo, ruleService, riskService, performerService, resourceService, nil, notifications, nil, zap.NewNop())
+	versionService := NewVersionService(versionRepo, scheduleRepo, ruleService, performerService, resourceService, schedulerService, zap.NewNop())
+
+	anna := &models.Performer{Name: "Анна", Contact: "anna@example.com"}
+	boris := &models.Performer{Name: "Борис", Contact: "+7 900 000-00-00"}
+	for _, performer := range []*models.Performer{anna, boris} {
+		if err := performerService.CreatePerformer(ctx, performer); err != nil {
+			t.Fatalf("create performer: %v", err)
+		}
+	}
+
+	schedule := domaintest.NewSchedule("Фестиваль")
+	schedule.Blocks[0].SlackPolicy = models.SlackPolicyEnd
+	schedule.Blocks[0].Items[0].PerformerIDs = []uint{boris.ID}
+	schedule.Blocks[1].Items[0].PerformerIDs = []uint{anna.ID}
+	if _, err := schedulerService.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Две правки подряд удлиняют «Открытие» и сдвигают «Косплей» на 10 и еще
+	// на 10 минут; первое выступление «Открытия» остается на месте
+	for range 2 {
+		current, err := schedulerService.GetSchedule(ctx, schedule.ID)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		current.Blocks[0].Duration += 10
+		if _, err := schedulerService.UpdateSchedule(ctx, current); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+	}
+	if sent := recorder.Sent(); len(sent) != 0 {
+		t.Fatalf("sent before flush: %d notifications", len(sent))
+	}
+
+	notifications.Flush(ctx)
+
+	sent := recorder.Sent()
+	if len(sent) != 1 {
+		t.Fatalf("sent %d notifications, want 1: %+v", len(sent), sent)
+	}
+	notification := sent[0]
+	if notification.Recipient.ID != anna.ID || notification.Recipient.Contact != anna.Contact {
+		t.Errorf("recipient = %+v, want Анна", notification.Recipient)
+	}
+	if len(notification.Moves) != 1 {
+		t.Fatalf("moves = %+v, want one", notification.Moves)
+	}
+	if move := notification.Moves[0]; move.To.Sub(move.From) != 20*time.Minute {
+		t.Errorf("move = %+v, want 20 min later", move)
+	}
+
+	// Серия отправлена и больше не повторяется
+	notifications.Flush(ctx)
+	if len(recorder.Sent()) != 1 {
+		t.Errorf("flush resent notifications")
+	}
+
+	// Восстановление исходной версии возвращает «Косплей» на 20 минут раньше
+	if _, err := versionService.RestoreVersion(ctx, schedule.ID, 1, "test"); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	notifications.Flush(ctx)
+	sent = recorder.Sent()
+	if len(sent) != 2 || sent[1].Recipient.ID != anna.ID || len(sent[1].Moves) != 1 {
+		t.Fatalf("want one notification about the restored slot, got %+v", sent)
+	}
+	if move := sent[1].Moves[0]; move.From.Sub(move.To) != 20*time.Minute {
+		t.Errorf("move = %+v, want 20 min earlier", move)
+	}
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/live"
+
+	"go.uber.org/zap"
+)
+
+// NowService показывает, что идет на сцене по плановому таймлайну расписания
+type NowService struct {
+	scheduleRepo domain.ScheduleRepository
+	logger       *zap.Logger
+}
+
+func NewNowService(scheduleRepo domain.ScheduleRepository, logger *zap.Logger) *NowService {
+	return &NowService{
+		scheduleRepo: scheduleRepo,
+		logger:       logger,
+	}
+}
+
+// NowQuery — момент, на который нужен статус; без at берется текущее время
+type NowQuery struct {
+	At time.Time `form:"at" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// GetNow возвращает текущие и следующие блок и элемент расписания в момент at
+func (s *NowService) GetNow(ctx context.Context, id uint, at time.Time) (*live.Status, error) {
+	schedule, err := s.scheduleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	for i := range schedule.Blocks {
+		schedule.Blocks[i].LayoutItems()
+	}
+	return live.At(schedule, at), nil
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/optimize"
+	"cor-events-scheduler/internal/domain/validation"
+	"cor-events-scheduler/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// OptimizerService предлагает порядок блоков и техперерывы для сохраненных
+// расписаний и сохраняет предложение, только если клиент его принял
+type OptimizerService struct {
+	scheduleRepo     domain.ScheduleRepository
+	ruleService      *RuleService
+	schedulerService *SchedulerService
+	optimizer        *optimize.Optimizer
+	logger           *zap.Logger
+}
+
+func NewOptimizerService(
+	scheduleRepo domain.ScheduleRepository,
+	ruleService *RuleService,
+	schedulerService *SchedulerService,
+	optimizer *optimize.Optimizer,
+	logger *zap.Logger,
+) *OptimizerService {
+	return &OptimizerService{
+		scheduleRepo:     scheduleRepo,
+		ruleService:      ruleService,
+		schedulerService: schedulerService,
+		optimizer:        optimizer,
+		logger:           logger,
+	}
+}
+
+// OptimizeRequest — ограничения оптимизации и решение о сохранении результата
+type OptimizeRequest struct {
+	optimize.Constraints
+	// Accept сохраняет предложенное расписание так же, как обычное обновление
+	Accept bool `json:"accept"`
+	// Revision — ревизия из ответа предпросмотра; обязательна вместе с Accept
+	Revision string `json:"revision,omitempty"`
+}
+
+// OptimizeResponse — предложение оптимизатора и отчет его проверки
+type OptimizeResponse struct {
+	*optimize.Result
+	// Revision связывает предложение с состоянием расписания, по которому оно построено
+	Revision   string            `json:"revision"`
+	Applied    bool              `json:"applied"`
+	Validation validation.Report `json:"validation"`
+}
+
+// OptimizeSchedule подбирает порядок блоков расписания. Без request.Accept
+// расписание не изменяется. С request.Accept сохраняется только то
+// предложение, которое клиент видел: если расписание или предложение
+// изменились с предпросмотра, возвращается utils.ErrConflict.
+func (s *OptimizerService) OptimizeSchedule(ctx context.Context, id uint, request OptimizeRequest) (*OptimizeResponse, error) {
+	schedule, err := s.scheduleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	ruleSet, err := s.ruleService.EffectiveRules(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Accept && request.Revision == "" {
+		return nil, fmt.Errorf("%w: accept requires the revision of the previewed proposal", utils.ErrInvalidInput)
+	}
+
+	result, err := s.optimizer.Optimize(ctx, schedule, request.Constraints, ruleSet)
+	if err != nil {
+		return nil, err
+	}
+	response := &OptimizeResponse{Result: result, Revision: proposalRevision(schedule, result.Schedule)}
+
+	// Оптимизатор детерминирован, поэтому та же ревизия означает то же
+	// расписание и то же предложение
+	if request.Accept && request.Revision != response.Revision {
+		return nil, fmt.Errorf("%w: schedule or rules changed since the preview, optimize again", utils.ErrConflict)
+	}
+
+	response.Validation, response.Applied, err = s.schedulerService.previewOrApply(ctx, result.Schedule, request.Accept)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Applied {
+		return response, nil
+	}
+
+	s.logger.Info("Applied optimized schedule",
+		zap.Uint("schedule_id", id),
+		zap.Int("changed_blocks", len(result.Changes)),
+		zap.Float64("risk_score", result.Proposed.RiskScore),
+	)
+
+	return response, nil
+}
+
+// proposalRevision — отпечаток сохраненного расписания (по времени его
+// изменения) и предложенных порядка блоков и техперерывов
+func proposalRevision(current, proposed *models.Schedule) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d@%d", current.ID, current.UpdatedAt.UnixNano())
+	for _, block := range proposed.Blocks {
+		fmt.Fprintf(hash, ";%d:%d", block.ID, block.TechBreakDuration)
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
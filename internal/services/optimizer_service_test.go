@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/optimize"
+	"cor-events-scheduler/internal/domain/risk"
+	"cor-events-scheduler/internal/infrastructure/memory"
+	"cor-events-scheduler/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+func TestOptimizeAcceptsOnlyThePreviewedProposal(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	scheduleRepo := memory.NewScheduleRepository(store)
+	ruleService := NewRuleService(memory.NewRuleSetRepository(store), scheduleRepo, zap.NewNop())
+	analyzer := risk.NewAnalyzer(risk.Options{})
+	riskService := NewRiskService(scheduleRepo, analyzer, nil, zap.NewNop())
+	performerService := NewPerformerService(memory.NewPerformerRepository(store), zap.NewNop())
+	resourceService := NewResourceService(memory.NewResourceRepository(store), zap.NewNop())
+	schedulerService := NewSchedulerService(scheduleRepo, memory.NewVersionRepository(store), ruleService, riskService, performerService, resourceService, nil, nil, nil, zap.NewNop())
+	service := NewOptimizerService(scheduleRepo, ruleService, schedulerService, optimize.NewOptimizer(analyzer, optimize.Options{}), zap.NewNop())
+
+	schedule := domaintest.NewSchedule("Фестиваль")
+	if _, err := schedulerService.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if _, err := service.OptimizeSchedule(ctx, schedule.ID, OptimizeRequest{Accept: true}); !errors.Is(err, utils.ErrInvalidInput) {
+		t.Fatalf("accept without a preview must fail with ErrInvalidInput, got %v", err)
+	}
+
+	preview, err := service.OptimizeSchedule(ctx, schedule.ID, OptimizeRequest{})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if preview.Applied || preview.Revision == "" {
+		t.Fatalf("preview must not be applied and must carry a revision, got %+v", preview)
+	}
+
+	// Расписание изменилось после предпросмотра: предложение устарело
+	current, err := schedulerService.GetSchedule(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	current.Name = "Фестиваль (перенос)"
+	if _, err := schedulerService.UpdateSchedule(ctx, current); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := service.OptimizeSchedule(ctx, schedule.ID, OptimizeRequest{Accept: true, Revision: preview.Revision}); !errors.Is(err, utils.ErrConflict) {
+		t.Fatalf("stale revision must fail with ErrConflict, got %v", err)
+	}
+
+	preview, err = service.OptimizeSchedule(ctx, schedule.ID, OptimizeRequest{})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	applied, err := service.OptimizeSchedule(ctx, schedule.ID, OptimizeRequest{Accept: true, Revision: preview.Revision})
+	if err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	if !applied.Applied || applied.Revision != preview.Revision {
+		t.Fatalf("want the previewed proposal applied, got %+v", applied)
+	}
+}
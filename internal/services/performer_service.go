@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/validation"
+	"cor-events-scheduler/pkg/ical"
+
+	"go.uber.org/zap"
+)
+
+// calendarProdID — PRODID календарей, которые формирует сервис
+const calendarProdID = "-//cor-events-scheduler//RU"
+
+// PerformerService управляет выступающими и проверяет их выступления во всех расписаниях
+type PerformerService struct {
+	performerRepo domain.PerformerRepository
+	logger        *zap.Logger
+}
+
+func NewPerformerService(performerRepo domain.PerformerRepository, logger *zap.Logger) *PerformerService {
+	return &PerformerService{
+		performerRepo: performerRepo,
+		logger:        logger,
+	}
+}
+
+// Itinerary — выступления выступающего во всех расписаниях по времени начала
+type Itinerary struct {
+	Performer   *models.Performer   `json:"performer"`
+	Appearances []models.Appearance `json:"appearances"`
+}
+
+func (s *PerformerService) CreatePerformer(ctx context.Context, performer *models.Performer) error {
+	report := validation.ValidatePerformer(performer)
+	if err := report.Err(); err != nil {
+		return err
+	}
+	if err := s.performerRepo.CreatePerformer(ctx, performer); err != nil {
+		return fmt.Errorf("failed to create performer: %w", err)
+	}
+	return nil
+}
+
+func (s *PerformerService) UpdatePerformer(ctx context.Context, performer *models.Performer) error {
+	report := validation.ValidatePerformer(performer)
+	if err := report.Err(); err != nil {
+		return err
+	}
+	if err := s.performerRepo.UpdatePerformer(ctx, performer); err != nil {
+		return fmt.Errorf("failed to update performer: %w", err)
+	}
+	return nil
+}
+
+func (s *PerformerService) GetPerformer(ctx context.Context, id uint) (*models.Performer, error) {
+	performer, err := s.performerRepo.GetPerformer(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get performer: %w", err)
+	}
+	return performer, nil
+}
+
+func (s *PerformerService) ListPerformers(ctx context.Context) ([]models.Performer, error) {
+	performers, err := s.performerRepo.ListPerformers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list performers: %w", err)
+	}
+	return performers, nil
+}
+
+func (s *PerformerService) DeletePerformer(ctx context.Context, id uint) error {
+	if err := s.performerRepo.DeletePerformer(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete performer: %w", err)
+	}
+	return nil
+}
+
+// CheckSchedule проверяет выступающих в расписании с рассчитанными временами
+// элементов против их выступлений в других сохраненных расписаниях
+func (s *PerformerService) CheckSchedule(ctx context.Context, schedule *models.Schedule) (validation.Report, error) {
+	var ids []uint
+	for _, block := range schedule.Blocks {
+		for _, item := range block.Items {
+			ids = append(ids, item.PerformerIDs...)
+		}
+	}
+	if len(ids) == 0 {
+		var report validation.Report
+		return report.Finish(), nil
+	}
+	ids = models.SortedIDs(ids)
+
+	performers, err := s.performerRepo.GetPerformers(ctx, ids)
+	if err != nil {
+		return validation.Report{}, fmt.Errorf("failed to get performers: %w", err)
+	}
+	appearances, err := s.performerRepo.ListAppearances(ctx, ids)
+	if err != nil {
+		return validation.Report{}, fmt.Errorf("failed to get performer appearances: %w", err)
+	}
+
+	return validation.ValidatePerformers(schedule, performers, appearances), nil
+}
+
+// GetItinerary возвращает выступления выступающего во всех расписаниях
+func (s *PerformerService) GetItinerary(ctx context.Context, id uint) (*Itinerary, error) {
+	performer, err := s.GetPerformer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	appearances, err := s.performerRepo.ListAppearances(ctx, []uint{id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get performer appearances: %w", err)
+	}
+
+	return &Itinerary{Performer: performer, Appearances: appearances}, nil
+}
+
+// GetItineraryCalendar возвращает выступления выступающего как календарь iCalendar
+func (s *PerformerService) GetItineraryCalendar(ctx context.Context, id uint) ([]byte, error) {
+	itinerary, err := s.GetItinerary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &ical.Calendar{
+		ProdID: calendarProdID,
+		Name:   itinerary.Performer.Name,
+		Events: make([]ical.Event, len(itinerary.Appearances)),
+	}
+	for i, appearance := range itinerary.Appearances {
+		calendar.Events[i] = ical.Event{
+			UID:         fmt.Sprintf("performer-%d-item-%d@cor-events-scheduler", id, appearance.ItemID),
+			Start:       appearance.StartTime,
+			End:         appearance.EndTime,
+			Summary:     appearance.ItemName,
+			Description: appearance.BlockName,
+			Location:    appearance.ScheduleName,
+		}
+	}
+
+	return calendar.Encode(), nil
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/notify"
+	"cor-events-scheduler/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// reminderBatch — сколько наступивших напоминаний обрабатывается за проход
+	reminderBatch = 100
+	// reminderGrace — на сколько сверх интервала проверки напоминание может
+	// опоздать, например из-за перезапуска; более поздние не отправляются
+	reminderGrace = 5 * time.Minute
+)
+
+// ReminderService напоминает о скором начале блоков команде площадки и о
+// скором начале выступлений их выступающим. Напоминания хранятся в базе и
+// пересчитываются при каждом сохранении расписания; рассылает их только
+// ведущий экземпляр. Напоминание отмечается отправленным до отправки, поэтому
+// после перезапуска или смены ведущего оно не повторяется. Nil-сервис ничего
+// не делает.
+type ReminderService struct {
+	reminderRepo domain.ReminderRepository
+	scheduleRepo domain.ScheduleRepository
+	performers   *PerformerService
+	notifier     notify.Notifier
+	lock         domain.LeaderLock
+	offsets      []int
+	producer     notify.Recipient
+	interval     time.Duration
+	logger       *zap.Logger
+
+	// leading — был ли экземпляр ведущим на прошлом проходе; только для журнала
+	leading bool
+}
+
+func NewReminderService(
+	reminderRepo domain.ReminderRepository,
+	scheduleRepo domain.ScheduleRepository,
+	performers *PerformerService,
+	notifier notify.Notifier,
+	lock domain.LeaderLock,
+	offsets []int,
+	producerContact string,
+	interval time.Duration,
+	logger *zap.Logger,
+) *ReminderService {
+	return &ReminderService{
+		reminderRepo: reminderRepo,
+		scheduleRepo: scheduleRepo,
+		performers:   performers,
+		notifier:     notifier,
+		lock:         lock,
+		offsets:      offsets,
+		producer:     notify.Recipient{Contact: producerContact},
+		interval:     interval,
+		logger:       logger,
+	}
+}
+
+// ScheduleChanged пересчитывает напоминания сохраненного расписания; ошибка
+// записывается в журнал и не мешает сохранению
+func (s *ReminderService) ScheduleChanged(ctx context.Context, schedule *models.Schedule) {
+	if s == nil {
+		return
+	}
+	planned := notify.PlanReminders(schedule, s.offsets)
+	if err := s.reminderRepo.SyncReminders(ctx, schedule.ID, planned, time.Now()); err != nil {
+		s.logger.Error("Failed to reschedule reminders", zap.Uint("schedule_id", schedule.ID), zap.Error(err))
+	}
+}
+
+// ListReminders возвращает напоминания расписания по времени срабатывания
+func (s *ReminderService) ListReminders(ctx context.Context, scheduleID uint) ([]models.Reminder, error) {
+	if _, err := s.scheduleRepo.GetByID(ctx, scheduleID); err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+	reminders, err := s.reminderRepo.ListReminders(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+// Run каждые interval проверяет наступившие напоминания, пока экземпляр
+// ведущий, и отказывается от лидерства при отмене ctx
+func (s *ReminderService) Run(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	defer func() {
+		if err := s.lock.Release(context.Background()); err != nil {
+			s.logger.Error("Failed to release reminder leadership", zap.Error(err))
+		}
+	}()
+
+	for {
+		s.tick(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ReminderService) tick(ctx context.Context) {
+	leader, err := s.lock.Acquire(ctx)
+	if err != nil {
+		s.logger.Error("Failed to elect reminder leader", zap.Error(err))
+		leader = false
+	}
+	if leader != s.leading {
+		s.logger.Info("Reminder leadership changed", zap.Bool("leader", leader))
+		s.leading = leader
+	}
+	if !leader {
+		return
+	}
+
+	if err := s.Dispatch(ctx, time.Now()); err != nil {
+		s.logger.Error("Failed to dispatch reminders", zap.Error(err))
+	}
+}
+
+// Dispatch отправляет напоминания, наступившие к now. Вызывается ведущим;
+// одновременный вызов с другого экземпляра не приводит к повторам, потому что
+// каждое напоминание берется на отправку один раз.
+func (s *ReminderService) Dispatch(ctx context.Context, now time.Time) error {
+	due, err := s.reminderRepo.DueReminders(ctx, now, reminderBatch)
+	if err != nil {
+		return fmt.Errorf("failed to get due reminders: %w", err)
+	}
+
+	schedules := make(map[uint]*models.Schedule)
+	for _, reminder := range due {
+		claimed, err := s.reminderRepo.ClaimReminder(ctx, reminder.ID, now)
+		if err != nil {
+			return fmt.Errorf("failed to claim reminder: %w", err)
+		}
+		if !claimed {
+			continue
+		}
+
+		fields := []zap.Field{
+			zap.Uint("reminder_id", reminder.ID),
+			zap.Uint("schedule_id", reminder.ScheduleID),
+			zap.Time("fire_at", reminder.FireAt),
+		}
+		if now.Sub(reminder.FireAt) > s.interval+reminderGrace {
+			s.logger.Info("Skipped late reminder", fields...)
+			continue
+		}
+
+		schedule, ok := schedules[reminder.ScheduleID]
+		if !ok {
+			schedule, err = s.scheduleRepo.GetByID(ctx, reminder.ScheduleID)
+			if errors.Is(err, utils.ErrNotFound) {
+				s.logger.Info("Skipped reminder of a deleted schedule", fields...)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get schedule: %w", err)
+			}
+			schedules[reminder.ScheduleID] = schedule
+		}
+
+		reminded, ok := notify.FindReminded(schedule, &reminder)
+		if !ok {
+			s.logger.Info("Skipped stale reminder", fields...)
+			continue
+		}
+		s.send(ctx, schedule, &reminder, reminded, fields)
+	}
+	return nil
+}
+
+// send отправляет напоминание о блоке команде площадки, о выступлении — его
+// выступающим; ошибки доставки записываются в журнал
+func (s *ReminderService) send(ctx context.Context, schedule *models.Schedule, reminder *models.Reminder, reminded notify.Reminded, fields []zap.Field) {
+	recipients := []notify.Recipient{s.producer}
+	if reminded.Item != nil {
+		recipients = nil
+		for _, id := range reminded.Item.PerformerIDs {
+			performer, err := s.performers.GetPerformer(ctx, id)
+			if errors.Is(err, utils.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				s.logger.Error("Failed to get performer to remind", append(fields, zap.Uint("performer_id", id), zap.Error(err))...)
+				continue
+			}
+			recipients = append(recipients, notify.Recipient{ID: performer.ID, Name: performer.Name, Contact: performer.Contact})
+		}
+	}
+
+	for _, recipient := range recipients {
+		err := s.notifier.Notify(ctx, notify.Remind(schedule, reminder, reminded, recipient))
+		recipientFields := append(fields, zap.Uint("performer_id", recipient.ID))
+		switch {
+		case errors.Is(err, notify.ErrNoAddress):
+			s.logger.Info("Recipient has no address for reminder", append(recipientFields, zap.Error(err))...)
+		case err != nil:
+			s.logger.Error("Failed to send reminder", append(recipientFields, zap.Error(err))...)
+		default:
+			s.logger.Info("Sent reminder", recipientFields...)
+		}
+	}
+}
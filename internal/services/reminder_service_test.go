@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/risk"
+	"cor-events-scheduler/internal/infrastructure/db"
+	"cor-events-scheduler/internal/infrastructure/memory"
+	"cor-events-scheduler/internal/infrastructure/notifier"
+
+	"go.uber.org/zap"
+)
+
+func TestRemindersFollowScheduleAndFireOnce(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	scheduleRepo := memory.NewScheduleRepository(store)
+	versionRepo := memory.NewVersionRepository(store)
+	reminderRepo := memory.NewReminderRepository(store)
+	ruleService := NewRuleService(memory.NewRuleSetRepository(store), scheduleRepo, zap.NewNop())
+	riskService := NewRiskService(scheduleRepo, risk.NewAnalyzer(risk.Options{}), nil, zap.NewNop())
+	performerService := NewPerformerService(memory.NewPerformerRepository(store), zap.NewNop())
+	resourceService := NewResourceService(memory.NewResourceRepository(store), zap.NewNop())
+
+	recorder := notifier.NewRecorder()
+	newReminderService := func() *ReminderService {
+		return NewReminderService(reminderRepo, scheduleRepo, performerService, recorder, db.NewLocalLock(),
+			[]int{30, 10}, "crew@example.com", 30*time.Second, zap.NewNop())
+	}
+	reminders := newReminderService()
+	schedulerService := NewSchedulerService(scheduleRepo, versionRepo, ruleService, riskService, performerService, resourceService, nil, nil, reminders, zap.NewNop())
+	versionService := NewVersionService(versionRepo, scheduleRepo, ruleService, performerService, resourceService, schedulerService, zap.NewNop())
+
+	anna := &models.Performer{Name: "Анна", Contact: "anna@example.com"}
+	if err := performerService.CreatePerformer(ctx, anna); err != nil {
+		t.Fatalf("create performer: %v", err)
+	}
+	schedule := domaintest.NewSchedule("Фестиваль")
+	schedule.Blocks[1].Items[0].PerformerIDs = []uint{anna.ID}
+	if _, err := schedulerService.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// По два напоминания о двух блоках и выступлении Анны
+	planned, err := reminders.ListReminders(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("list reminders: %v", err)
+	}
+	if len(planned) != 6 {
+		t.Fatalf("want 6 reminders, got %+v", planned)
+	}
+
+	start := schedule.StartDate
+	if err := reminders.Dispatch(ctx, start.Add(-30*time.Minute)); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	sent := recorder.Sent()
+	if len(sent) != 1 || sent[0].Recipient.Contact != "crew@example.com" || sent[0].Upcoming.Name != "Открытие" || sent[0].Upcoming.Minutes != 30 {
+		t.Fatalf("want the 30 min reminder of the first block for the crew, got %+v", sent)
+	}
+
+	// Перезапуск: новый экземпляр не повторяет отправленное
+	reminders = newReminderService()
+	if err := reminders.Dispatch(ctx, start.Add(-30*time.Minute)); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if len(recorder.Sent()) != 1 {
+		t.Fatalf("reminder fired twice: %+v", recorder.Sent())
+	}
+
+	// Удлинение первого блока сдвигает второй блок и выступление на 10 минут
+	current, err := schedulerService.GetSchedule(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	current.Blocks[0].Duration += 10
+	if _, err := schedulerService.UpdateSchedule(ctx, current); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	moved, err := schedulerService.GetSchedule(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	contestStart := moved.Blocks[1].StartTime
+	if !contestStart.Equal(schedule.Blocks[1].StartTime.Add(10 * time.Minute)) {
+		t.Fatalf("second block must move 10 min later, got %v", contestStart)
+	}
+
+	// Напоминание первого блока за 10 минут опоздало и не отправляется,
+	// напоминания второго блока и выступления идут по новому времени
+	if err := reminders.Dispatch(ctx, contestStart.Add(-30*time.Minute)); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	sent = recorder.Sent()[1:]
+	if len(sent) != 2 {
+		t.Fatalf("want reminders for the moved block and item, got %+v", sent)
+	}
+	for _, n := range sent {
+		if !n.Upcoming.StartsAt.Equal(contestStart) || n.Upcoming.Minutes != 30 {
+			t.Errorf("reminder must follow the new start %v, got %+v", contestStart, n.Upcoming)
+		}
+	}
+	if sent[0].Recipient.Contact != "crew@example.com" || sent[1].Recipient.ID != anna.ID || sent[1].Upcoming.Block != "Косплей" {
+		t.Errorf("unexpected recipients: %+v", sent)
+	}
+
+	// Восстановление исходной версии возвращает напоминания к прежнему времени
+	if _, err := versionService.RestoreVersion(ctx, schedule.ID, 1, "test"); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	restored, err := reminders.ListReminders(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("list reminders: %v", err)
+	}
+	for _, reminder := range restored {
+		if reminder.BlockID == moved.Blocks[1].ID && !reminder.StartsAt.Equal(schedule.Blocks[1].StartTime) {
+			t.Errorf("restored reminder must follow the original start %v, got %+v", schedule.Blocks[1].StartTime, reminder)
+		}
+	}
+}
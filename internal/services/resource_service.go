@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/booking"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/validation"
+	"cor-events-scheduler/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// ResourceService управляет ресурсами и проверяет их брони во всех расписаниях
+type ResourceService struct {
+	resourceRepo domain.ResourceRepository
+	logger       *zap.Logger
+}
+
+func NewResourceService(resourceRepo domain.ResourceRepository, logger *zap.Logger) *ResourceService {
+	return &ResourceService{
+		resourceRepo: resourceRepo,
+		logger:       logger,
+	}
+}
+
+// FreeBusyQuery — интервал, за который запрашивается загрузка ресурса
+type FreeBusyQuery struct {
+	From time.Time `form:"from" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   time.Time `form:"to" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// BusyPeriod — период, в котором занята хотя бы одна единица ресурса
+type BusyPeriod struct {
+	booking.Period
+	// Available — сколько единиц ресурса осталось свободно; отрицательное значение означает перебронирование
+	Available int `json:"available"`
+}
+
+// FreeBusy — загрузка ресурса в интервале [from, to)
+type FreeBusy struct {
+	Resource *models.Resource   `json:"resource"`
+	From     time.Time          `json:"from"`
+	To       time.Time          `json:"to"`
+	Busy     []BusyPeriod       `json:"busy"`
+	Free     []booking.Interval `json:"free"`
+}
+
+func (s *ResourceService) CreateResource(ctx context.Context, resource *models.Resource) error {
+	report := validation.ValidateResource(resource)
+	if err := report.Err(); err != nil {
+		return err
+	}
+	if err := s.resourceRepo.CreateResource(ctx, resource); err != nil {
+		return fmt.Errorf("failed to create resource: %w", err)
+	}
+	return nil
+}
+
+func (s *ResourceService) UpdateResource(ctx context.Context, resource *models.Resource) error {
+	report := validation.ValidateResource(resource)
+	if err := report.Err(); err != nil {
+		return err
+	}
+	if err := s.resourceRepo.UpdateResource(ctx, resource); err != nil {
+		return fmt.Errorf("failed to update resource: %w", err)
+	}
+	return nil
+}
+
+func (s *ResourceService) GetResource(ctx context.Context, id uint) (*models.Resource, error) {
+	resource, err := s.resourceRepo.GetResource(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource: %w", err)
+	}
+	return resource, nil
+}
+
+func (s *ResourceService) ListResources(ctx context.Context) ([]models.Resource, error) {
+	resources, err := s.resourceRepo.ListResources(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+	return resources, nil
+}
+
+func (s *ResourceService) DeleteResource(ctx context.Context, id uint) error {
+	if err := s.resourceRepo.DeleteResource(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete resource: %w", err)
+	}
+	return nil
+}
+
+// CheckSchedule проверяет брони ресурсов расписания с рассчитанными временами
+// блоков и элементов против броней в других сохраненных расписаниях
+func (s *ResourceService) CheckSchedule(ctx context.Context, schedule *models.Schedule) (validation.Report, error) {
+	// Чужие брони нужны только в пределах собственных броней расписания
+	var ids []uint
+	var from, to time.Time
+	for i := range schedule.Blocks {
+		for _, usage := range schedule.Blocks[i].ResourceUsages(schedule.ID, schedule.Name) {
+			ids = append(ids, usage.ResourceID)
+			if from.IsZero() || usage.StartTime.Before(from) {
+				from = usage.StartTime
+			}
+			if usage.EndTime.After(to) {
+				to = usage.EndTime
+			}
+		}
+	}
+	if len(ids) == 0 {
+		var report validation.Report
+		return report.Finish(), nil
+	}
+	ids = models.SortedIDs(ids)
+
+	resources, err := s.resourceRepo.GetResources(ctx, ids)
+	if err != nil {
+		return validation.Report{}, fmt.Errorf("failed to get resources: %w", err)
+	}
+	usages, err := s.resourceRepo.ListUsages(ctx, ids, from, to)
+	if err != nil {
+		return validation.Report{}, fmt.Errorf("failed to get resource usages: %w", err)
+	}
+
+	return validation.ValidateResources(schedule, resources, usages), nil
+}
+
+// GetFreeBusy возвращает периоды занятости и свободные периоды ресурса в
+// интервале [from, to) по броням всех расписаний
+func (s *ResourceService) GetFreeBusy(ctx context.Context, id uint, query FreeBusyQuery) (*FreeBusy, error) {
+	if !query.To.After(query.From) {
+		return nil, fmt.Errorf("%w: to must be after from", utils.ErrInvalidInput)
+	}
+
+	resource, err := s.GetResource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	usages, err := s.resourceRepo.ListUsages(ctx, []uint{id}, query.From, query.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource usages: %w", err)
+	}
+
+	periods := booking.Timeline(usages, query.From, query.To)
+	busy := make([]BusyPeriod, len(periods))
+	for i, period := range periods {
+		busy[i] = BusyPeriod{Period: period, Available: resource.Capacity - period.Used}
+	}
+
+	return &FreeBusy{
+		Resource: resource,
+		From:     query.From,
+		To:       query.To,
+		Busy:     busy,
+		Free:     booking.Free(periods, query.From, query.To),
+	}, nil
+}
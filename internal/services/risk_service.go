@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/risk"
+
+	"go.uber.org/zap"
+)
+
+// RiskService оценивает риск срыва расписаний
+type RiskService struct {
+	scheduleRepo domain.ScheduleRepository
+	analyzer     *risk.Analyzer
+	metrics      *SchedulerMetrics
+	logger       *zap.Logger
+}
+
+func NewRiskService(
+	scheduleRepo domain.ScheduleRepository,
+	analyzer *risk.Analyzer,
+	metrics *SchedulerMetrics,
+	logger *zap.Logger,
+) *RiskService {
+	return &RiskService{
+		scheduleRepo: scheduleRepo,
+		analyzer:     analyzer,
+		metrics:      metrics,
+		logger:       logger,
+	}
+}
+
+// AnalyzeSchedule оценивает сохраненное расписание
+func (s *RiskService) AnalyzeSchedule(ctx context.Context, id uint) (*risk.Assessment, error) {
+	schedule, err := s.scheduleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	return s.analyzer.Analyze(schedule), nil
+}
+
+// Record оценивает только что сохраненное расписание и записывает оценку
+// в метрики. Запросы оценки метрики не меняют, поэтому распределение
+// отражает расписания, а не частоту обращений к ним.
+func (s *RiskService) Record(schedule *models.Schedule) {
+	assessment := s.analyzer.Analyze(schedule)
+	s.metrics.observeSchedule(schedule, assessment)
+
+	s.logger.Debug("Recorded schedule risk",
+		zap.Uint("schedule_id", schedule.ID),
+		zap.Float64("score", assessment.Score),
+	)
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/rules"
+	"cor-events-scheduler/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// RuleService управляет наборами правил площадки. Расписание проверяется по
+// собственному набору, а при его отсутствии — по набору организации.
+type RuleService struct {
+	ruleRepo     domain.RuleSetRepository
+	scheduleRepo domain.ScheduleRepository
+	logger       *zap.Logger
+}
+
+func NewRuleService(
+	ruleRepo domain.RuleSetRepository,
+	scheduleRepo domain.ScheduleRepository,
+	logger *zap.Logger,
+) *RuleService {
+	return &RuleService{
+		ruleRepo:     ruleRepo,
+		scheduleRepo: scheduleRepo,
+		logger:       logger,
+	}
+}
+
+// RuleSetDocument — сохраненный набор правил вместе с исходным текстом
+type RuleSetDocument struct {
+	ScheduleID uint         `json:"schedule_id"`
+	Rules      []rules.Rule `json:"rules"`
+	Source     string       `json:"source"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// GetRuleSet возвращает набор правил расписания или организации (models.OrganizationRuleSetID).
+// Сохраненный набор, который не разбирается, — ошибка данных сервера (utils.ErrDatabaseOperation).
+func (s *RuleService) GetRuleSet(ctx context.Context, scheduleID uint) (*RuleSetDocument, error) {
+	stored, err := s.ruleRepo.GetRuleSet(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule set: %w", err)
+	}
+
+	ruleSet, err := rules.Parse([]byte(stored.Source))
+	if err != nil {
+		return nil, fmt.Errorf("%w: stored rule set %d is invalid: %v", utils.ErrDatabaseOperation, scheduleID, err)
+	}
+
+	return newRuleSetDocument(stored, ruleSet), nil
+}
+
+// SaveRuleSet проверяет и сохраняет набор правил в YAML или JSON
+func (s *RuleService) SaveRuleSet(ctx context.Context, scheduleID uint, source []byte) (*RuleSetDocument, error) {
+	if scheduleID != models.OrganizationRuleSetID {
+		if _, err := s.scheduleRepo.GetByID(ctx, scheduleID); err != nil {
+			return nil, fmt.Errorf("failed to get schedule: %w", err)
+		}
+	}
+
+	ruleSet, err := rules.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &models.RuleSet{ScheduleID: scheduleID, Source: string(source)}
+	if err := s.ruleRepo.SaveRuleSet(ctx, stored); err != nil {
+		return nil, fmt.Errorf("failed to save rule set: %w", err)
+	}
+
+	s.logger.Info("Saved rule set",
+		zap.Uint("schedule_id", scheduleID),
+		zap.Int("rules", len(ruleSet.Rules)),
+	)
+
+	return newRuleSetDocument(stored, ruleSet), nil
+}
+
+// DeleteRuleSet удаляет набор правил
+func (s *RuleService) DeleteRuleSet(ctx context.Context, scheduleID uint) error {
+	if err := s.ruleRepo.DeleteRuleSet(ctx, scheduleID); err != nil {
+		return fmt.Errorf("failed to delete rule set: %w", err)
+	}
+	return nil
+}
+
+// EffectiveRules возвращает правила, по которым проверяется расписание.
+// Для нового расписания (scheduleID == 0) действует набор организации.
+func (s *RuleService) EffectiveRules(ctx context.Context, scheduleID uint) (*rules.RuleSet, error) {
+	ids := []uint{models.OrganizationRuleSetID}
+	if scheduleID != models.OrganizationRuleSetID {
+		ids = []uint{scheduleID, models.OrganizationRuleSetID}
+	}
+
+	for _, id := range ids {
+		stored, err := s.ruleRepo.GetRuleSet(ctx, id)
+		if errors.Is(err, utils.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rule set: %w", err)
+		}
+
+		ruleSet, err := rules.Parse([]byte(stored.Source))
+		if err != nil {
+			return nil, fmt.Errorf("%w: stored rule set %d is invalid: %v", utils.ErrDatabaseOperation, id, err)
+		}
+		return ruleSet, nil
+	}
+
+	return &rules.RuleSet{}, nil
+}
+
+func newRuleSetDocument(stored *models.RuleSet, ruleSet *rules.RuleSet) *RuleSetDocument {
+	document := &RuleSetDocument{
+		ScheduleID: stored.ScheduleID,
+		Rules:      ruleSet.Rules,
+		Source:     stored.Source,
+		UpdatedAt:  stored.UpdatedAt,
+	}
+	if document.Rules == nil {
+		document.Rules = []rules.Rule{}
+	}
+	return document
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/risk"
+	"cor-events-scheduler/internal/infrastructure/memory"
+	"cor-events-scheduler/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+func TestBrokenStoredRuleSetIsServerError(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	scheduleRepo := memory.NewScheduleRepository(store)
+	ruleRepo := memory.NewRuleSetRepository(store)
+	ruleService := NewRuleService(ruleRepo, scheduleRepo, zap.NewNop())
+	riskService := NewRiskService(scheduleRepo, risk.NewAnalyzer(risk.Options{}), nil, zap.NewNop())
+	performerService := NewPerformerService(memory.NewPerformerRepository(store), zap.NewNop())
+	resourceService := NewResourceService(memory.NewResourceRepository(store), zap.NewNop())
+	schedulerService := NewSchedulerService(scheduleRepo, memory.NewVersionRepository(store), ruleService, riskService, performerService, resourceService, nil, nil, nil, zap.NewNop())
+
+	// Набор, записанный в хранилище в обход проверки
+	if err := ruleRepo.SaveRuleSet(ctx, &models.RuleSet{ScheduleID: models.OrganizationRuleSetID, Source: "rules: [{type: unknown}]"}); err != nil {
+		t.Fatalf("seed rule set: %v", err)
+	}
+
+	// Клиент с корректным расписанием не должен получать 400 из-за данных сервера
+	_, err := schedulerService.CreateSchedule(ctx, domaintest.NewSchedule("Фестиваль"))
+	if !errors.Is(err, utils.ErrDatabaseOperation) || errors.Is(err, utils.ErrInvalidInput) {
+		t.Fatalf("create with a broken stored rule set must fail with ErrDatabaseOperation, got %v", err)
+	}
+
+	_, err = ruleService.GetRuleSet(ctx, models.OrganizationRuleSetID)
+	if !errors.Is(err, utils.ErrDatabaseOperation) || errors.Is(err, utils.ErrInvalidInput) {
+		t.Fatalf("get of a broken stored rule set must fail with ErrDatabaseOperation, got %v", err)
+	}
+}
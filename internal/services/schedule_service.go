@@ -2,173 +2,223 @@ package services
 
 import (
 	"context"
+	"cor-events-scheduler/internal/domain"
 	"cor-events-scheduler/internal/domain/models"
-	"cor-events-scheduler/internal/domain/repositories"
-	"encoding/json"
+	"cor-events-scheduler/internal/domain/rules"
+	"cor-events-scheduler/internal/domain/validation"
+	"cor-events-scheduler/pkg/utils"
+	"errors"
 	"fmt"
-	"time"
 
 	"go.uber.org/zap"
 )
 
 type SchedulerService struct {
-	scheduleRepo *repositories.ScheduleRepository
-	versionRepo  *repositories.VersionRepository
-	logger       *zap.Logger
+	scheduleRepo  domain.ScheduleRepository
+	versionRepo   domain.VersionRepository
+	ruleService   *RuleService
+	riskService   *RiskService
+	performers    *PerformerService
+	resources     *ResourceService
+	metrics       *SchedulerMetrics
+	notifications *NotificationService
+	reminders     *ReminderService
+	logger        *zap.Logger
 }
 
 func NewSchedulerService(
-	scheduleRepo *repositories.ScheduleRepository,
-	versionRepo *repositories.VersionRepository,
+	scheduleRepo domain.ScheduleRepository,
+	versionRepo domain.VersionRepository,
+	ruleService *RuleService,
+	riskService *RiskService,
+	performers *PerformerService,
+	resources *ResourceService,
+	metrics *SchedulerMetrics,
+	notifications *NotificationService,
+	reminders *ReminderService,
 	logger *zap.Logger,
 ) *SchedulerService {
 	return &SchedulerService{
-		scheduleRepo: scheduleRepo,
-		versionRepo:  versionRepo,
-		logger:       logger,
+		scheduleRepo:  scheduleRepo,
+		versionRepo:   versionRepo,
+		ruleService:   ruleService,
+		riskService:   riskService,
+		performers:    performers,
+		resources:     resources,
+		metrics:       metrics,
+		notifications: notifications,
+		reminders:     reminders,
+		logger:        logger,
 	}
 }
 
-func (s *SchedulerService) CreateSchedule(ctx context.Context, schedule *models.Schedule) error {
-	// Валидация входных данных
-	if err := s.validateScheduleInput(schedule); err != nil {
-		return fmt.Errorf("invalid schedule data: %w", err)
+// CreateSchedule сохраняет расписание и возвращает отчет проверки с предупреждениями
+func (s *SchedulerService) CreateSchedule(ctx context.Context, schedule *models.Schedule) (validation.Report, error) {
+	ruleSet, err := s.ruleService.EffectiveRules(ctx, models.OrganizationRuleSetID)
+	if err != nil {
+		return validation.Report{}, err
 	}
 
-	// Обработка времен блоков
-	if err := s.processBlockTimes(schedule); err != nil {
-		return fmt.Errorf("failed to process block times: %w", err)
+	report, err := prepareSchedule(schedule, ruleSet)
+	if err != nil {
+		return report, err
 	}
-
-	// Валидация временных интервалов
-	if err := s.validateScheduleTimes(schedule); err != nil {
-		return fmt.Errorf("invalid schedule times: %w", err)
+	if err := checkSharedUsage(ctx, s.performers, s.resources, schedule, &report); err != nil {
+		return report, err
 	}
 
 	// Создаем расписание
 	if err := s.scheduleRepo.Create(ctx, schedule); err != nil {
-		return fmt.Errorf("failed to create schedule: %w", err)
+		return report, fmt.Errorf("failed to create schedule: %w", err)
 	}
+	s.metrics.scheduleCreated()
+	s.riskService.Record(schedule)
+	s.reminders.ScheduleChanged(ctx, schedule)
 
 	// Создаем начальную версию
 	if err := s.createInitialVersion(ctx, schedule); err != nil {
 		s.logger.Error("Failed to create initial version", zap.Error(err))
 	}
 
-	return nil
+	return report, nil
+}
+
+// prepareSchedule проверяет расписание, рассчитывает времена блоков и проверяет
+// результат по правилам площадки. Используется при создании, обновлении и
+// восстановлении версии расписания. Ошибка возвращается, если в отчете есть
+// хотя бы одна блокирующая проблема.
+func prepareSchedule(schedule *models.Schedule, ruleSet *rules.RuleSet) (validation.Report, error) {
+	report := validation.ValidateSchedule(schedule)
+	if err := report.Err(); err != nil {
+		return report, err
+	}
+
+	processBlockTimes(schedule)
+	if schedule.Constraints == nil {
+		schedule.Constraints = []models.BlockConstraint{}
+	}
+
+	report.Merge(ruleSet.Evaluate(schedule))
+	return report, report.Err()
 }
 
-// processBlockTimes обрабатывает времена блоков
-func (s *SchedulerService) processBlockTimes(schedule *models.Schedule) error {
+// checkSharedUsage дополняет отчет проверкой выступающих и ресурсов, общих с
+// другими расписаниями. Времена блоков и элементов расписания уже должны быть
+// рассчитаны. Возвращает ошибку хранилища или ошибку проверки, если в отчете
+// есть блокирующие проблемы.
+func checkSharedUsage(ctx context.Context, performers *PerformerService, resources *ResourceService, schedule *models.Schedule, report *validation.Report) error {
+	performerReport, err := performers.CheckSchedule(ctx, schedule)
+	if err != nil {
+		return err
+	}
+	resourceReport, err := resources.CheckSchedule(ctx, schedule)
+	if err != nil {
+		return err
+	}
+	report.Merge(performerReport)
+	report.Merge(resourceReport)
+	return report.Err()
+}
+
+// processBlockTimes располагает блоки подряд от начала расписания и элементы
+// внутри блоков; блок без длительности получает длительность своих элементов
+// вместе с перестановками между ними
+func processBlockTimes(schedule *models.Schedule) {
 	currentTime := schedule.StartDate
 
 	for i := range schedule.Blocks {
 		block := &schedule.Blocks[i]
+		block.StartTime = currentTime
 
-		// При обновлении всегда устанавливаем время начала блока
-		if i == 0 {
-			// Первый блок начинается в начале расписания
-			block.StartTime = currentTime
-		} else {
-			// Последующие блоки начинаются после окончания предыдущего
-			prevBlock := schedule.Blocks[i-1]
-			block.StartTime = prevBlock.StartTime.Add(
-				time.Duration(prevBlock.Duration+prevBlock.TechBreakDuration) * time.Minute,
-			)
-		}
-
-		// Проверяем длительность блока
 		if block.Duration <= 0 {
-			// Если длительность не указана, вычисляем на основе элементов
-			totalDuration := 0
-			for _, item := range block.Items {
-				totalDuration += item.Duration
-			}
-			if totalDuration <= 0 {
-				return fmt.Errorf("block %d (%s) must have positive duration", i+1, block.Name)
-			}
-			block.Duration = totalDuration
-		} else {
-			// Проверяем, что указанная длительность не меньше суммы элементов
-			totalItemsDuration := 0
-			for _, item := range block.Items {
-				totalItemsDuration += item.Duration
-			}
-			if block.Duration < totalItemsDuration {
-				return fmt.Errorf("block %d (%s) duration cannot be less than sum of items duration", i+1, block.Name)
-			}
+			block.Duration = block.ContentDuration()
 		}
+		block.LayoutItems()
 
-		// Обновляем время для следующего блока
-		currentTime = block.StartTime.Add(time.Duration(block.Duration+block.TechBreakDuration) * time.Minute)
+		currentTime = block.EndTime()
 	}
-
-	return nil
 }
 
-// validateScheduleTimes проверяет корректность временных интервалов
-func (s *SchedulerService) validateScheduleTimes(schedule *models.Schedule) error {
-	if schedule.StartDate.IsZero() || schedule.EndDate.IsZero() {
-		return fmt.Errorf("schedule must have start and end dates")
+// ValidateSchedule проверяет расписание без сохранения, включая правила площадки.
+// Для существующего расписания (schedule.ID != 0) действуют его собственные правила.
+func (s *SchedulerService) ValidateSchedule(ctx context.Context, schedule *models.Schedule) (validation.Report, error) {
+	ruleSet, err := s.ruleService.EffectiveRules(ctx, schedule.ID)
+	if err != nil {
+		return validation.Report{}, err
 	}
 
-	if schedule.StartDate.After(schedule.EndDate) {
-		return fmt.Errorf("schedule start date must be before end date")
+	report, err := prepareSchedule(schedule, ruleSet)
+	if err != nil {
+		return report, nil
 	}
-
-	lastEndTime := schedule.StartDate
-	for i, block := range schedule.Blocks {
-		blockEndTime := block.StartTime.Add(time.Duration(block.Duration+block.TechBreakDuration) * time.Minute)
-
-		if blockEndTime.After(schedule.EndDate) {
-			return fmt.Errorf("block %d (%s) ends after schedule end time", i+1, block.Name)
-		}
-
-		// Проверяем наложение блоков
-		if block.StartTime.Before(lastEndTime) {
-			return fmt.Errorf("block %d (%s) overlaps with previous block", i+1, block.Name)
-		}
-
-		lastEndTime = blockEndTime
+	if err := checkSharedUsage(ctx, s.performers, s.resources, schedule, &report); err != nil && !errors.Is(err, utils.ErrValidation) {
+		return report, err
 	}
-
-	return nil
+	return report, nil
 }
 
-func (s *SchedulerService) UpdateSchedule(ctx context.Context, schedule *models.Schedule) error {
+// UpdateSchedule обновляет расписание и возвращает отчет проверки с предупреждениями.
+// Если ограничения не переданы (nil), остаются сохраненные; пустой список их удаляет.
+func (s *SchedulerService) UpdateSchedule(ctx context.Context, schedule *models.Schedule) (validation.Report, error) {
 	// Получаем текущее расписание
 	currentSchedule, err := s.scheduleRepo.GetByID(ctx, schedule.ID)
 	if err != nil {
-		return fmt.Errorf("failed to get current schedule: %w", err)
+		return validation.Report{}, fmt.Errorf("failed to get current schedule: %w", err)
+	}
+	if schedule.Constraints == nil {
+		schedule.Constraints = append([]models.BlockConstraint{}, currentSchedule.Constraints...)
 	}
 
-	// Валидация входных данных
-	if err := s.validateScheduleInput(schedule); err != nil {
-		return fmt.Errorf("invalid schedule data: %w", err)
+	ruleSet, err := s.ruleService.EffectiveRules(ctx, schedule.ID)
+	if err != nil {
+		return validation.Report{}, err
 	}
 
-	// Обработка времен блоков
-	if err := s.processBlockTimes(schedule); err != nil {
-		return fmt.Errorf("failed to process block times: %w", err)
+	report, err := prepareSchedule(schedule, ruleSet)
+	if err != nil {
+		return report, err
+	}
+	if err := checkSharedUsage(ctx, s.performers, s.resources, schedule, &report); err != nil {
+		return report, err
 	}
 
-	// Валидация временных интервалов
-	if err := s.validateScheduleTimes(schedule); err != nil {
-		return fmt.Errorf("invalid schedule times: %w", err)
+	// Расписание и версия с его новым состоянием сохраняются вместе, как при
+	// восстановлении версии, поэтому изменения версии описывают это обновление
+	err = s.scheduleRepo.UpdateWithVersion(ctx, schedule, func(saved *models.Schedule, latest *models.ScheduleVersion) (*models.ScheduleVersion, error) {
+		return newVersion(saved, latest, "", nil)
+	})
+	if err != nil {
+		return report, fmt.Errorf("failed to update schedule: %w", err)
 	}
+	s.scheduleUpdated(ctx, currentSchedule, schedule)
+
+	return report, nil
+}
 
-	// Создаем новую версию перед обновлением
-	if err := s.createVersion(ctx, currentSchedule); err != nil {
-		s.logger.Error("Failed to create version before update", zap.Error(err))
-		// Продолжаем обновление даже при ошибке версионирования
+// previewOrApply проверяет предложенное расписание (подгонка, оптимизация) без
+// сохранения или, если accept, сохраняет его как обычное обновление. Возвращает
+// отчет проверки и признак того, что расписание сохранено.
+func (s *SchedulerService) previewOrApply(ctx context.Context, proposed *models.Schedule, accept bool) (validation.Report, bool, error) {
+	if !accept {
+		report, err := s.ValidateSchedule(ctx, proposed)
+		return report, false, err
 	}
 
-	// Обновляем расписание
-	if err := s.scheduleRepo.Update(ctx, schedule); err != nil {
-		return fmt.Errorf("failed to update schedule: %w", err)
+	report, err := s.UpdateSchedule(ctx, proposed)
+	if err != nil {
+		return report, false, err
 	}
+	return report, true, nil
+}
 
-	return nil
+// scheduleUpdated обновляет метрики, риски и напоминания сохраненного
+// расписания и сообщает выступающим о переносах относительно before; общее
+// для обновления и восстановления версии
+func (s *SchedulerService) scheduleUpdated(ctx context.Context, before, schedule *models.Schedule) {
+	s.metrics.scheduleUpdated()
+	s.riskService.Record(schedule)
+	s.notifications.ScheduleChanged(before, schedule)
+	s.reminders.ScheduleChanged(ctx, schedule)
 }
 
 func (s *SchedulerService) GetSchedule(ctx context.Context, id uint) (*models.Schedule, error) {
@@ -179,108 +229,77 @@ func (s *SchedulerService) GetSchedule(ctx context.Context, id uint) (*models.Sc
 	return schedule, nil
 }
 
-func (s *SchedulerService) DeleteSchedule(ctx context.Context, id uint) error {
-	// Получаем расписание перед удалением
-	schedule, err := s.scheduleRepo.GetByID(ctx, id)
+// GetScheduleBySlug возвращает расписание по адресу публичной страницы
+func (s *SchedulerService) GetScheduleBySlug(ctx context.Context, slug string) (*models.Schedule, error) {
+	schedule, err := s.scheduleRepo.GetBySlug(ctx, slug)
 	if err != nil {
-		return fmt.Errorf("failed to get schedule: %w", err)
-	}
-
-	// Создаем финальную версию перед удалением
-	if err := s.createVersion(ctx, schedule); err != nil {
-		s.logger.Error("Failed to create final version before deletion", zap.Error(err))
-		// Продолжаем удаление даже при ошибке версионирования
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
 	}
+	return schedule, nil
+}
 
-	// Удаляем расписание
+// DeleteSchedule удаляет расписание. Отдельная версия не записывается: версии
+// сохраняются после каждого изменения, и последняя уже хранит это состояние.
+func (s *SchedulerService) DeleteSchedule(ctx context.Context, id uint) error {
 	if err := s.scheduleRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete schedule: %w", err)
 	}
+	s.metrics.scheduleDeleted()
 
 	return nil
 }
 
-func (s *SchedulerService) ListSchedules(ctx context.Context, page, pageSize int) ([]models.Schedule, int64, error) {
-	offset := (page - 1) * pageSize
-	return s.scheduleRepo.List(ctx, offset, pageSize)
-}
-
-// Вспомогательные методы
-
-// validateScheduleInput проверяет корректность входных данных
-func (s *SchedulerService) validateScheduleInput(schedule *models.Schedule) error {
-	if schedule.Name == "" {
-		return fmt.Errorf("schedule must have a name")
+// ListScheduleSummaries возвращает страницу облегченных расписаний и курсор следующей страницы.
+// Пустой курсор в ответе означает, что страниц больше нет.
+func (s *SchedulerService) ListScheduleSummaries(ctx context.Context, cursor string, limit int) ([]models.ScheduleSummary, string, error) {
+	afterID, err := utils.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
 	}
 
-	if len(schedule.Blocks) == 0 {
-		return fmt.Errorf("schedule must have at least one block")
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	summaries, err := s.scheduleRepo.ListSummaries(ctx, afterID, limit+1)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list schedules: %w", err)
 	}
 
-	for i, block := range schedule.Blocks {
-		if block.Name == "" {
-			return fmt.Errorf("block %d must have a name", i+1)
-		}
-
-		// Проверяем элементы блока
-		for j, item := range block.Items {
-			if item.Name == "" {
-				return fmt.Errorf("item %d in block %d must have a name", j+1, i+1)
-			}
-			if item.Duration <= 0 {
-				return fmt.Errorf("item %d in block %d must have positive duration", j+1, i+1)
-			}
-		}
+	nextCursor := ""
+	if len(summaries) > limit {
+		summaries = summaries[:limit]
+		nextCursor = utils.EncodeCursor(summaries[limit-1].ID)
 	}
 
-	return nil
+	return summaries, nextCursor, nil
 }
 
-func (s *SchedulerService) arrangeBlockTimes(schedule *models.Schedule) {
-	currentTime := schedule.StartDate
-
-	for i := range schedule.Blocks {
-		block := &schedule.Blocks[i]
-		block.StartTime = currentTime
-		currentTime = currentTime.Add(time.Duration(block.Duration) * time.Minute)
+// ListSchedules возвращает страницу расписаний с блоками и элементами и курсор следующей страницы
+func (s *SchedulerService) ListSchedules(ctx context.Context, cursor string, limit int) ([]models.Schedule, string, error) {
+	afterID, err := utils.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
 	}
-}
 
-func (s *SchedulerService) createInitialVersion(ctx context.Context, schedule *models.Schedule) error {
-	data, err := json.Marshal(schedule)
+	schedules, err := s.scheduleRepo.List(ctx, afterID, limit+1)
 	if err != nil {
-		return fmt.Errorf("failed to marshal schedule: %w", err)
+		return nil, "", fmt.Errorf("failed to list schedules: %w", err)
 	}
 
-	version := &models.ScheduleVersion{ // Было models.Version
-		ScheduleID: schedule.ID,
-		Version:    1,
-		Data:       data,
-		CreatedAt:  time.Now(),
+	nextCursor := ""
+	if len(schedules) > limit {
+		schedules = schedules[:limit]
+		nextCursor = utils.EncodeCursor(schedules[limit-1].ID)
 	}
 
-	return s.versionRepo.CreateVersion(ctx, version)
+	return schedules, nextCursor, nil
 }
 
-func (s *SchedulerService) createVersion(ctx context.Context, schedule *models.Schedule) error {
-	// Получаем последнюю версию
-	lastVersion, err := s.versionRepo.GetLatestVersion(ctx, schedule.ID)
-	if err != nil {
-		// Если это первая версия
-		return s.createInitialVersion(ctx, schedule)
-	}
+// Вспомогательные методы
 
-	data, err := json.Marshal(schedule)
+// createInitialVersion записывает первую версию созданного расписания
+func (s *SchedulerService) createInitialVersion(ctx context.Context, schedule *models.Schedule) error {
+	version, err := newVersion(schedule, nil, "", nil)
 	if err != nil {
-		return fmt.Errorf("failed to marshal schedule: %w", err)
-	}
-
-	version := &models.ScheduleVersion{
-		ScheduleID: schedule.ID,
-		Version:    lastVersion.Version + 1,
-		Data:       data,
-		CreatedAt:  time.Now(),
+		return err
 	}
-
 	return s.versionRepo.CreateVersion(ctx, version)
 }
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/risk"
+	"cor-events-scheduler/internal/infrastructure/memory"
+
+	"go.uber.org/zap"
+)
+
+func TestUpdateScheduleKeepsOmittedConstraints(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	scheduleRepo := memory.NewScheduleRepository(store)
+	ruleService := NewRuleService(memory.NewRuleSetRepository(store), scheduleRepo, zap.NewNop())
+	riskService := NewRiskService(scheduleRepo, risk.NewAnalyzer(risk.Options{}), nil, zap.NewNop())
+	performerService := NewPerformerService(memory.NewPerformerRepository(store), zap.NewNop())
+	resourceService := NewResourceService(memory.NewResourceRepository(store), zap.NewNop())
+	service := NewSchedulerService(scheduleRepo, memory.NewVersionRepository(store), ruleService, riskService, performerService, resourceService, nil, nil, nil, zap.NewNop())
+
+	schedule := domaintest.NewSchedule("Фестиваль")
+	if _, err := service.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// PUT без поля constraints, заодно с переименованием блока-цели
+	update, err := service.GetSchedule(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	update.Blocks[0].Name = "Церемония открытия"
+	update.Constraints = nil
+	if _, err := service.UpdateSchedule(ctx, update); err != nil {
+		t.Fatalf("update without constraints: %v", err)
+	}
+
+	saved, err := service.GetSchedule(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(saved.Constraints) != 2 {
+		t.Fatalf("omitted constraints must be kept, got %+v", saved.Constraints)
+	}
+	if c := saved.Constraints[0]; c.TargetBlockID != saved.Blocks[0].ID || c.TargetBlock != "Церемония открытия" {
+		t.Fatalf("constraint must follow the renamed block, got %+v", c)
+	}
+
+	// Пустой список удаляет ограничения
+	saved.Constraints = []models.BlockConstraint{}
+	if _, err := service.UpdateSchedule(ctx, saved); err != nil {
+		t.Fatalf("update with empty constraints: %v", err)
+	}
+	cleared, err := service.GetSchedule(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(cleared.Constraints) != 0 {
+		t.Fatalf("empty constraints must remove the saved ones, got %+v", cleared.Constraints)
+	}
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+type SearchService struct {
+	searchRepo domain.SearchRepository
+	logger     *zap.Logger
+}
+
+func NewSearchService(searchRepo domain.SearchRepository, logger *zap.Logger) *SearchService {
+	return &SearchService{
+		searchRepo: searchRepo,
+		logger:     logger,
+	}
+}
+
+// Search ищет совпадения по названиям расписаний, блоков и элементов
+func (s *SearchService) Search(ctx context.Context, query string, offset, limit int) ([]models.SearchHit, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: search query must not be empty", utils.ErrInvalidInput)
+	}
+
+	hits, err := s.searchRepo.Search(ctx, query, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search schedules: %w", err)
+	}
+
+	return hits, nil
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/share"
+	"cor-events-scheduler/internal/domain/validation"
+	"cor-events-scheduler/internal/domain/views"
+	"cor-events-scheduler/internal/web"
+	"cor-events-scheduler/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// Сроки действия ссылок
+const (
+	DefaultShareLinkLifetime = 7 * 24 * time.Hour
+	MaxShareLinkLifetime     = 90 * 24 * time.Hour
+)
+
+// ShareService выдает ссылки только для чтения на представление расписания и
+// открывает расписание по их токенам
+type ShareService struct {
+	linkRepo  domain.ShareLinkRepository
+	schedules *SchedulerService
+	versions  *VersionService
+	signer    *share.Signer
+	logger    *zap.Logger
+}
+
+func NewShareService(
+	linkRepo domain.ShareLinkRepository,
+	schedules *SchedulerService,
+	versions *VersionService,
+	signer *share.Signer,
+	logger *zap.Logger,
+) *ShareService {
+	return &ShareService{
+		linkRepo:  linkRepo,
+		schedules: schedules,
+		versions:  versions,
+		signer:    signer,
+		logger:    logger,
+	}
+}
+
+// ShareLinkRequest — параметры новой ссылки
+type ShareLinkRequest struct {
+	// View — представление: public или crew; представления по выступающему не выдаются
+	View string `json:"view" binding:"required" example:"crew"`
+	// Version — номер версии расписания; без него ссылка показывает текущее расписание
+	Version *int   `json:"version,omitempty"`
+	Label   string `json:"label" example:"Для техников площадки"`
+	// ExpiresAt — окончание срока действия; по умолчанию через 7 дней, не позже чем через 90
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ShareLinksQuery — фильтр списка ссылок; all включает отозванные и просроченные
+type ShareLinksQuery struct {
+	All bool `form:"all"`
+}
+
+// CreateLink выдает ссылку на представление расписания или его версии
+func (s *ShareService) CreateLink(ctx context.Context, scheduleID uint, request ShareLinkRequest) (*models.ShareLink, error) {
+	if _, err := s.schedules.GetSchedule(ctx, scheduleID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	link := &models.ShareLink{
+		ScheduleID: scheduleID,
+		View:       request.View,
+		Version:    request.Version,
+		Label:      request.Label,
+		ExpiresAt:  now.Add(DefaultShareLinkLifetime),
+	}
+	if request.ExpiresAt != nil {
+		link.ExpiresAt = *request.ExpiresAt
+	}
+	// Токен хранит срок действия с точностью до секунды
+	link.ExpiresAt = link.ExpiresAt.UTC().Truncate(time.Second)
+
+	report := validation.ValidateShareLink(link, now, MaxShareLinkLifetime)
+	if err := report.Err(); err != nil {
+		return nil, err
+	}
+	if link.Version != nil {
+		if _, err := s.versions.GetVersion(ctx, scheduleID, *link.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.linkRepo.CreateShareLink(ctx, link); err != nil {
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+	link.Token = s.token(link)
+
+	s.logger.Info("Created share link",
+		zap.Uint("schedule_id", scheduleID),
+		zap.Uint("link_id", link.ID),
+		zap.String("view", link.View),
+		zap.Time("expires_at", link.ExpiresAt),
+	)
+	return link, nil
+}
+
+// ListLinks возвращает действующие ссылки расписания с их токенами, а с
+// query.All — также отозванные и просроченные
+func (s *ShareService) ListLinks(ctx context.Context, scheduleID uint, query ShareLinksQuery) ([]models.ShareLink, error) {
+	if _, err := s.schedules.GetSchedule(ctx, scheduleID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.linkRepo.ListShareLinks(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+
+	now := time.Now()
+	result := make([]models.ShareLink, 0, len(links))
+	for _, link := range links {
+		active := link.Active(now)
+		if !active && !query.All {
+			continue
+		}
+		if active {
+			link.Token = s.token(&link)
+		}
+		result = append(result, link)
+	}
+	return result, nil
+}
+
+// RevokeLink отзывает ссылку расписания; повторный отзыв ничего не меняет
+func (s *ShareService) RevokeLink(ctx context.Context, scheduleID, linkID uint) error {
+	link, err := s.linkRepo.GetShareLink(ctx, linkID)
+	if err != nil {
+		return fmt.Errorf("failed to get share link: %w", err)
+	}
+	if link.ScheduleID != scheduleID {
+		return fmt.Errorf("%w: share link %d does not belong to schedule %d", utils.ErrNotFound, linkID, scheduleID)
+	}
+
+	if err := s.linkRepo.RevokeShareLink(ctx, linkID, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+
+	s.logger.Info("Revoked share link", zap.Uint("schedule_id", scheduleID), zap.Uint("link_id", linkID))
+	return nil
+}
+
+// SharedView возвращает расписание по токену в представлении ссылки
+func (s *ShareService) SharedView(ctx context.Context, token string) (views.Object, error) {
+	view, schedule, err := s.resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return view.Project(schedule), nil
+}
+
+// SharedText возвращает расписание по токену в виде текста
+func (s *ShareService) SharedText(ctx context.Context, token string) (string, error) {
+	_, schedule, err := s.resolve(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return formatText(schedule), nil
+}
+
+// SharedPage возвращает HTML-страницу расписания по токену
+func (s *ShareService) SharedPage(ctx context.Context, token string, options web.Options) ([]byte, error) {
+	_, schedule, err := s.resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return renderPage(schedule, options)
+}
+
+// errShareLinkNotFound — единый ответ на любой отклоненный токен, чтобы по
+// нему нельзя было отличить отозванную ссылку от поддельной или просроченной
+var errShareLinkNotFound = fmt.Errorf("%w: share link not found", utils.ErrNotFound)
+
+// resolve проверяет токен и ссылку, учитывает обращение и возвращает
+// представление ссылки с уже отфильтрованным расписанием
+func (s *ShareService) resolve(ctx context.Context, token string) (views.View, *models.Schedule, error) {
+	now := time.Now()
+	link, err := s.checkToken(ctx, token, now)
+	if errors.Is(err, utils.ErrNotFound) {
+		s.logger.Info("Rejected share token", zap.Error(err))
+		return views.View{}, nil, errShareLinkNotFound
+	}
+	if err != nil {
+		return views.View{}, nil, err
+	}
+
+	view, ok := views.Get(link.View)
+	if !ok {
+		return views.View{}, nil, fmt.Errorf("share link %d has unknown view %q", link.ID, link.View)
+	}
+
+	var schedule *models.Schedule
+	if link.Version != nil {
+		schedule, err = s.versions.GetVersionSchedule(ctx, link.ScheduleID, *link.Version)
+	} else {
+		schedule, err = s.schedules.GetSchedule(ctx, link.ScheduleID)
+	}
+	if err != nil {
+		return views.View{}, nil, err
+	}
+
+	if err := s.linkRepo.RecordShareAccess(ctx, link.ID, now); err != nil {
+		return views.View{}, nil, fmt.Errorf("failed to record share link access: %w", err)
+	}
+	return view, view.Filter(schedule, 0), nil
+}
+
+// checkToken проверяет подпись и срок токена и возвращает действующую ссылку,
+// на которую он выдан
+func (s *ShareService) checkToken(ctx context.Context, token string, now time.Time) (*models.ShareLink, error) {
+	claims, err := s.signer.Verify(token, now)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.linkRepo.GetShareLink(ctx, claims.LinkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+	// Подпись верна, но ссылка в хранилище могла принадлежать другой базе
+	if claims != s.claims(link) {
+		return nil, fmt.Errorf("%w: share token does not match link %d", utils.ErrNotFound, link.ID)
+	}
+	if !link.Active(now) {
+		return nil, fmt.Errorf("%w: share link %d is revoked or expired", utils.ErrNotFound, link.ID)
+	}
+	return link, nil
+}
+
+func (s *ShareService) claims(link *models.ShareLink) share.Claims {
+	claims := share.Claims{
+		LinkID:     link.ID,
+		ScheduleID: link.ScheduleID,
+		View:       link.View,
+		ExpiresAt:  link.ExpiresAt.Unix(),
+	}
+	if link.Version != nil {
+		claims.Version = *link.Version
+	}
+	return claims
+}
+
+func (s *ShareService) token(link *models.ShareLink) string {
+	return s.signer.Sign(s.claims(link))
+}
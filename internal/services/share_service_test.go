@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/risk"
+	"cor-events-scheduler/internal/domain/share"
+	"cor-events-scheduler/internal/domain/views"
+	"cor-events-scheduler/internal/infrastructure/memory"
+	"cor-events-scheduler/internal/web"
+	"cor-events-scheduler/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+func TestShareLinks(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	scheduleRepo := memory.NewScheduleRepository(store)
+	versionRepo := memory.NewVersionRepository(store)
+	linkRepo := memory.NewShareLinkRepository(store)
+	ruleService := NewRuleService(memory.NewRuleSetRepository(store), scheduleRepo, zap.NewNop())
+	performerService := NewPerformerService(memory.NewPerformerRepository(store), zap.NewNop())
+	resourceService := NewResourceService(memory.NewResourceRepository(store), zap.NewNop())
+	riskService := NewRiskService(scheduleRepo, risk.NewAnalyzer(risk.Options{}), nil, zap.NewNop())
+	schedulerService := NewSchedulerService(scheduleRepo, versionRepo, ruleService, riskService, performerService, resourceService, nil, nil, nil, zap.NewNop())
+	versionService := NewVersionService(versionRepo, scheduleRepo, ruleService, performerService, resourceService, schedulerService, zap.NewNop())
+	service := NewShareService(linkRepo, schedulerService, versionService, share.NewSigner([]byte("secret")), zap.NewNop())
+
+	schedule := domaintest.NewSchedule("Фестиваль")
+	schedule.Blocks[0].Items[0].Visibility = models.VisibilityCrew
+	if _, err := schedulerService.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	public, err := service.CreateLink(ctx, schedule.ID, ShareLinkRequest{View: views.Public})
+	if err != nil {
+		t.Fatalf("create public link: %v", err)
+	}
+	if public.Token == "" || public.ExpiresAt.Sub(time.Now()) < DefaultShareLinkLifetime-time.Minute {
+		t.Fatalf("link must have a token and the default lifetime, got %+v", public)
+	}
+	crew, err := service.CreateLink(ctx, schedule.ID, ShareLinkRequest{View: views.Crew, Label: "Техники"})
+	if err != nil {
+		t.Fatalf("create crew link: %v", err)
+	}
+
+	// Ссылка открывает только свое представление
+	text, err := service.SharedText(ctx, public.Token)
+	if err != nil {
+		t.Fatalf("shared text: %v", err)
+	}
+	if strings.Contains(text, "Приветствие") || !strings.Contains(text, "Гимн") {
+		t.Fatalf("public link must hide crew items:\n%s", text)
+	}
+	if text, _ = service.SharedText(ctx, crew.Token); !strings.Contains(text, "Приветствие") {
+		t.Fatalf("crew link must show crew items:\n%s", text)
+	}
+	if _, err := service.SharedPage(ctx, crew.Token, web.Options{}); err != nil {
+		t.Fatalf("shared page: %v", err)
+	}
+
+	// Отзыв закрывает доступ, а счетчик хранит обращения до отзыва
+	if err := service.RevokeLink(ctx, schedule.ID, crew.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	// Отозванная ссылка неотличима от поддельной
+	if _, err := service.SharedView(ctx, crew.Token); err != errShareLinkNotFound {
+		t.Fatalf("revoked link must fail with errShareLinkNotFound, got %v", err)
+	}
+	if err := service.RevokeLink(ctx, schedule.ID+1, public.ID); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("link of another schedule must fail with ErrNotFound, got %v", err)
+	}
+
+	active, err := service.ListLinks(ctx, schedule.ID, ShareLinksQuery{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != public.ID || active[0].Token != public.Token || active[0].AccessCount != 1 {
+		t.Fatalf("want the public link with one access, got %+v", active)
+	}
+	all, _ := service.ListLinks(ctx, schedule.ID, ShareLinksQuery{All: true})
+	if len(all) != 2 || all[1].RevokedAt == nil || all[1].Token != "" || all[1].AccessCount != 2 {
+		t.Fatalf("want the revoked crew link without a token and two accesses, got %+v", all)
+	}
+
+	// Ссылка на версию показывает ее снимок, а не текущее расписание
+	version := 1
+	pinned, err := service.CreateLink(ctx, schedule.ID, ShareLinkRequest{View: views.Public, Version: &version})
+	if err != nil {
+		t.Fatalf("create version link: %v", err)
+	}
+	current, _ := schedulerService.GetSchedule(ctx, schedule.ID)
+	current.Name = "Фестиваль (перенос)"
+	if _, err := schedulerService.UpdateSchedule(ctx, current); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	object, err := service.SharedView(ctx, pinned.Token)
+	if err != nil {
+		t.Fatalf("shared view: %v", err)
+	}
+	if name, _ := object.Get("name"); name != "Фестиваль" {
+		t.Fatalf("version link must show version 1, got %v", name)
+	}
+
+	missing := 99
+	if _, err := service.CreateLink(ctx, schedule.ID, ShareLinkRequest{View: views.Public, Version: &missing}); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("link to a missing version must fail with ErrNotFound, got %v", err)
+	}
+	if _, err := service.CreateLink(ctx, schedule.ID, ShareLinkRequest{View: views.Performers}); !errors.Is(err, utils.ErrValidation) {
+		t.Fatalf("performer view link must fail validation, got %v", err)
+	}
+	if _, err := service.SharedView(ctx, "forged."+public.Token); err != errShareLinkNotFound {
+		t.Fatalf("forged token must fail with errShareLinkNotFound, got %v", err)
+	}
+}
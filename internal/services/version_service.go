@@ -3,84 +3,122 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/changelog"
 	"cor-events-scheduler/internal/domain/models"
-	"cor-events-scheduler/internal/domain/repositories"
+	"cor-events-scheduler/pkg/utils"
 
-	"github.com/r3labs/diff"
 	"go.uber.org/zap"
 )
 
 type VersionService struct {
-	versionRepo  *repositories.VersionRepository
-	scheduleRepo *repositories.ScheduleRepository
+	versionRepo  domain.VersionRepository
+	scheduleRepo domain.ScheduleRepository
+	ruleService  *RuleService
+	performers   *PerformerService
+	resources    *ResourceService
+	scheduler    *SchedulerService
 	logger       *zap.Logger
 }
 
 func NewVersionService(
-	versionRepo *repositories.VersionRepository,
-	scheduleRepo *repositories.ScheduleRepository,
+	versionRepo domain.VersionRepository,
+	scheduleRepo domain.ScheduleRepository,
+	ruleService *RuleService,
+	performers *PerformerService,
+	resources *ResourceService,
+	scheduler *SchedulerService,
 	logger *zap.Logger,
 ) *VersionService {
 	return &VersionService{
 		versionRepo:  versionRepo,
 		scheduleRepo: scheduleRepo,
+		ruleService:  ruleService,
+		performers:   performers,
+		resources:    resources,
+		scheduler:    scheduler,
 		logger:       logger,
 	}
 }
 
 func (s *VersionService) CreateNewVersion(ctx context.Context, schedule *models.Schedule, createdBy string) error {
+	_, err := s.createVersion(ctx, schedule, createdBy, nil)
+	return err
+}
+
+// createVersion сохраняет снимок расписания как новую версию
+func (s *VersionService) createVersion(ctx context.Context, schedule *models.Schedule, createdBy string, restoredFrom *int) (*models.ScheduleVersion, error) {
 	// Получаем последнюю версию
 	latestVersion, err := s.versionRepo.GetLatestVersion(ctx, schedule.ID)
+	if errors.Is(err, utils.ErrNotFound) {
+		latestVersion, err = nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest version: %w", err)
+	}
+
+	version, err := newVersion(schedule, latestVersion, createdBy, restoredFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	// Сохраняем новую версию
+	if err := s.versionRepo.CreateVersion(ctx, version); err != nil {
+		return nil, fmt.Errorf("failed to create version: %w", err)
+	}
+
+	s.logger.Info("Created new schedule version",
+		zap.Uint("schedule_id", schedule.ID),
+		zap.Int("version", version.Version),
+		zap.String("created_by", createdBy),
+	)
+
+	return version, nil
+}
+
+// newVersion строит следующую за latestVersion версию со снимком расписания.
+// restoredFrom указывает версию, из которой восстановлено расписание.
+func newVersion(schedule *models.Schedule, latestVersion *models.ScheduleVersion, createdBy string, restoredFrom *int) (*models.ScheduleVersion, error) {
 	newVersionNum := 1
-	if err == nil {
+	if latestVersion != nil {
 		newVersionNum = latestVersion.Version + 1
 	}
 
 	// Сериализуем расписание
 	scheduleData, err := json.Marshal(schedule)
 	if err != nil {
-		return fmt.Errorf("failed to marshal schedule: %w", err)
+		return nil, fmt.Errorf("failed to marshal schedule: %w", err)
 	}
 
 	// Создаем запись о версии
 	version := &models.ScheduleVersion{
-		ScheduleID: schedule.ID,
-		Version:    newVersionNum,
-		Data:       scheduleData,
-		CreatedBy:  createdBy,
-		CreatedAt:  time.Now(),
-		IsActive:   true,
+		ScheduleID:   schedule.ID,
+		Version:      newVersionNum,
+		Data:         scheduleData,
+		CreatedBy:    createdBy,
+		CreatedAt:    time.Now(),
+		IsActive:     true,
+		RestoredFrom: restoredFrom,
 	}
 
 	// Если есть предыдущая версия, вычисляем изменения
 	if latestVersion != nil {
-		var oldSchedule models.Schedule
-		if err := json.Unmarshal(latestVersion.Data, &oldSchedule); err != nil {
-			return fmt.Errorf("failed to unmarshal old schedule: %w", err)
-		}
-
-		changelog, err := s.generateChangelog(&oldSchedule, schedule)
+		changes, err := describeChanges(latestVersion, schedule)
 		if err != nil {
-			return fmt.Errorf("failed to generate changelog: %w", err)
+			return nil, err
 		}
-		version.Changes = changelog
+		version.Changes = changes
 	}
 
-	// Сохраняем новую версию
-	if err := s.versionRepo.CreateVersion(ctx, version); err != nil {
-		return fmt.Errorf("failed to create version: %w", err)
+	if restoredFrom != nil {
+		version.Changes = fmt.Sprintf("Restored from version %d\n", *restoredFrom) + version.Changes
 	}
 
-	s.logger.Info("Created new schedule version",
-		zap.Uint("schedule_id", schedule.ID),
-		zap.Int("version", newVersionNum),
-		zap.String("created_by", createdBy),
-	)
-
-	return nil
+	return version, nil
 }
 
 func (s *VersionService) GetVersionHistory(ctx context.Context, scheduleID uint) ([]models.VersionMetadata, error) {
@@ -92,72 +130,85 @@ func (s *VersionService) GetVersionHistory(ctx context.Context, scheduleID uint)
 	metadata := make([]models.VersionMetadata, len(versions))
 	for i, v := range versions {
 		metadata[i] = models.VersionMetadata{
-			Version:   v.Version,
-			CreatedAt: v.CreatedAt,
-			Changes:   v.Changes,
+			Version:      v.Version,
+			CreatedAt:    v.CreatedAt,
+			CreatedBy:    v.CreatedBy,
+			Changes:      v.Changes,
+			RestoredFrom: v.RestoredFrom,
 		}
 	}
 
 	return metadata, nil
 }
 
-func (s *VersionService) RestoreVersion(ctx context.Context, scheduleID uint, version int) error {
-	versions, err := s.versionRepo.GetVersionsByScheduleID(ctx, scheduleID) // Было GetVersion
+// RestoreVersion восстанавливает расписание из снимка версии. Снимок проходит
+// ту же подготовку, что и обычное обновление, а результат записывается как
+// новая версия с указанием исходной; после сохранения, как и при обновлении,
+// пересчитываются риски и напоминания, а выступающие узнают о переносах.
+func (s *VersionService) RestoreVersion(ctx context.Context, scheduleID uint, version int, createdBy string) (*models.Schedule, error) {
+	schedule, err := s.GetVersionSchedule(ctx, scheduleID, version)
 	if err != nil {
-		return fmt.Errorf("failed to get version: %w", err)
+		return nil, err
 	}
-
-	// Найдем нужную версию
-	var scheduleVersion *models.ScheduleVersion
-	for i := range versions {
-		if versions[i].Version == version {
-			scheduleVersion = &versions[i]
-			break
-		}
+	current, err := s.scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current schedule: %w", err)
 	}
 
-	if scheduleVersion == nil {
-		return fmt.Errorf("version %d not found", version)
+	ruleSet, err := s.ruleService.EffectiveRules(ctx, scheduleID)
+	if err != nil {
+		return nil, err
 	}
 
-	var schedule models.Schedule
-	if err := json.Unmarshal(scheduleVersion.Data, &schedule); err != nil {
-		return fmt.Errorf("failed to unmarshal schedule data: %w", err)
+	report, err := prepareSchedule(schedule, ruleSet)
+	if err == nil {
+		// Выступающие и ресурсы снимка могли быть удалены или заняты после его записи
+		err = checkSharedUsage(ctx, s.performers, s.resources, schedule, &report)
+	}
+	if errors.Is(err, utils.ErrValidation) {
+		return nil, fmt.Errorf("%w: version %d cannot be restored: %w", utils.ErrConflict, version, err)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.scheduleRepo.Update(ctx, &schedule); err != nil {
-		return fmt.Errorf("failed to restore schedule: %w", err)
+	// Расписание и запись о восстановлении сохраняются вместе: восстановление
+	// без версии потерялось бы в истории
+	var restored *models.Schedule
+	var recorded *models.ScheduleVersion
+	err = s.scheduleRepo.UpdateWithVersion(ctx, schedule, func(saved *models.Schedule, latest *models.ScheduleVersion) (*models.ScheduleVersion, error) {
+		restored = saved
+		next, err := newVersion(saved, latest, createdBy, &version)
+		recorded = next
+		return next, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore schedule: %w", err)
 	}
+	s.scheduler.scheduleUpdated(ctx, current, restored)
 
 	s.logger.Info("Restored schedule version",
 		zap.Uint("schedule_id", scheduleID),
 		zap.Int("version", version),
+		zap.Int("new_version", recorded.Version),
 	)
 
-	return nil
+	return restored, nil
 }
 
-func (s *VersionService) generateChangelog(old, new *models.Schedule) (string, error) {
-	changelog := ""
-
-	// Используем библиотеку r3labs/diff для сравнения структур
-	differences, err := diff.Diff(old, new)
-	if err != nil {
-		return "", fmt.Errorf("failed to calculate diff: %w", err)
+// describeChanges описывает отличия schedule от снимка previous текстом на
+// языке расписания, по строке на изменение
+func describeChanges(previous *models.ScheduleVersion, schedule *models.Schedule) (string, error) {
+	var old models.Schedule
+	if err := json.Unmarshal(previous.Data, &old); err != nil {
+		return "", fmt.Errorf("failed to unmarshal old schedule: %w", err)
 	}
 
-	for _, d := range differences {
-		switch d.Type {
-		case diff.CREATE:
-			changelog += fmt.Sprintf("Added %s: %v\n", d.Path, d.To)
-		case diff.UPDATE:
-			changelog += fmt.Sprintf("Changed %s: %v -> %v\n", d.Path, d.From, d.To)
-		case diff.DELETE:
-			changelog += fmt.Sprintf("Removed %s: %v\n", d.Path, d.From)
-		}
+	var text string
+	for _, line := range changelog.Text(changelog.Compare(&old, schedule), schedule.Language, schedule.Location()) {
+		text += line + "\n"
 	}
-
-	return changelog, nil
+	return text, nil
 }
 
 func (s *VersionService) GetVersion(ctx context.Context, scheduleID uint, version int) (*models.ScheduleVersion, error) {
@@ -173,5 +224,78 @@ func (s *VersionService) GetVersion(ctx context.Context, scheduleID uint, versio
 		}
 	}
 
-	return nil, fmt.Errorf("version %d not found for schedule %d", version, scheduleID)
+	return nil, fmt.Errorf("%w: version %d not found for schedule %d", utils.ErrNotFound, version, scheduleID)
+}
+
+// GetVersionSchedule возвращает расписание из снимка версии
+func (s *VersionService) GetVersionSchedule(ctx context.Context, scheduleID uint, version int) (*models.Schedule, error) {
+	scheduleVersion, err := s.GetVersion(ctx, scheduleID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule models.Schedule
+	if err := json.Unmarshal(scheduleVersion.Data, &schedule); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schedule data: %w", err)
+	}
+	schedule.ID = scheduleID
+	return &schedule, nil
+}
+
+// VersionChangesQuery — версия, с которой сравнивается запрошенная, и язык текста
+type VersionChangesQuery struct {
+	// From — номер версии для сравнения; по умолчанию предыдущая, 0 — расписание без блоков
+	From *int `form:"from"`
+	// Language — язык текста: ru или en; по умолчанию язык расписания
+	Language string `form:"lang"`
+}
+
+// VersionChanges — отличия версии To от версии From
+type VersionChanges struct {
+	From    int                `json:"from"`
+	To      int                `json:"to"`
+	Changes []changelog.Change `json:"changes"`
+	Text    []string           `json:"text"`
+}
+
+// CompareVersions сравнивает снимки двух версий расписания: блоки и элементы
+// сопоставляются по ID, а изменения возвращаются записями и текстом
+func (s *VersionService) CompareVersions(ctx context.Context, scheduleID uint, version int, query VersionChangesQuery) (*VersionChanges, error) {
+	if query.Language != "" && !changelog.Supported(query.Language) {
+		return nil, fmt.Errorf("%w: unsupported language %q", utils.ErrInvalidInput, query.Language)
+	}
+
+	to, err := s.GetVersionSchedule(ctx, scheduleID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	from := version - 1
+	if query.From != nil {
+		from = *query.From
+	}
+	if from < 0 {
+		return nil, fmt.Errorf("%w: version must not be negative", utils.ErrInvalidInput)
+	}
+	// Пустое расписание отличается от версии только блоками
+	empty := *to
+	empty.Blocks = nil
+	old := &empty
+	if from > 0 {
+		if old, err = s.GetVersionSchedule(ctx, scheduleID, from); err != nil {
+			return nil, err
+		}
+	}
+
+	language := query.Language
+	if language == "" {
+		language = to.Language
+	}
+	changes := changelog.Compare(old, to)
+	return &VersionChanges{
+		From:    from,
+		To:      version,
+		Changes: changes,
+		Text:    changelog.Text(changes, language, to.Location()),
+	}, nil
 }
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"cor-events-scheduler/internal/domain"
+	"cor-events-scheduler/internal/domain/domaintest"
+	"cor-events-scheduler/internal/domain/models"
+	"cor-events-scheduler/internal/domain/risk"
+	"cor-events-scheduler/internal/infrastructure/memory"
+
+	"go.uber.org/zap"
+)
+
+func newTestServices() (*SchedulerService, *VersionService, domain.VersionRepository) {
+	store := memory.NewStore()
+	scheduleRepo := memory.NewScheduleRepository(store)
+	versionRepo := memory.NewVersionRepository(store)
+	ruleService := NewRuleService(memory.NewRuleSetRepository(store), scheduleRepo, zap.NewNop())
+
+	riskService := NewRiskService(scheduleRepo, risk.NewAnalyzer(risk.Options{}), nil, zap.NewNop())
+	performerService := NewPerformerService(memory.NewPerformerRepository(store), zap.NewNop())
+	resourceService := NewResourceService(memory.NewResourceRepository(store), zap.NewNop())
+
+	schedulerService := NewSchedulerService(scheduleRepo, versionRepo, ruleService, riskService, performerService, resourceService, nil, nil, nil, zap.NewNop())
+	return schedulerService,
+		NewVersionService(versionRepo, scheduleRepo, ruleService, performerService, resourceService, schedulerService, zap.NewNop()),
+		versionRepo
+}
+
+func TestUpdateAndRestoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	schedulerService, versionService, versionRepo := newTestServices()
+
+	// Создание
+	original := domaintest.NewSchedule("Фестиваль")
+	if _, err := schedulerService.CreateSchedule(ctx, original); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	created, err := schedulerService.GetSchedule(ctx, original.ID)
+	if err != nil {
+		t.Fatalf("get created: %v", err)
+	}
+	domaintest.AssertSameSchedule(t, original, created)
+
+	// Обновление всех полей, включая ранее игнорируемые
+	updated := domaintest.NewSchedule("Фестиваль")
+	updated.ID = created.ID
+	updated.Name = "Фестиваль (перенос)"
+	for i := range updated.Blocks {
+		updated.Blocks[i].ID = created.Blocks[i].ID
+		for j := range updated.Blocks[i].Items {
+			updated.Blocks[i].Items[j].ID = created.Blocks[i].Items[j].ID
+		}
+	}
+	updated.Blocks[0].Type = "ceremony"
+	updated.Blocks[0].TechBreakDuration = 15
+	updated.Blocks[0].Items[0].Type = "video"
+	updated.Blocks[0].Items[0].Description = "Видеообращение"
+	updated.Blocks[1].Items = append(updated.Blocks[1].Items, models.BlockItem{
+		Name: "Участник 2", Type: "performance", Description: "Сценка", Duration: 15, Order: 2,
+	})
+
+	if _, err := schedulerService.UpdateSchedule(ctx, updated); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	stored, err := schedulerService.GetSchedule(ctx, updated.ID)
+	if err != nil {
+		t.Fatalf("get updated: %v", err)
+	}
+	domaintest.AssertSameSchedule(t, updated, stored)
+
+	// Восстановление первой версии
+	restored, err := versionService.RestoreVersion(ctx, created.ID, 1, "tester")
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	domaintest.AssertSameSchedule(t, created, restored)
+
+	reloaded, err := schedulerService.GetSchedule(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("get restored: %v", err)
+	}
+	domaintest.AssertSameSchedule(t, created, reloaded)
+
+	// Восстановление записано новой версией со ссылкой на исходную
+	latest, err := versionRepo.GetLatestVersion(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("latest version: %v", err)
+	}
+	if latest.RestoredFrom == nil || *latest.RestoredFrom != 1 {
+		t.Fatalf("latest version should be restored from 1, got %v", latest.RestoredFrom)
+	}
+	if latest.CreatedBy != "tester" {
+		t.Fatalf("latest version author = %q, want tester", latest.CreatedBy)
+	}
+
+	// Обновление и восстановление записывают состояние после изменения, поэтому
+	// у каждой версии есть изменения относительно предыдущей
+	versions, err := versionRepo.GetVersionsByScheduleID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("versions: %v", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("want versions for create, update and restore, got %d", len(versions))
+	}
+	if versions[1].Changes == "" {
+		t.Fatal("update version must describe the update")
+	}
+	if !strings.Contains(latest.Changes, "Расписание переименовано в «Фестиваль»") || !strings.Contains(latest.Changes, "Элемент «Участник 2» удален") {
+		t.Fatalf("restore version must describe the undone update, got %q", latest.Changes)
+	}
+}
+
+func TestRestoreRejectsInvalidSnapshot(t *testing.T) {
+	ctx := context.Background()
+	schedulerService, versionService, versionRepo := newTestServices()
+
+	schedule := domaintest.NewSchedule("Фестиваль")
+	if _, err := schedulerService.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Снимок, блоки которого не помещаются в окно расписания
+	broken := domaintest.NewSchedule("Фестиваль")
+	broken.ID = schedule.ID
+	broken.EndDate = broken.StartDate.Add(30 * time.Minute)
+	if err := versionService.CreateNewVersion(ctx, broken, "tester"); err != nil {
+		t.Fatalf("create version: %v", err)
+	}
+
+	latest, err := versionRepo.GetLatestVersion(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("latest version: %v", err)
+	}
+	if _, err := versionService.RestoreVersion(ctx, schedule.ID, latest.Version, "tester"); err == nil {
+		t.Fatal("restore of a snapshot that overflows the schedule window should fail")
+	}
+}
+
+func TestVersionChanges(t *testing.T) {
+	ctx := context.Background()
+	schedulerService, versionService, versionRepo := newTestServices()
+
+	schedule := domaintest.NewSchedule("Фестиваль")
+	schedule.Language = models.LanguageEnglish
+	if _, err := schedulerService.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Каждое обновление записывает версию со своими изменениями
+	for _, duration := range []int{8, 6} {
+		current, err := schedulerService.GetSchedule(ctx, schedule.ID)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		current.Blocks[0].Items[0].Duration = duration
+		if _, err := schedulerService.UpdateSchedule(ctx, current); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+	}
+
+	latest, err := versionRepo.GetLatestVersion(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("latest version: %v", err)
+	}
+	if want := "Item 'Приветствие' shortened from 8 to 6 min\n"; latest.Changes != want {
+		t.Fatalf("want changes %q, got %q", want, latest.Changes)
+	}
+
+	changes, err := versionService.CompareVersions(ctx, schedule.ID, latest.Version, VersionChangesQuery{Language: models.LanguageRussian})
+	if err != nil {
+		t.Fatalf("compare: %v", err)
+	}
+	if changes.From != latest.Version-1 || len(changes.Changes) != 1 || changes.Text[0] != "Элемент «Приветствие» сокращен с 8 до 6 мин" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+
+	from := 0
+	changes, err = versionService.CompareVersions(ctx, schedule.ID, 1, VersionChangesQuery{From: &from})
+	if err != nil {
+		t.Fatalf("compare with empty: %v", err)
+	}
+	if len(changes.Changes) == 0 || changes.Text[0] != "Block 'Открытие' added" {
+		t.Fatalf("comparison with an empty schedule must add everything, got %+v", changes.Text)
+	}
+}
@@ -0,0 +1,26 @@
+// Обновляет выделение идущего блока и элемента по часам браузера и сообщает
+// высоту страницы родительскому окну, когда она открыта в iframe.
+(function () {
+  var spans = document.querySelectorAll("[data-start][data-end]");
+
+  function refresh() {
+    var now = Date.now();
+    for (var i = 0; i < spans.length; i++) {
+      var start = Date.parse(spans[i].getAttribute("data-start"));
+      var end = Date.parse(spans[i].getAttribute("data-end"));
+      spans[i].classList.toggle("is-current", now >= start && now < end);
+      spans[i].classList.toggle("is-past", now >= end);
+    }
+  }
+
+  function reportHeight() {
+    if (window.parent !== window) {
+      window.parent.postMessage({ type: "schedule:height", height: document.documentElement.scrollHeight }, "*");
+    }
+  }
+
+  refresh();
+  reportHeight();
+  setInterval(refresh, 30000);
+  window.addEventListener("resize", reportHeight);
+})();
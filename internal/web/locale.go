@@ -0,0 +1,85 @@
+package web
+
+import (
+	"fmt"
+	"time"
+
+	"cor-events-scheduler/internal/domain/models"
+)
+
+const defaultLanguage = models.LanguageRussian
+
+// labels — подписи страницы на языке расписания
+type labels struct {
+	Program  string
+	Now      string
+	Timezone string
+	Print    string
+	Empty    string
+}
+
+type locale struct {
+	code     string
+	labels   labels
+	months   [12]string
+	weekdays [7]string
+	// formatDay и formatDate строят заголовок дня программы и дату с годом
+	// по названиям месяца и дня недели
+	formatDay  func(t time.Time, month, weekday string) string
+	formatDate func(t time.Time, month string) string
+}
+
+var locales = map[string]*locale{
+	models.LanguageRussian: {
+		code: models.LanguageRussian,
+		labels: labels{
+			Program:  "Программа",
+			Now:      "Сейчас",
+			Timezone: "Время указано в часовом поясе",
+			Print:    "Распечатать",
+			Empty:    "Программа пока не опубликована",
+		},
+		months: [12]string{"января", "февраля", "марта", "апреля", "мая", "июня",
+			"июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		weekdays: [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		formatDay: func(t time.Time, month, weekday string) string {
+			return fmt.Sprintf("%d %s, %s", t.Day(), month, weekday)
+		},
+		formatDate: func(t time.Time, month string) string {
+			return fmt.Sprintf("%d %s %d", t.Day(), month, t.Year())
+		},
+	},
+	models.LanguageEnglish: {
+		code: models.LanguageEnglish,
+		labels: labels{
+			Program:  "Programme",
+			Now:      "Now",
+			Timezone: "Times are shown in",
+			Print:    "Print",
+			Empty:    "The programme has not been published yet",
+		},
+		months: [12]string{"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December"},
+		weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		formatDay: func(t time.Time, month, weekday string) string {
+			return fmt.Sprintf("%s, %s %d", weekday, month, t.Day())
+		},
+		formatDate: func(t time.Time, month string) string {
+			return fmt.Sprintf("%s %d, %d", month, t.Day(), t.Year())
+		},
+	},
+}
+
+// day возвращает заголовок дня программы
+func (l *locale) day(t time.Time) string {
+	return l.formatDay(t, l.months[t.Month()-1], l.weekdays[t.Weekday()])
+}
+
+// period возвращает даты расписания: один день или первый и последний через тире
+func (l *locale) period(start, end time.Time) string {
+	first := l.formatDate(start, l.months[start.Month()-1])
+	if start.Format(time.DateOnly) == end.Format(time.DateOnly) {
+		return first
+	}
+	return first + " – " + l.formatDate(end, l.months[end.Month()-1])
+}
@@ -0,0 +1,172 @@
+// Package web отрисовывает публичную HTML-страницу расписания для участников:
+// программу по дням с выделением идущего блока, версию для печати и режим
+// встраивания в iframe. Шаблон, стили и скрипт встроены в бинарник.
+package web
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"time"
+)
+
+// ContentType — MIME-тип страницы
+const ContentType = "text/html; charset=utf-8"
+
+var (
+	//go:embed templates/schedule.html
+	templates embed.FS
+
+	//go:embed assets/schedule.css
+	styles string
+
+	//go:embed assets/schedule.js
+	script string
+
+	pageTemplate = template.Must(template.ParseFS(templates, "templates/schedule.html"))
+)
+
+// Schedule — публичная программа расписания
+type Schedule struct {
+	Name string
+	// Language — язык страницы: ru (по умолчанию) или en
+	Language string
+	// Location — часовой пояс, в котором показываются времена; nil означает UTC
+	Location  *time.Location
+	StartDate time.Time
+	EndDate   time.Time
+	Blocks    []Block
+}
+
+// Block — блок программы; EndTime — конец без техперерыва
+type Block struct {
+	Name      string
+	Track     string
+	StartTime time.Time
+	EndTime   time.Time
+	Items     []Item
+}
+
+// Item — элемент блока
+type Item struct {
+	Name      string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Options — режим отрисовки
+type Options struct {
+	// Embed убирает заголовок и фон страницы для встраивания в iframe
+	Embed bool
+	// Now — момент, по которому выделяется идущий блок; нулевое значение
+	// заменяется текущим временем. На открытой странице выделение дальше
+	// обновляет скрипт.
+	Now time.Time
+}
+
+// Render возвращает HTML-страницу расписания
+func Render(schedule *Schedule, options Options) ([]byte, error) {
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+	location := schedule.Location
+	if location == nil {
+		location = time.UTC
+	}
+	locale := locales[schedule.Language]
+	if locale == nil {
+		locale = locales[defaultLanguage]
+	}
+
+	view := pageView{
+		Language: locale.code,
+		Title:    schedule.Name,
+		Labels:   locale.labels,
+		Period:   locale.period(schedule.StartDate.In(location), schedule.EndDate.In(location)),
+		Timezone: location.String(),
+		Embed:    options.Embed,
+		Styles:   template.CSS(styles),
+		Script:   template.JS(script),
+	}
+
+	for _, block := range schedule.Blocks {
+		start := block.StartTime.In(location)
+		date := start.Format(time.DateOnly)
+		if len(view.Days) == 0 || view.Days[len(view.Days)-1].date != date {
+			view.Days = append(view.Days, dayView{date: date, Title: locale.day(start)})
+		}
+		day := &view.Days[len(view.Days)-1]
+
+		blockView := blockView{
+			Name:  block.Name,
+			Track: block.Track,
+			span:  newSpan(block.StartTime, block.EndTime, location, options.Now),
+		}
+		for _, item := range block.Items {
+			blockView.Items = append(blockView.Items, itemView{
+				Name: item.Name,
+				span: newSpan(item.StartTime, item.EndTime, location, options.Now),
+			})
+		}
+		day.Blocks = append(day.Blocks, blockView)
+	}
+
+	var buf bytes.Buffer
+	if err := pageTemplate.Execute(&buf, view); err != nil {
+		return nil, fmt.Errorf("failed to render schedule page: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+type pageView struct {
+	Language string
+	Title    string
+	Labels   labels
+	Period   string
+	Timezone string
+	Embed    bool
+	Styles   template.CSS
+	Script   template.JS
+	Days     []dayView
+}
+
+type dayView struct {
+	date   string
+	Title  string
+	Blocks []blockView
+}
+
+type blockView struct {
+	span
+	Name  string
+	Track string
+	Items []itemView
+}
+
+type itemView struct {
+	span
+	Name string
+}
+
+// span — интервал на странице: время для показа, метки RFC 3339 для скрипта
+// и состояние на момент отрисовки
+type span struct {
+	Start     string
+	End       string
+	StartAttr string
+	EndAttr   string
+	Current   bool
+	Past      bool
+}
+
+func newSpan(start, end time.Time, location *time.Location, now time.Time) span {
+	return span{
+		Start:     start.In(location).Format("15:04"),
+		End:       end.In(location).Format("15:04"),
+		StartAttr: start.UTC().Format(time.RFC3339),
+		EndAttr:   end.UTC().Format(time.RFC3339),
+		Current:   !now.Before(start) && now.Before(end),
+		Past:      !now.Before(end),
+	}
+}
@@ -0,0 +1,99 @@
+package web
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSchedule(language string, location *time.Location) *Schedule {
+	start := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+	return &Schedule{
+		Name:      "Фестиваль <весна>",
+		Language:  language,
+		Location:  location,
+		StartDate: start,
+		EndDate:   start.Add(26 * time.Hour),
+		Blocks: []Block{
+			{
+				Name: "Открытие", Track: "Главная сцена",
+				StartTime: start, EndTime: start.Add(30 * time.Minute),
+				Items: []Item{
+					{Name: "Приветствие", StartTime: start, EndTime: start.Add(10 * time.Minute)},
+					{Name: "Гимн", StartTime: start.Add(10 * time.Minute), EndTime: start.Add(15 * time.Minute)},
+				},
+			},
+			{Name: "Косплей", StartTime: start.Add(40 * time.Minute), EndTime: start.Add(100 * time.Minute)},
+			{Name: "Закрытие", StartTime: start.Add(25 * time.Hour), EndTime: start.Add(26 * time.Hour)},
+		},
+	}
+}
+
+func render(t *testing.T, schedule *Schedule, options Options) string {
+	t.Helper()
+	body, err := Render(schedule, options)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return string(body)
+}
+
+func TestRenderInScheduleLanguageAndZone(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	now := time.Date(2024, 4, 1, 10, 12, 0, 0, time.UTC)
+	page := render(t, newSchedule("", moscow), Options{Now: now})
+
+	for _, want := range []string{
+		`<html lang="ru">`,
+		"Фестиваль &lt;весна&gt;",
+		"1 апреля 2024 – 2 апреля 2024",
+		"1 апреля, понедельник",
+		"2 апреля, вторник",
+		// 10:00 UTC — 13:00 по Москве, а метки для скрипта остаются в UTC
+		`<time datetime="2024-04-01T10:00:00Z">13:00</time>–<time datetime="2024-04-01T10:30:00Z">13:30</time>`,
+		`<li class="block is-current" data-start="2024-04-01T10:00:00Z"`,
+		`<li class="item is-past" data-start="2024-04-01T10:00:00Z"`,
+		`<li class="item is-current" data-start="2024-04-01T10:10:00Z"`,
+		"Главная сцена",
+		"Время указано в часовом поясе Europe/Moscow",
+		"Распечатать",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page must contain %q", want)
+		}
+	}
+	if strings.Count(page, `class="block is-current"`) != 1 {
+		t.Error("exactly one block must be highlighted")
+	}
+}
+
+func TestRenderEnglishEmbed(t *testing.T) {
+	page := render(t, newSchedule("en", nil), Options{Embed: true, Now: time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC)})
+
+	for _, want := range []string{
+		`<html lang="en">`,
+		`<body class="embed">`,
+		"Monday, April 1",
+		"Times are shown in UTC",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page must contain %q", want)
+		}
+	}
+	if strings.Contains(page, "<header") || strings.Contains(page, `class="block is-current"`) {
+		t.Error("embedded page must have no header and a finished schedule no current block")
+	}
+}
+
+func TestRenderWithoutBlocks(t *testing.T) {
+	schedule := newSchedule("de", nil)
+	schedule.Blocks = nil
+
+	page := render(t, schedule, Options{})
+	if !strings.Contains(page, "Программа пока не опубликована") {
+		t.Error("unknown language must fall back to Russian and show the empty programme")
+	}
+}
@@ -0,0 +1,113 @@
+// Package ical формирует календари в формате iCalendar (RFC 5545), которые
+// можно подписать как ленту в календарных приложениях.
+package ical
+
+import (
+	"strings"
+	"time"
+)
+
+// ContentType — MIME-тип календаря
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineOctets — длина строки, после которой она переносится (RFC 5545, 3.1)
+const maxLineOctets = 75
+
+const timeFormat = "20060102T150405Z"
+
+// Calendar — календарь с событиями
+type Calendar struct {
+	// ProdID — идентификатор программы, создавшей календарь
+	ProdID string
+	// Name — название календаря, которое показывают календарные приложения
+	Name   string
+	Events []Event
+}
+
+// Event — событие календаря; времена записываются в UTC
+type Event struct {
+	// UID — постоянный идентификатор события, по которому приложения
+	// узнают его при обновлении ленты
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	// Stamp — время формирования события; нулевое значение заменяется текущим временем
+	Stamp time.Time
+}
+
+// Encode возвращает календарь в формате iCalendar
+func (c *Calendar) Encode() []byte {
+	var b strings.Builder
+	w := func(name, value string) {
+		writeLine(&b, name+":"+value)
+	}
+
+	w("BEGIN", "VCALENDAR")
+	w("VERSION", "2.0")
+	w("PRODID", escape(c.ProdID))
+	w("CALSCALE", "GREGORIAN")
+	if c.Name != "" {
+		w("X-WR-CALNAME", escape(c.Name))
+	}
+
+	now := time.Now()
+	for _, event := range c.Events {
+		stamp := event.Stamp
+		if stamp.IsZero() {
+			stamp = now
+		}
+
+		w("BEGIN", "VEVENT")
+		w("UID", escape(event.UID))
+		w("DTSTAMP", stamp.UTC().Format(timeFormat))
+		w("DTSTART", event.Start.UTC().Format(timeFormat))
+		w("DTEND", event.End.UTC().Format(timeFormat))
+		w("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			w("DESCRIPTION", escape(event.Description))
+		}
+		if event.Location != "" {
+			w("LOCATION", escape(event.Location))
+		}
+		w("END", "VEVENT")
+	}
+
+	w("END", "VCALENDAR")
+	return []byte(b.String())
+}
+
+// escape экранирует текстовое значение (RFC 5545, 3.3.11)
+func escape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// writeLine записывает строку с переносами по 75 байт, не разрывая символы UTF-8
+func writeLine(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Продолжение начинается с пробела, который входит в длину строки
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,52 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEncode(t *testing.T) {
+	start := time.Date(2024, 4, 1, 15, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	calendar := &Calendar{
+		ProdID: "-//cor-events-scheduler//RU",
+		Name:   "Хор",
+		Events: []Event{{
+			UID:         "item-7@cor-events-scheduler",
+			Start:       start,
+			End:         start.Add(30 * time.Minute),
+			Summary:     "Гимн; открытие, часть 1",
+			Description: "Строка 1\nСтрока 2",
+			Stamp:       start,
+		}},
+	}
+
+	encoded := string(calendar.Encode())
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20240401T120000Z\r\n",
+		"DTEND:20240401T123000Z\r\n",
+		`SUMMARY:Гимн\; открытие\, часть 1` + "\r\n",
+		`DESCRIPTION:Строка 1\nСтрока 2` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(encoded, want) {
+			t.Errorf("missing %q in\n%s", want, encoded)
+		}
+	}
+}
+
+func TestEncodeFoldsLongLines(t *testing.T) {
+	calendar := &Calendar{ProdID: "test", Events: []Event{{Summary: strings.Repeat("Выступление ", 20)}}}
+
+	for _, line := range strings.Split(strings.TrimSuffix(string(calendar.Encode()), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line is %d octets long: %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a character: %q", line)
+		}
+	}
+}
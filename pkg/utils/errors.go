@@ -5,6 +5,7 @@ import "errors"
 var (
 	ErrNotFound          = errors.New("resource not found")
 	ErrInvalidInput      = errors.New("invalid input")
+	ErrValidation        = errors.New("validation failed")
 	ErrConflict          = errors.New("resource conflict")
 	ErrDatabaseOperation = errors.New("database operation failed")
 	ErrInvalidTimeFormat = errors.New("invalid time format")
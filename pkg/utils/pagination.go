@@ -1,5 +1,11 @@
 package utils
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
 type PaginationParams struct {
 	Page     int
 	PageSize int
@@ -21,3 +27,34 @@ func (p *PaginationParams) GetLimit() int {
 	}
 	return p.PageSize
 }
+
+// cursor — содержимое непрозрачного курсора keyset-пагинации
+type cursor struct {
+	AfterID uint `json:"a"`
+}
+
+// EncodeCursor кодирует позицию после записи afterID в непрозрачную строку
+func EncodeCursor(afterID uint) string {
+	data, _ := json.Marshal(cursor{AfterID: afterID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor разбирает курсор, полученный от EncodeCursor.
+// Пустая строка означает первую страницу.
+func DecodeCursor(value string) (uint, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.AfterID == 0 {
+		return 0, fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+
+	return c.AfterID, nil
+}